@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"sync/atomic"
+)
+
+// Component is a part of the application with a lifecycle, like a server or a migration step.
+type Component interface {
+	// Start returns once the component is started. Work that keeps running must be done in the background.
+	Start(ctx context.Context) error
+
+	// Stop gracefully stops the component before the context is done.
+	Stop(ctx context.Context) error
+}
+
+// Monitored is implemented by components that can fail after they started. The Runner stops all
+// the components when a value is received on the channel. A nil error means the component exited
+// when it was not asked to, which is also a failure.
+type Monitored interface {
+	Failed() <-chan error
+}
+
+// Runner starts the components of the application, waits for a signal, the context, or a failure,
+// and then stops the components in the reverse order.
+//
+//	runner := app.New(
+//		app.WithComponent("migrations", migrations),
+//		app.WithComponent("http", app.Background(srv.Run, srv.Shutdown)),
+//	)
+//	if err := runner.Run(context.Background()); err != nil {
+//		logger.Fatal(err)
+//	}
+type Runner struct {
+	cfg *config
+	ran atomic.Bool
+}
+
+// New creates a Runner.
+func New(opts ...Option) *Runner {
+	return &Runner{
+		cfg: configure(opts...),
+	}
+}
+
+// Run starts the components in order. If a component fails to start, the components that started are stopped.
+// Once started, Run blocks until one of the signals is received, the context is done, or a Monitored component fails.
+// The errors of the failure and of stopping the components are returned joined together. A signal or the end of
+// the context is a graceful shutdown and is not an error.
+func (r *Runner) Run(ctx context.Context) error {
+	if r.ran.Swap(true) {
+		panic("The runner can only be run once.")
+	}
+
+	runCtx := ctx
+	if len(r.cfg.signals) > 0 {
+		var stopSignals context.CancelFunc
+		runCtx, stopSignals = signal.NotifyContext(ctx, r.cfg.signals...)
+		defer stopSignals()
+	}
+	runCtx, cancel := context.WithCancel(runCtx)
+	defer cancel()
+
+	failures := make(chan error, len(r.cfg.components))
+	started := make([]namedComponent, 0, len(r.cfg.components))
+	var failure error
+	for _, component := range r.cfg.components {
+		if err := r.start(runCtx, component); err != nil {
+			failure = err
+			break
+		}
+		started = append(started, component)
+		if monitored, isMonitored := component.component.(Monitored); isMonitored {
+			go forwardFailure(runCtx, component.name, monitored, failures)
+		}
+	}
+
+	if failure == nil {
+		select {
+		case <-runCtx.Done():
+		case failure = <-failures:
+		}
+	}
+	cancel()
+
+	errs := []error{failure}
+	for i := len(started) - 1; i >= 0; i-- {
+		errs = append(errs, r.stop(started[i]))
+	}
+	return errors.Join(errs...)
+}
+
+// start starts a component with the start timeout.
+func (r *Runner) start(ctx context.Context, component namedComponent) error {
+	startCtx, cancel := context.WithTimeout(ctx, r.cfg.startTimeout)
+	defer cancel()
+	if err := component.component.Start(startCtx); err != nil {
+		return fmt.Errorf("failed to start the component '%s' (%w)", component.name, err)
+	}
+	return nil
+}
+
+// stop stops a component with the stop timeout. It is not tied to the context of Run since it is done by then.
+func (r *Runner) stop(component namedComponent) error {
+	stopCtx, cancel := context.WithTimeout(context.Background(), r.cfg.stopTimeout)
+	defer cancel()
+	if err := component.component.Stop(stopCtx); err != nil {
+		return fmt.Errorf("failed to stop the component '%s' (%w)", component.name, err)
+	}
+	return nil
+}
+
+// forwardFailure sends the first failure of a Monitored component until the context is done.
+func forwardFailure(ctx context.Context, name string, monitored Monitored, failures chan<- error) {
+	select {
+	case err := <-monitored.Failed():
+		if err != nil {
+			failures <- fmt.Errorf("the component '%s' failed (%w)", name, err)
+		} else {
+			failures <- fmt.Errorf("the component '%s' exited unexpectedly", name)
+		}
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,187 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/app"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type eventLog struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]string{}, l.events...)
+}
+
+type testComponent struct {
+	name      string
+	log       *eventLog
+	startErr  error
+	stopErr   error
+	started   chan struct{}
+	blockStop bool
+}
+
+func (c *testComponent) Start(context.Context) error {
+	c.log.add("start " + c.name)
+	if c.started != nil {
+		close(c.started)
+	}
+	return c.startErr
+}
+
+func (c *testComponent) Stop(ctx context.Context) error {
+	c.log.add("stop " + c.name)
+	if c.blockStop {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.stopErr
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the context is done it should stop the components in reverse order", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		started := make(chan struct{})
+		runner := app.New(
+			app.WithSignals(),
+			app.WithComponent("first", &testComponent{name: "first", log: log}),
+			app.WithComponent("second", &testComponent{name: "second", log: log, started: started}),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- runner.Run(ctx)
+		}()
+		<-started
+		cancel()
+		assert.NoError(t, <-done)
+		assert.Equals(t, log.get(), []string{"start first", "start second", "stop second", "stop first"})
+	})
+
+	t.Run("when a component fails to start it should stop the components that started", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		runner := app.New(
+			app.WithSignals(),
+			app.WithComponent("first", &testComponent{name: "first", log: log}),
+			app.WithComponent("second", &testComponent{name: "second", log: log, startErr: errors.New("start error")}),
+			app.WithComponent("third", &testComponent{name: "third", log: log}),
+		)
+		err := runner.Run(context.Background())
+		assert.ErrorExact(t, err, "failed to start the component 'second' (start error)")
+		assert.Equals(t, log.get(), []string{"start first", "start second", "stop first"})
+	})
+
+	t.Run("when components fail to stop it should return all the errors", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		runner := app.New(
+			app.WithSignals(),
+			app.WithStopTimeout(time.Millisecond*10),
+			app.WithComponent("first", &testComponent{name: "first", log: log, stopErr: errors.New("stop error")}),
+			app.WithComponent("second", &testComponent{name: "second", log: log, blockStop: true}),
+		)
+		err := runner.Run(ctx)
+		assert.ErrorPart(t, err, "failed to stop the component 'first' (stop error)")
+		assert.ErrorPart(t, err, "failed to stop the component 'second' (context deadline exceeded)")
+		assert.Equals(t, log.get(), []string{"start first", "start second", "stop second", "stop first"})
+	})
+
+	t.Run("when a background component fails it should stop the other components", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		fail := make(chan error)
+		runner := app.New(
+			app.WithSignals(),
+			app.WithComponent("first", &testComponent{name: "first", log: log}),
+			app.WithComponent("server", app.Background(func() error {
+				return <-fail
+			}, func(context.Context) error {
+				log.add("stop server")
+				return nil
+			})),
+		)
+		done := make(chan error)
+		go func() {
+			done <- runner.Run(context.Background())
+		}()
+		fail <- errors.New("listener closed")
+		assert.ErrorExact(t, <-done, "the component 'server' failed (listener closed)")
+		assert.Equals(t, log.get(), []string{"start first", "stop server", "stop first"})
+	})
+
+	t.Run("when a background component exits without an error it should stop the other components", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		exit := make(chan struct{})
+		runner := app.New(
+			app.WithSignals(),
+			app.WithComponent("first", &testComponent{name: "first", log: log}),
+			app.WithComponent("server", app.Background(func() error {
+				<-exit
+				return nil
+			}, func(context.Context) error {
+				log.add("stop server")
+				return nil
+			})),
+		)
+		done := make(chan error)
+		go func() {
+			done <- runner.Run(context.Background())
+		}()
+		close(exit)
+		assert.ErrorExact(t, <-done, "the component 'server' exited unexpectedly")
+		assert.Equals(t, log.get(), []string{"start first", "stop server", "stop first"})
+	})
+
+	t.Run("when a signal is received it should stop the components", func(t *testing.T) {
+		t.Parallel()
+		log := &eventLog{}
+		started := make(chan struct{})
+		runner := app.New(
+			app.WithSignals(syscall.SIGUSR1),
+			app.WithComponent("first", &testComponent{name: "first", log: log, started: started}),
+		)
+		done := make(chan error)
+		go func() {
+			done <- runner.Run(context.Background())
+		}()
+		<-started
+		assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+		assert.NoError(t, <-done)
+		assert.Equals(t, log.get(), []string{"start first", "stop first"})
+	})
+
+	t.Run("when the runner is run twice it should panic", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		runner := app.New(app.WithSignals())
+		assert.NoError(t, runner.Run(ctx))
+		assert.PanicExact(t, func() {
+			_ = runner.Run(ctx)
+		}, "The runner can only be run once.")
+	})
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+)
+
+// background is a Component that calls a blocking function on its own goroutine.
+type background struct {
+	run      func() error
+	shutdown func(ctx context.Context) error
+	failed   chan error
+}
+
+// Background creates a Component out of a function that blocks while the component runs, like the Run
+// function of the HTTP server. Stop calls the shutdown function, which must make the run function return.
+// The run function returning before Stop is called fails the Runner, even without an error.
+func Background(run func() error, shutdown func(ctx context.Context) error) Component {
+	if run == nil || shutdown == nil {
+		panic("The run and shutdown functions cannot be nil.")
+	}
+	return &background{
+		run:      run,
+		shutdown: shutdown,
+		failed:   make(chan error, 1),
+	}
+}
+
+// Start calls the run function on a goroutine.
+func (b *background) Start(context.Context) error {
+	go func() {
+		b.failed <- b.run()
+	}()
+	return nil
+}
+
+// Stop calls the shutdown function.
+func (b *background) Stop(ctx context.Context) error {
+	return b.shutdown(ctx)
+}
+
+// Failed receives the result of the run function.
+func (b *background) Failed() <-chan error {
+	return b.failed
+}
@@ -0,0 +1,38 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/app"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestBackground(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the functions are nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			app.Background(nil, func(context.Context) error { return nil })
+		}, "The run and shutdown functions cannot be nil.")
+		assert.PanicExact(t, func() {
+			app.Background(func() error { return nil }, nil)
+		}, "The run and shutdown functions cannot be nil.")
+	})
+
+	t.Run("when it is started it should report the result of the run function", func(t *testing.T) {
+		t.Parallel()
+		component := app.Background(func() error {
+			return errors.New("run error")
+		}, func(context.Context) error {
+			return errors.New("shutdown error")
+		})
+		assert.NoError(t, component.Start(context.Background()))
+		monitored, isMonitored := component.(app.Monitored)
+		assert.True(t, isMonitored)
+		assert.ErrorExact(t, <-monitored.Failed(), "run error")
+		assert.ErrorExact(t, component.Stop(context.Background()), "shutdown error")
+	})
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultStartTimeout is the default time a component has to start.
+	defaultStartTimeout = time.Second * 30
+
+	// defaultStopTimeout is the default time a component has to stop.
+	defaultStopTimeout = time.Second * 30
+)
+
+// namedComponent is a Component with the name used in the errors.
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+// config holds the configuration of a Runner.
+type config struct {
+	components   []namedComponent
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	signals      []os.Signal
+}
+
+// Option configures a Runner.
+type Option func(*config)
+
+// WithComponent adds a component to the Runner. The components are started in the order they are added
+// and are stopped in the reverse order.
+func WithComponent(name string, component Component) Option {
+	return func(cfg *config) {
+		cfg.components = append(cfg.components, namedComponent{
+			name:      name,
+			component: component,
+		})
+	}
+}
+
+// WithStartTimeout sets the time each component has to start.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.startTimeout = timeout
+	}
+}
+
+// WithStopTimeout sets the time each component has to stop.
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.stopTimeout = timeout
+	}
+}
+
+// WithSignals sets the signals that stop the Runner. The default signals are SIGINT and SIGTERM.
+// Calling it without signals makes the Runner only stop when its context is done or a component fails.
+func WithSignals(signals ...os.Signal) Option {
+	return func(cfg *config) {
+		cfg.signals = signals
+	}
+}
+
+// configure creates a config out of the provided options and validates it.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		components:   make([]namedComponent, 0),
+		startTimeout: defaultStartTimeout,
+		stopTimeout:  defaultStopTimeout,
+		signals:      []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.startTimeout <= 0 {
+		panic("The start timeout must be greater than zero.")
+	}
+	if cfg.stopTimeout <= 0 {
+		panic("The stop timeout must be greater than zero.")
+	}
+	names := make(map[string]struct{}, len(cfg.components))
+	for _, component := range cfg.components {
+		if component.component == nil {
+			panic("The component cannot be nil.")
+		}
+		if _, duplicate := names[component.name]; duplicate {
+			panic("The component names must be unique.")
+		}
+		names[component.name] = struct{}{}
+	}
+	return cfg
+}
@@ -0,0 +1,33 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/app"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			opts     []app.Option
+			panicMsg string
+		}{
+			{[]app.Option{app.WithStartTimeout(0)}, "The start timeout must be greater than zero."},
+			{[]app.Option{app.WithStopTimeout(0)}, "The stop timeout must be greater than zero."},
+			{[]app.Option{app.WithComponent("nil", nil)}, "The component cannot be nil."},
+			{[]app.Option{
+				app.WithComponent("same", &testComponent{}),
+				app.WithComponent("same", &testComponent{}),
+			}, "The component names must be unique."},
+		}
+		for _, testCase := range testCases {
+			assert.PanicExact(t, func() {
+				app.New(testCase.opts...)
+			}, testCase.panicMsg)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package concurrency
+
+import (
+	"runtime"
+)
+
+// config holds the configuration of a Pool.
+type config struct {
+	workers   int
+	queueSize int
+	failFast  bool
+}
+
+// Option configures a Pool.
+type Option func(*config)
+
+// WithWorkers sets the number of tasks that run at the same time. The default is GOMAXPROCS.
+func WithWorkers(workers int) Option {
+	return func(cfg *config) {
+		cfg.workers = workers
+	}
+}
+
+// WithQueueSize sets the number of tasks that can be submitted without waiting for a worker.
+// The default is the number of workers.
+func WithQueueSize(queueSize int) Option {
+	return func(cfg *config) {
+		cfg.queueSize = queueSize
+	}
+}
+
+// WithFailFast cancels the context of the tasks when a task fails. The tasks that have not started are skipped.
+func WithFailFast() Option {
+	return func(cfg *config) {
+		cfg.failFast = true
+	}
+}
+
+// configure creates a config out of the provided options and validates it.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		workers:   runtime.GOMAXPROCS(0),
+		queueSize: -1,
+		failFast:  false,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.workers <= 0 {
+		panic("The number of workers must be greater than zero.")
+	}
+	if cfg.queueSize == -1 {
+		cfg.queueSize = cfg.workers
+	}
+	if cfg.queueSize < 0 {
+		panic("The queue size cannot be negative.")
+	}
+	return cfg
+}
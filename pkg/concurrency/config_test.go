@@ -0,0 +1,30 @@
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/concurrency"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			concurrency.NewPool(context.Background(), concurrency.WithWorkers(0))
+		}, "The number of workers must be greater than zero.")
+		assert.PanicExact(t, func() {
+			concurrency.NewPool(context.Background(), concurrency.WithQueueSize(-2))
+		}, "The queue size cannot be negative.")
+	})
+
+	t.Run("when the queue size is zero it should hand the tasks directly to the workers", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background(), concurrency.WithWorkers(1), concurrency.WithQueueSize(0))
+		assert.NoError(t, pool.Submit(func(context.Context) error { return nil }))
+		assert.NoError(t, pool.Wait())
+	})
+}
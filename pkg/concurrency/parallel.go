@@ -0,0 +1,40 @@
+package concurrency
+
+import (
+	"context"
+)
+
+// Map calls the function on each item on a Pool and returns the results in the order of the items.
+// The results of the items that failed or did not run are zero values.
+func Map[In any, Out any](ctx context.Context, items []In, fn func(ctx context.Context, item In) (Out, error), opts ...Option) ([]Out, error) {
+	results := make([]Out, len(items))
+	pool := NewPool(ctx, opts...)
+	for i, item := range items {
+		submitErr := pool.Submit(func(ctx context.Context) error {
+			result, err := fn(ctx, item)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			return nil
+		})
+		if submitErr != nil {
+			break
+		}
+	}
+	return results, pool.Wait()
+}
+
+// ForEach calls the function on each item on a Pool and returns the errors joined together.
+func ForEach[T any](ctx context.Context, items []T, fn func(ctx context.Context, item T) error, opts ...Option) error {
+	pool := NewPool(ctx, opts...)
+	for _, item := range items {
+		submitErr := pool.Submit(func(ctx context.Context) error {
+			return fn(ctx, item)
+		})
+		if submitErr != nil {
+			break
+		}
+	}
+	return pool.Wait()
+}
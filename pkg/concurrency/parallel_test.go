@@ -0,0 +1,75 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/concurrency"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the items are mapped it should return the results in order", func(t *testing.T) {
+		t.Parallel()
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		results, err := concurrency.Map(context.Background(), items, func(_ context.Context, item int) (string, error) {
+			return fmt.Sprintf("item-%d", item*item), nil
+		}, concurrency.WithWorkers(3))
+		assert.NoError(t, err)
+		assert.Equals(t, results, []string{"item-1", "item-4", "item-9", "item-16", "item-25", "item-36", "item-49", "item-64"})
+	})
+
+	t.Run("when an item fails it should return the error and the zero value for it", func(t *testing.T) {
+		t.Parallel()
+		results, err := concurrency.Map(context.Background(), []int{1, 2, 3}, func(_ context.Context, item int) (int, error) {
+			if item == 2 {
+				return item, errors.New("item error")
+			}
+			return item * 10, nil
+		})
+		assert.ErrorExact(t, err, "item error")
+		assert.Equals(t, results, []int{10, 0, 30})
+	})
+
+	t.Run("when there are no items it should return an empty result", func(t *testing.T) {
+		t.Parallel()
+		results, err := concurrency.Map(context.Background(), []int{}, func(_ context.Context, item int) (int, error) {
+			return item, nil
+		})
+		assert.NoError(t, err)
+		assert.Equals(t, len(results), 0)
+	})
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the function is called on each item it should visit all of them", func(t *testing.T) {
+		t.Parallel()
+		var sum atomic.Int64
+		err := concurrency.ForEach(context.Background(), []int64{1, 2, 3, 4}, func(_ context.Context, item int64) error {
+			sum.Add(item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equals(t, sum.Load(), int64(10))
+	})
+
+	t.Run("when the context is already canceled it should not call the function", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var called atomic.Bool
+		err := concurrency.ForEach(ctx, []int{1, 2, 3}, func(context.Context, int) error {
+			called.Store(true)
+			return nil
+		})
+		assert.ErrorExact(t, err, context.Canceled.Error())
+		assert.False(t, called.Load())
+	})
+}
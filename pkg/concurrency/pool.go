@@ -0,0 +1,127 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed is returned when a task is submitted to a Pool that is waiting or done.
+var ErrClosed = errors.New("the pool is closed")
+
+// Task is a unit of work run by a Pool. The context is done when the Pool is canceled.
+type Task func(ctx context.Context) error
+
+// Pool runs the submitted tasks on a bounded number of workers and collects their errors.
+//
+//	pool := concurrency.NewPool(ctx, concurrency.WithWorkers(4))
+//	for _, item := range items {
+//		if err := pool.Submit(func(ctx context.Context) error { return process(ctx, item) }); err != nil {
+//			break
+//		}
+//	}
+//	err := pool.Wait()
+type Pool struct {
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	failFast  bool
+	tasks     chan Task
+	closeLock sync.RWMutex
+	closed    bool
+	workers   sync.WaitGroup
+	errLock   sync.Mutex
+	errs      []error
+	failed    bool
+	waitOnce  sync.Once
+	waitErr   error
+}
+
+// NewPool creates a Pool and starts its workers. The tasks are canceled when the context is done.
+// Wait must be called to stop the workers.
+func NewPool(ctx context.Context, opts ...Option) *Pool {
+	cfg := configure(opts...)
+	poolCtx, cancel := context.WithCancelCause(ctx)
+	p := &Pool{
+		ctx:      poolCtx,
+		cancel:   cancel,
+		failFast: cfg.failFast,
+		tasks:    make(chan Task, cfg.queueSize),
+		errs:     make([]error, 0),
+	}
+	p.workers.Add(cfg.workers)
+	for range cfg.workers {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues the task. It waits for room in the queue, and returns an error if the Pool is closed or canceled.
+func (p *Pool) Submit(task Task) error {
+	p.closeLock.RLock()
+	defer p.closeLock.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	if err := p.ctx.Err(); err != nil {
+		return context.Cause(p.ctx)
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.ctx.Done():
+		return context.Cause(p.ctx)
+	}
+}
+
+// work runs the queued tasks until the queue is closed. Once the Pool is canceled, the remaining tasks are skipped.
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for task := range p.tasks {
+		if p.ctx.Err() != nil {
+			continue
+		}
+		if err := p.run(task); err != nil {
+			p.errLock.Lock()
+			p.errs = append(p.errs, err)
+			if p.failFast && !p.failed {
+				p.failed = true
+				p.cancel(err)
+			}
+			p.errLock.Unlock()
+		}
+	}
+}
+
+// run calls the task and converts a panic into an error.
+func (p *Pool) run(task Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the task panicked (%v)", r)
+		}
+	}()
+	return task(p.ctx)
+}
+
+// Wait stops accepting tasks, waits for the queued tasks to finish, and stops the workers.
+// It returns the errors of the tasks joined together. If the context of the Pool was canceled
+// before the tasks were done, its cause is also returned.
+func (p *Pool) Wait() error {
+	p.waitOnce.Do(func() {
+		p.closeLock.Lock()
+		p.closed = true
+		close(p.tasks)
+		p.closeLock.Unlock()
+		p.workers.Wait()
+
+		p.errLock.Lock()
+		defer p.errLock.Unlock()
+		errs := p.errs
+		if p.ctx.Err() != nil && !p.failed {
+			errs = append(errs, context.Cause(p.ctx))
+		}
+		p.waitErr = errors.Join(errs...)
+		p.cancel(ErrClosed)
+	})
+	return p.waitErr
+}
@@ -0,0 +1,125 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/concurrency"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when tasks are submitted it should run all of them before Wait returns", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background(), concurrency.WithWorkers(3))
+		var count atomic.Int64
+		for range 100 {
+			assert.NoError(t, pool.Submit(func(context.Context) error {
+				count.Add(1)
+				return nil
+			}))
+		}
+		assert.NoError(t, pool.Wait())
+		assert.Equals(t, count.Load(), int64(100))
+	})
+
+	t.Run("when tasks are submitted it should not run more than the number of workers at once", func(t *testing.T) {
+		t.Parallel()
+		const workers = 2
+		pool := concurrency.NewPool(context.Background(), concurrency.WithWorkers(workers))
+		var running atomic.Int64
+		var maxRunning atomic.Int64
+		for range 20 {
+			assert.NoError(t, pool.Submit(func(context.Context) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for observed := maxRunning.Load(); current > observed; observed = maxRunning.Load() {
+					if maxRunning.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				return nil
+			}))
+		}
+		assert.NoError(t, pool.Wait())
+		assert.LessOrEqual(t, maxRunning.Load(), int64(workers))
+	})
+
+	t.Run("when tasks fail it should return all of their errors", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background(), concurrency.WithWorkers(2))
+		assert.NoError(t, pool.Submit(func(context.Context) error { return errors.New("first error") }))
+		assert.NoError(t, pool.Submit(func(context.Context) error { return nil }))
+		assert.NoError(t, pool.Submit(func(context.Context) error { return errors.New("second error") }))
+		err := pool.Wait()
+		assert.ErrorPart(t, err, "first error")
+		assert.ErrorPart(t, err, "second error")
+	})
+
+	t.Run("when a task panics it should return the panic as an error", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background())
+		assert.NoError(t, pool.Submit(func(context.Context) error { panic("task failure") }))
+		assert.ErrorExact(t, pool.Wait(), "the task panicked (task failure)")
+	})
+
+	t.Run("when fail fast is set and a task fails it should cancel the other tasks", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background(), concurrency.WithWorkers(1), concurrency.WithQueueSize(10), concurrency.WithFailFast())
+		started := make(chan struct{})
+		release := make(chan struct{})
+		assert.NoError(t, pool.Submit(func(context.Context) error {
+			close(started)
+			<-release
+			return errors.New("task error")
+		}))
+		<-started
+		var skippedRan atomic.Bool
+		for range 5 {
+			assert.NoError(t, pool.Submit(func(context.Context) error {
+				skippedRan.Store(true)
+				return nil
+			}))
+		}
+		close(release)
+		assert.ErrorExact(t, pool.Wait(), "task error")
+		assert.False(t, skippedRan.Load())
+	})
+
+	t.Run("when the context is canceled it should skip the queued tasks and return the cause", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		pool := concurrency.NewPool(ctx, concurrency.WithWorkers(1), concurrency.WithQueueSize(10))
+		started := make(chan struct{})
+		assert.NoError(t, pool.Submit(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		}))
+		<-started
+		var skippedRan atomic.Bool
+		assert.NoError(t, pool.Submit(func(context.Context) error {
+			skippedRan.Store(true)
+			return nil
+		}))
+		cancel()
+		assert.ErrorExact(t, pool.Submit(func(context.Context) error { return nil }), context.Canceled.Error())
+		assert.ErrorExact(t, pool.Wait(), context.Canceled.Error())
+		assert.False(t, skippedRan.Load())
+	})
+
+	t.Run("when Wait is called it should reject new tasks and return the same result", func(t *testing.T) {
+		t.Parallel()
+		pool := concurrency.NewPool(context.Background())
+		assert.NoError(t, pool.Submit(func(context.Context) error { return errors.New("task error") }))
+		assert.ErrorExact(t, pool.Wait(), "task error")
+		assert.ErrorExact(t, pool.Wait(), "task error")
+		assert.ErrorExact(t, pool.Submit(func(context.Context) error { return nil }), concurrency.ErrClosed.Error())
+	})
+}
@@ -0,0 +1,77 @@
+package contextutil
+
+import (
+	"context"
+	"time"
+)
+
+// Detach returns a context with the values of the parent that is not canceled when the parent is.
+// It is meant for background work started by a request that must outlive the request.
+func Detach(parent context.Context) context.Context {
+	return context.WithoutCancel(parent)
+}
+
+// mergedContext looks up the values in the primary context and then in the others.
+type mergedContext struct {
+	context.Context
+	others []context.Context
+}
+
+// Value returns the first value found for the key in the contexts.
+func (c *mergedContext) Value(key any) any {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	for _, other := range c.others {
+		if value := other.Value(key); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// Merge returns a context that is done when any of the contexts is done, with the cause of the first one done.
+// Its deadline is the earliest deadline of the contexts, and its values are looked up in the contexts in order.
+// The cancel function must be called to release the resources once the context is no longer used.
+func Merge(ctxs ...context.Context) (context.Context, context.CancelFunc) {
+	if len(ctxs) == 0 {
+		panic("At least one context must be merged.")
+	}
+	primary := ctxs[0]
+	others := ctxs[1:]
+
+	var deadlineCancel context.CancelFunc = func() {}
+	if deadline, hasDeadline := earliestDeadline(others); hasDeadline {
+		if primaryDeadline, primaryHasDeadline := primary.Deadline(); !primaryHasDeadline || deadline.Before(primaryDeadline) {
+			primary, deadlineCancel = context.WithDeadline(primary, deadline)
+		}
+	}
+
+	merged, cancel := context.WithCancelCause(primary)
+	stops := make([]func() bool, 0, len(others))
+	for _, other := range others {
+		stops = append(stops, context.AfterFunc(other, func() {
+			cancel(context.Cause(other))
+		}))
+	}
+	return &mergedContext{Context: merged, others: others}, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel(context.Canceled)
+		deadlineCancel()
+	}
+}
+
+// earliestDeadline returns the earliest deadline of the contexts, or false if none of them has one.
+func earliestDeadline(ctxs []context.Context) (time.Time, bool) {
+	var earliest time.Time
+	found := false
+	for _, ctx := range ctxs {
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && (!found || deadline.Before(earliest)) {
+			earliest = deadline
+			found = true
+		}
+	}
+	return earliest, found
+}
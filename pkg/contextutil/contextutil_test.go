@@ -0,0 +1,115 @@
+package contextutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/contextutil"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type contextKey string
+
+func TestDetach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the parent is canceled it should keep the values but not be canceled", func(t *testing.T) {
+		t.Parallel()
+		parent, cancel := context.WithTimeout(context.WithValue(context.Background(), contextKey("key"), "value"), time.Hour)
+		detached := contextutil.Detach(parent)
+		cancel()
+		assert.NoError(t, detached.Err())
+		assert.Equals(t, detached.Value(contextKey("key")), any("value"))
+		_, hasDeadline := detached.Deadline()
+		assert.False(t, hasDeadline)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when no contexts are merged it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			contextutil.Merge()
+		}, "At least one context must be merged.")
+	})
+
+	t.Run("when any of the contexts is canceled it should be done with its cause", func(t *testing.T) {
+		t.Parallel()
+		first, cancelFirst := context.WithCancel(context.Background())
+		defer cancelFirst()
+		second, cancelSecond := context.WithCancelCause(context.Background())
+		merged, cancel := contextutil.Merge(first, second)
+		defer cancel()
+		assert.NoError(t, merged.Err())
+		cancelSecond(errors.New("shutting down"))
+		<-merged.Done()
+		assert.ErrorExact(t, merged.Err(), context.Canceled.Error())
+		assert.ErrorExact(t, context.Cause(merged), "shutting down")
+		assert.NoError(t, first.Err())
+	})
+
+	t.Run("when the primary context is canceled it should be done", func(t *testing.T) {
+		t.Parallel()
+		first, cancelFirst := context.WithCancel(context.Background())
+		merged, cancel := contextutil.Merge(first, context.Background())
+		defer cancel()
+		cancelFirst()
+		<-merged.Done()
+		assert.ErrorExact(t, merged.Err(), context.Canceled.Error())
+	})
+
+	t.Run("when the contexts have deadlines it should have the earliest one", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		late, cancelLate := context.WithDeadline(context.Background(), now.Add(time.Hour))
+		defer cancelLate()
+		early, cancelEarly := context.WithDeadline(context.Background(), now.Add(time.Minute))
+		defer cancelEarly()
+		for _, ctxs := range [][]context.Context{{late, early}, {early, late}, {context.Background(), early}} {
+			merged, cancel := contextutil.Merge(ctxs...)
+			deadline, hasDeadline := merged.Deadline()
+			assert.True(t, hasDeadline)
+			assert.Equals(t, deadline, now.Add(time.Minute))
+			cancel()
+		}
+		merged, cancel := contextutil.Merge(context.Background(), context.Background())
+		defer cancel()
+		_, hasDeadline := merged.Deadline()
+		assert.False(t, hasDeadline)
+	})
+
+	t.Run("when a value is looked up it should search the contexts in order", func(t *testing.T) {
+		t.Parallel()
+		first := context.WithValue(context.Background(), contextKey("shared"), "first")
+		second := context.WithValue(context.WithValue(context.Background(), contextKey("shared"), "second"), contextKey("other"), "other")
+		merged, cancel := contextutil.Merge(first, second)
+		defer cancel()
+		assert.Equals(t, merged.Value(contextKey("shared")), any("first"))
+		assert.Equals(t, merged.Value(contextKey("other")), any("other"))
+		assert.Nil(t, merged.Value(contextKey("missing")))
+	})
+
+	t.Run("when the cancel function is called it should be canceled and stop following the contexts", func(t *testing.T) {
+		t.Parallel()
+		merged, cancel := contextutil.Merge(context.Background(), context.Background())
+		cancel()
+		<-merged.Done()
+		assert.ErrorExact(t, merged.Err(), context.Canceled.Error())
+	})
+
+	t.Run("when a child is derived from the merged context it should be canceled with it", func(t *testing.T) {
+		t.Parallel()
+		other, cancelOther := context.WithCancel(context.Background())
+		merged, cancel := contextutil.Merge(context.Background(), other)
+		defer cancel()
+		child, cancelChild := context.WithCancel(merged)
+		defer cancelChild()
+		cancelOther()
+		<-child.Done()
+		assert.ErrorExact(t, child.Err(), context.Canceled.Error())
+	})
+}
@@ -0,0 +1,56 @@
+package contextutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// Key is a typed key for context values. Each Key is unique, so values cannot collide between packages,
+// and the values do not need to be cast.
+//
+//	var requestIDKey = contextutil.NewKey[string]("requestID")
+//	ctx = requestIDKey.WithValue(ctx, "abc")
+//	requestID, found := requestIDKey.Value(ctx)
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates a Key. The name is only used for debugging.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{
+		name: name,
+	}
+}
+
+// String returns the name of the Key.
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// WithValue returns a copy of the context with the value set for the Key.
+func (k *Key[T]) WithValue(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Value returns the value of the Key in the context, or false if it is not set.
+func (k *Key[T]) Value(ctx context.Context) (T, bool) {
+	value, found := ctx.Value(k).(T)
+	return value, found
+}
+
+// ValueOr returns the value of the Key in the context, or the fallback if it is not set.
+func (k *Key[T]) ValueOr(ctx context.Context, fallback T) T {
+	if value, found := k.Value(ctx); found {
+		return value
+	}
+	return fallback
+}
+
+// MustValue returns the value of the Key in the context, and panics if it is not set.
+func (k *Key[T]) MustValue(ctx context.Context) T {
+	value, found := k.Value(ctx)
+	if !found {
+		panic(fmt.Sprintf("The context does not have a value for the key %s.", k.name))
+	}
+	return value
+}
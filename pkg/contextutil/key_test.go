@@ -0,0 +1,46 @@
+package contextutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/contextutil"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a value is set it should be returned with its type", func(t *testing.T) {
+		t.Parallel()
+		key := contextutil.NewKey[int]("count")
+		assert.Equals(t, key.String(), "count")
+		ctx := key.WithValue(context.Background(), 5)
+		value, found := key.Value(ctx)
+		assert.True(t, found)
+		assert.Equals(t, value, 5)
+		assert.Equals(t, key.ValueOr(ctx, 1), 5)
+		assert.Equals(t, key.MustValue(ctx), 5)
+	})
+
+	t.Run("when a value is not set it should not be found", func(t *testing.T) {
+		t.Parallel()
+		key := contextutil.NewKey[string]("name")
+		value, found := key.Value(context.Background())
+		assert.False(t, found)
+		assert.Equals(t, value, "")
+		assert.Equals(t, key.ValueOr(context.Background(), "fallback"), "fallback")
+		assert.PanicExact(t, func() {
+			key.MustValue(context.Background())
+		}, "The context does not have a value for the key name.")
+	})
+
+	t.Run("when keys have the same name and type it should not collide", func(t *testing.T) {
+		t.Parallel()
+		first := contextutil.NewKey[string]("name")
+		second := contextutil.NewKey[string]("name")
+		ctx := first.WithValue(context.Background(), "first")
+		_, found := second.Value(ctx)
+		assert.False(t, found)
+	})
+}
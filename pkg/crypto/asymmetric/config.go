@@ -0,0 +1,32 @@
+package asymmetric
+
+import (
+	"crypto/rand"
+	"io"
+)
+
+// config is the configuration for the asymmetric operations.
+type config struct {
+	random io.Reader
+}
+
+// Option is optional configuration of the asymmetric operations.
+type Option func(*config)
+
+// WithRandomReader overwrites the source of randomness used for key generation, padding, nonces, and signatures.
+func WithRandomReader(random io.Reader) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		random: rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
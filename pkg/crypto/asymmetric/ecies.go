@@ -0,0 +1,114 @@
+package asymmetric
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// eciesInfo binds the derived keys to this scheme.
+const eciesInfo = "GoTools ECIES AES-256-GCM"
+
+// EncryptECIES encrypts the plaintext for the holder of the private key of the recipient.
+// An ephemeral key is agreed with the recipient key, and the shared secret is stretched with HKDF-SHA256
+// into an AES-256-GCM key. The output is the ephemeral public key, followed by the nonce and the sealed data.
+func EncryptECIES(recipient *ecdh.PublicKey, plaintext []byte, opts ...Option) ([]byte, error) {
+	if recipient == nil {
+		return nil, errors.New("the public key cannot be nil")
+	}
+	cfg := configure(opts...)
+
+	ephemeral, err := recipient.Curve().GenerateKey(cfg.random)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the ephemeral key (%w)", err)
+	}
+	aead, err := eciesAEAD(ephemeral, recipient, ephemeral.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralBytes := ephemeral.PublicKey().Bytes()
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(cfg.random, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate the nonce (%w)", err)
+	}
+
+	out := make([]byte, 0, len(ephemeralBytes)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, ephemeralBytes...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, ephemeralBytes), nil
+}
+
+// DecryptECIES decrypts a ciphertext that was encrypted with EncryptECIES.
+func DecryptECIES(privateKey *ecdh.PrivateKey, ciphertext []byte) ([]byte, error) {
+	if privateKey == nil {
+		return nil, errors.New("the private key cannot be nil")
+	}
+
+	publicKeySize := len(privateKey.PublicKey().Bytes())
+	if len(ciphertext) < publicKeySize {
+		return nil, fmt.Errorf("cipher-text of len %d is shorter then the minimum length of %d", len(ciphertext), publicKeySize)
+	}
+	ephemeralBytes := ciphertext[:publicKeySize]
+	ephemeral, err := privateKey.Curve().NewPublicKey(ephemeralBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the ephemeral public key (%w)", err)
+	}
+
+	aead, err := eciesAEAD(privateKey, ephemeral, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	rest := ciphertext[publicKeySize:]
+	if len(rest) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("cipher-text of len %d is shorter then the minimum length of %d", len(ciphertext), publicKeySize+aead.NonceSize()+aead.Overhead())
+	}
+
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], ephemeralBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the data (%w)", err)
+	}
+	return plaintext, nil
+}
+
+// eciesAEAD agrees on a shared secret between the keys and derives the AES-256-GCM cipher from it.
+func eciesAEAD(privateKey *ecdh.PrivateKey, peer *ecdh.PublicKey, ephemeral *ecdh.PublicKey) (cipher.AEAD, error) {
+	secret, err := privateKey.ECDH(peer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree on a shared secret (%w)", err)
+	}
+	key := hkdfSHA256(secret, ephemeral.Bytes(), []byte(eciesInfo), 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the block cipher (%w)", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GCM cipher (%w)", err)
+	}
+	return aead, nil
+}
+
+// hkdfSHA256 derives a key of the requested length with HKDF (RFC 5869) using SHA-256.
+func hkdfSHA256(secret []byte, salt []byte, info []byte, length int) []byte {
+	extractor := hmac.New(sha256.New, salt)
+	extractor.Write(secret)
+	pseudoRandomKey := extractor.Sum(nil)
+
+	out := make([]byte, 0, length+sha256.Size)
+	var previous []byte
+	for counter := byte(1); len(out) < length; counter++ {
+		expander := hmac.New(sha256.New, pseudoRandomKey)
+		expander.Write(previous)
+		expander.Write(info)
+		expander.Write([]byte{counter})
+		previous = expander.Sum(nil)
+		out = append(out, previous...)
+	}
+	return out[:length]
+}
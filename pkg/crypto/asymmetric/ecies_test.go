@@ -0,0 +1,108 @@
+package asymmetric_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/asymmetric"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestECIES(t *testing.T) {
+	t.Parallel()
+
+	curves := map[string]ecdh.Curve{
+		"X25519": ecdh.X25519(),
+		"P256":   ecdh.P256(),
+		"P384":   ecdh.P384(),
+		"P521":   ecdh.P521(),
+	}
+
+	for name, curve := range curves {
+		t.Run("when data is encrypted with the "+name+" curve it should be decrypted", func(t *testing.T) {
+			t.Parallel()
+			privateKey, err := curve.GenerateKey(rand.Reader)
+			assert.NoError(t, err)
+			for _, plaintext := range [][]byte{{}, []byte("a"), make([]byte, 4096)} {
+				ciphertext, err := asymmetric.EncryptECIES(privateKey.PublicKey(), plaintext)
+				assert.NoError(t, err)
+				decrypted, err := asymmetric.DecryptECIES(privateKey, ciphertext)
+				assert.NoError(t, err)
+				assert.Equals(t, len(decrypted), len(plaintext))
+				assert.Equals(t, string(decrypted), string(plaintext))
+			}
+		})
+	}
+
+	t.Run("when the same data is encrypted twice it should have different cipher-text", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		first, err := asymmetric.EncryptECIES(privateKey.PublicKey(), []byte("data"))
+		assert.NoError(t, err)
+		second, err := asymmetric.EncryptECIES(privateKey.PublicKey(), []byte("data"))
+		assert.NoError(t, err)
+		assert.NotEquals(t, first, second)
+	})
+
+	t.Run("when the cipher-text is decrypted with another key it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		otherKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		ciphertext, err := asymmetric.EncryptECIES(privateKey.PublicKey(), []byte("data"))
+		assert.NoError(t, err)
+		decrypted, err := asymmetric.DecryptECIES(otherKey, ciphertext)
+		assert.ErrorPart(t, err, "failed to decrypt the data")
+		assert.Nil(t, decrypted)
+	})
+
+	t.Run("when the cipher-text is tampered with it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		ciphertext, err := asymmetric.EncryptECIES(privateKey.PublicKey(), []byte("data"))
+		assert.NoError(t, err)
+		ciphertext[len(ciphertext)-1] ^= 0xFF
+		_, err = asymmetric.DecryptECIES(privateKey, ciphertext)
+		assert.ErrorPart(t, err, "failed to decrypt the data")
+	})
+
+	t.Run("when the cipher-text is too short it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		_, err = asymmetric.DecryptECIES(privateKey, []byte{1, 2, 3})
+		assert.ErrorPart(t, err, "shorter then the minimum length of 32")
+		ciphertext, err := asymmetric.EncryptECIES(privateKey.PublicKey(), nil)
+		assert.NoError(t, err)
+		_, err = asymmetric.DecryptECIES(privateKey, ciphertext[:40])
+		assert.ErrorPart(t, err, "shorter then the minimum length of 60")
+	})
+
+	t.Run("when the ephemeral public key is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		_, err = asymmetric.DecryptECIES(privateKey, make([]byte, 100))
+		assert.ErrorPart(t, err, "failed to parse the ephemeral public key")
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		_, err = asymmetric.EncryptECIES(privateKey.PublicKey(), []byte("data"), asymmetric.WithRandomReader(failingReader{}))
+		assert.ErrorPart(t, err, "failed to generate the ephemeral key (random reader error)")
+	})
+
+	t.Run("when the keys are nil it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := asymmetric.EncryptECIES(nil, []byte("data"))
+		assert.ErrorExact(t, err, "the public key cannot be nil")
+		_, err = asymmetric.DecryptECIES(nil, []byte("data"))
+		assert.ErrorExact(t, err, "the private key cannot be nil")
+	})
+}
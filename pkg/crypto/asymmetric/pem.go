@@ -0,0 +1,116 @@
+package asymmetric
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// privateKeyBlockType is the PEM block type of PKCS #8 private keys.
+	privateKeyBlockType = "PRIVATE KEY"
+
+	// publicKeyBlockType is the PEM block type of PKIX public keys.
+	publicKeyBlockType = "PUBLIC KEY"
+)
+
+// MarshalPrivateKeyPEM encodes an RSA, ECDSA, Ed25519, or ECDH private key as a PKCS #8 PEM block.
+func MarshalPrivateKeyPEM(privateKey crypto.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the private key (%w)", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: privateKeyBlockType, Bytes: der}), nil
+}
+
+// ParsePrivateKeyPEM decodes a PKCS #8 PEM block into a private key.
+func ParsePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	der, err := decodePEM(data, privateKeyBlockType)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key (%w)", err)
+	}
+	return privateKey, nil
+}
+
+// MarshalPublicKeyPEM encodes an RSA, ECDSA, Ed25519, or ECDH public key as a PKIX PEM block.
+func MarshalPublicKeyPEM(publicKey crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the public key (%w)", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyBlockType, Bytes: der}), nil
+}
+
+// ParsePublicKeyPEM decodes a PKIX PEM block into a public key.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	der, err := decodePEM(data, publicKeyBlockType)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key (%w)", err)
+	}
+	return publicKey, nil
+}
+
+// SavePrivateKey writes the private key as PEM to a file that only the owner can read.
+func SavePrivateKey(path string, privateKey crypto.PrivateKey) error {
+	data, err := MarshalPrivateKeyPEM(privateKey)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write the private key (%w)", err)
+	}
+	return nil
+}
+
+// LoadPrivateKey reads a PEM private key from a file.
+func LoadPrivateKey(path string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the private key (%w)", err)
+	}
+	return ParsePrivateKeyPEM(data)
+}
+
+// SavePublicKey writes the public key as PEM to a file.
+func SavePublicKey(path string, publicKey crypto.PublicKey) error {
+	data, err := MarshalPublicKeyPEM(publicKey)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write the public key (%w)", err)
+	}
+	return nil
+}
+
+// LoadPublicKey reads a PEM public key from a file.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the public key (%w)", err)
+	}
+	return ParsePublicKeyPEM(data)
+}
+
+// decodePEM returns the bytes of the first PEM block in the data, which must have the expected type.
+func decodePEM(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("PEM block type %q is not %q", block.Type, blockType)
+	}
+	return block.Bytes, nil
+}
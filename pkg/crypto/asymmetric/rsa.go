@@ -0,0 +1,34 @@
+package asymmetric
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// EncryptOAEP encrypts the plaintext with RSA-OAEP using SHA-256.
+// The label is not secret, but the same label must be given to DecryptOAEP.
+func EncryptOAEP(publicKey *rsa.PublicKey, plaintext []byte, label []byte, opts ...Option) ([]byte, error) {
+	if publicKey == nil {
+		return nil, errors.New("the public key cannot be nil")
+	}
+	cfg := configure(opts...)
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), cfg.random, publicKey, plaintext, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt with RSA-OAEP (%w)", err)
+	}
+	return ciphertext, nil
+}
+
+// DecryptOAEP decrypts a ciphertext that was encrypted with EncryptOAEP.
+func DecryptOAEP(privateKey *rsa.PrivateKey, ciphertext []byte, label []byte) ([]byte, error) {
+	if privateKey == nil {
+		return nil, errors.New("the private key cannot be nil")
+	}
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), nil, privateKey, ciphertext, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with RSA-OAEP (%w)", err)
+	}
+	return plaintext, nil
+}
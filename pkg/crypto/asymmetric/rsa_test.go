@@ -0,0 +1,68 @@
+package asymmetric_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/asymmetric"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// failingReader is a source of randomness that always fails.
+type failingReader struct{}
+
+// Read returns an error.
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("random reader error")
+}
+
+func TestRSAOAEP(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	t.Run("when data is encrypted it should be decrypted with the same label", func(t *testing.T) {
+		t.Parallel()
+		plaintext := []byte("secret data")
+		ciphertext, err := asymmetric.EncryptOAEP(&privateKey.PublicKey, plaintext, []byte("label"))
+		assert.NoError(t, err)
+		assert.NotEquals(t, ciphertext, plaintext)
+		decrypted, err := asymmetric.DecryptOAEP(privateKey, ciphertext, []byte("label"))
+		assert.NoError(t, err)
+		assert.Equals(t, decrypted, plaintext)
+	})
+
+	t.Run("when the label is different it should fail to decrypt", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := asymmetric.EncryptOAEP(&privateKey.PublicKey, []byte("secret data"), []byte("label"))
+		assert.NoError(t, err)
+		decrypted, err := asymmetric.DecryptOAEP(privateKey, ciphertext, []byte("other"))
+		assert.ErrorPart(t, err, "failed to decrypt with RSA-OAEP")
+		assert.Nil(t, decrypted)
+	})
+
+	t.Run("when the data is too large for the key it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := asymmetric.EncryptOAEP(&privateKey.PublicKey, make([]byte, 1024), nil)
+		assert.ErrorPart(t, err, "failed to encrypt with RSA-OAEP")
+		assert.Nil(t, ciphertext)
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := asymmetric.EncryptOAEP(&privateKey.PublicKey, []byte("data"), nil, asymmetric.WithRandomReader(failingReader{}))
+		assert.ErrorPart(t, err, "random reader error")
+		assert.Nil(t, ciphertext)
+	})
+
+	t.Run("when the keys are nil it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := asymmetric.EncryptOAEP(nil, []byte("data"), nil)
+		assert.ErrorExact(t, err, "the public key cannot be nil")
+		_, err = asymmetric.DecryptOAEP(nil, []byte("data"), nil)
+		assert.ErrorExact(t, err, "the private key cannot be nil")
+	})
+}
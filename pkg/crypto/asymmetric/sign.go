@@ -0,0 +1,48 @@
+package asymmetric
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// Sign signs the data with an Ed25519 or ECDSA private key.
+// ECDSA signatures are over the SHA-256 digest of the data and are ASN.1 encoded.
+func Sign(privateKey crypto.PrivateKey, data []byte, opts ...Option) ([]byte, error) {
+	cfg := configure(opts...)
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, data), nil
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		signature, err := ecdsa.SignASN1(cfg.random, key, digest[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign with ECDSA (%w)", err)
+		}
+		return signature, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+}
+
+// Verify checks that the signature of the data was made by the private key of the Ed25519 or ECDSA public key.
+func Verify(publicKey crypto.PublicKey, data []byte, signature []byte) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
@@ -0,0 +1,56 @@
+package asymmetric_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/asymmetric"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when data is signed with Ed25519 it should be verified", func(t *testing.T) {
+		t.Parallel()
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		signature, err := asymmetric.Sign(privateKey, []byte("data"))
+		assert.NoError(t, err)
+		assert.NoError(t, asymmetric.Verify(publicKey, []byte("data"), signature))
+		assert.ErrorExact(t, asymmetric.Verify(publicKey, []byte("other"), signature), "invalid Ed25519 signature")
+	})
+
+	t.Run("when data is signed with ECDSA it should be verified", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		signature, err := asymmetric.Sign(privateKey, []byte("data"))
+		assert.NoError(t, err)
+		assert.NoError(t, asymmetric.Verify(&privateKey.PublicKey, []byte("data"), signature))
+		assert.ErrorExact(t, asymmetric.Verify(&privateKey.PublicKey, []byte("other"), signature), "invalid ECDSA signature")
+	})
+
+	t.Run("when the ECDSA random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		signature, err := asymmetric.Sign(privateKey, []byte("data"), asymmetric.WithRandomReader(failingReader{}))
+		assert.ErrorPart(t, err, "failed to sign with ECDSA")
+		assert.Nil(t, signature)
+	})
+
+	t.Run("when the key type is not supported it should return an error", func(t *testing.T) {
+		t.Parallel()
+		privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		assert.NoError(t, err)
+		_, err = asymmetric.Sign(privateKey, []byte("data"))
+		assert.ErrorExact(t, err, "unsupported private key type *rsa.PrivateKey")
+		err = asymmetric.Verify(&privateKey.PublicKey, []byte("data"), nil)
+		assert.ErrorExact(t, err, "unsupported public key type *rsa.PublicKey")
+	})
+}
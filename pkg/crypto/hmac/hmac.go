@@ -0,0 +1,114 @@
+package hmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// Algorithm is the hash function of an HMAC.
+type Algorithm string
+
+const (
+	// SHA256 selects HMAC-SHA256.
+	SHA256 Algorithm = "SHA256"
+
+	// SHA384 selects HMAC-SHA384.
+	SHA384 Algorithm = "SHA384"
+
+	// SHA512 selects HMAC-SHA512.
+	SHA512 Algorithm = "SHA512"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the data.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ParseAlgorithm returns the Algorithm with the given name. The name is not case-sensitive.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	algorithm := Algorithm(strings.ToUpper(name))
+	if _, err := algorithm.hashFunc(); err != nil {
+		return "", err
+	}
+	return algorithm, nil
+}
+
+// hashFunc returns the constructor of the hash function of the algorithm.
+func (algorithm Algorithm) hashFunc() (func() hash.Hash, error) {
+	switch algorithm {
+	case SHA256:
+		return sha256.New, nil
+	case SHA384:
+		return sha512.New384, nil
+	case SHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported HMAC algorithm %q", algorithm)
+	}
+}
+
+// New returns an HMAC hash that can be written to incrementally.
+func New(algorithm Algorithm, key []byte) (hash.Hash, error) {
+	hashFunc, err := algorithm.hashFunc()
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, errors.New("the key cannot be empty")
+	}
+	return hmac.New(hashFunc, key), nil
+}
+
+// Sign returns the HMAC of the data.
+func Sign(algorithm Algorithm, key []byte, data []byte) ([]byte, error) {
+	mac, err := New(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// SignReader returns the HMAC of all the data in the reader without holding it in memory.
+func SignReader(algorithm Algorithm, key []byte, reader io.Reader) ([]byte, error) {
+	mac, err := New(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(mac, reader); err != nil {
+		return nil, fmt.Errorf("failed to read the data (%w)", err)
+	}
+	return mac.Sum(nil), nil
+}
+
+// Verify checks in constant time that the signature is the HMAC of the data.
+// It returns ErrInvalidSignature if it is not.
+func Verify(algorithm Algorithm, key []byte, data []byte, signature []byte) error {
+	expected, err := Sign(algorithm, key, data)
+	if err != nil {
+		return err
+	}
+	return compare(expected, signature)
+}
+
+// VerifyReader checks in constant time that the signature is the HMAC of all the data in the reader.
+// It returns ErrInvalidSignature if it is not.
+func VerifyReader(algorithm Algorithm, key []byte, reader io.Reader, signature []byte) error {
+	expected, err := SignReader(algorithm, key, reader)
+	if err != nil {
+		return err
+	}
+	return compare(expected, signature)
+}
+
+// compare checks in constant time that the signatures are equal.
+func compare(expected []byte, signature []byte) error {
+	if !hmac.Equal(expected, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
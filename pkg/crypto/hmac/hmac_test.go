@@ -0,0 +1,101 @@
+package hmac_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/hmac"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestHMAC(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("key")
+	data := []byte("The quick brown fox jumps over the lazy dog")
+
+	t.Run("when data is signed it should match the known HMAC values", func(t *testing.T) {
+		t.Parallel()
+		testCases := map[hmac.Algorithm]string{
+			hmac.SHA256: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+			hmac.SHA512: "b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb" +
+				"82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a",
+		}
+		for algorithm, expected := range testCases {
+			signature, err := hmac.Sign(algorithm, key, data)
+			assert.NoError(t, err)
+			assert.Equals(t, hex.EncodeToString(signature), expected)
+		}
+	})
+
+	t.Run("when a signature is verified it should succeed only for the same data and key", func(t *testing.T) {
+		t.Parallel()
+		for _, algorithm := range []hmac.Algorithm{hmac.SHA256, hmac.SHA384, hmac.SHA512} {
+			signature, err := hmac.Sign(algorithm, key, data)
+			assert.NoError(t, err)
+			assert.NoError(t, hmac.Verify(algorithm, key, data, signature))
+			err = hmac.Verify(algorithm, key, []byte("other"), signature)
+			assert.True(t, errors.Is(err, hmac.ErrInvalidSignature))
+			err = hmac.Verify(algorithm, []byte("other"), data, signature)
+			assert.True(t, errors.Is(err, hmac.ErrInvalidSignature))
+			err = hmac.Verify(algorithm, key, data, signature[:len(signature)-1])
+			assert.True(t, errors.Is(err, hmac.ErrInvalidSignature))
+		}
+	})
+
+	t.Run("when data is streamed it should have the same signature as the whole data", func(t *testing.T) {
+		t.Parallel()
+		signature, err := hmac.Sign(hmac.SHA256, key, data)
+		assert.NoError(t, err)
+		streamed, err := hmac.SignReader(hmac.SHA256, key, iotest.OneByteReader(bytes.NewReader(data)))
+		assert.NoError(t, err)
+		assert.Equals(t, streamed, signature)
+		assert.NoError(t, hmac.VerifyReader(hmac.SHA256, key, bytes.NewReader(data), signature))
+		err = hmac.VerifyReader(hmac.SHA256, key, strings.NewReader("other"), signature)
+		assert.True(t, errors.Is(err, hmac.ErrInvalidSignature))
+
+		mac, err := hmac.New(hmac.SHA256, key)
+		assert.NoError(t, err)
+		mac.Write(data[:10])
+		mac.Write(data[10:])
+		assert.Equals(t, mac.Sum(nil), signature)
+	})
+
+	t.Run("when the reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		reader := iotest.ErrReader(errors.New("reader error"))
+		_, err := hmac.SignReader(hmac.SHA256, key, reader)
+		assert.ErrorExact(t, err, "failed to read the data (reader error)")
+		err = hmac.VerifyReader(hmac.SHA256, key, reader, nil)
+		assert.ErrorExact(t, err, "failed to read the data (reader error)")
+	})
+
+	t.Run("when the algorithm is not supported it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := hmac.Sign("MD5", key, data)
+		assert.ErrorExact(t, err, `unsupported HMAC algorithm "MD5"`)
+		err = hmac.Verify("MD5", key, data, nil)
+		assert.ErrorExact(t, err, `unsupported HMAC algorithm "MD5"`)
+		_, err = hmac.SignReader("MD5", key, bytes.NewReader(data))
+		assert.ErrorExact(t, err, `unsupported HMAC algorithm "MD5"`)
+	})
+
+	t.Run("when the key is empty it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := hmac.New(hmac.SHA256, nil)
+		assert.ErrorExact(t, err, "the key cannot be empty")
+	})
+
+	t.Run("when an algorithm is parsed it should ignore the case", func(t *testing.T) {
+		t.Parallel()
+		algorithm, err := hmac.ParseAlgorithm("sha384")
+		assert.NoError(t, err)
+		assert.Equals(t, algorithm, hmac.SHA384)
+		_, err = hmac.ParseAlgorithm("sha1")
+		assert.ErrorExact(t, err, `unsupported HMAC algorithm "SHA1"`)
+	})
+}
@@ -0,0 +1,357 @@
+package kdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"sync"
+)
+
+const (
+	// argon2idName is the name of argon2id in the encoded format.
+	argon2idName = "argon2id"
+
+	// argon2Version is the version of the Argon2 algorithm (1.3).
+	argon2Version = 0x13
+
+	// argon2idType is the type identifier of argon2id.
+	argon2idType = 2
+
+	// argon2SyncPoints is the number of slices in each lane.
+	argon2SyncPoints = 4
+
+	// argon2BlockWords is the number of 64-bit words in an Argon2 memory block.
+	argon2BlockWords = 128
+
+	// argon2idMaxDecodedMemory is the largest memory in KiB accepted from an encoding (4 GiB).
+	argon2idMaxDecodedMemory = 4 * 1024 * 1024
+
+	// argon2idMaxDecodedIterations is the largest number of iterations accepted from an encoding.
+	argon2idMaxDecodedIterations = 16
+
+	// argon2idMaxDecodedParallelism is the largest parallelism accepted from an encoding.
+	argon2idMaxDecodedParallelism = 255
+)
+
+// argon2Block is a 1 KiB block of Argon2 memory.
+type argon2Block [argon2BlockWords]uint64
+
+// Argon2idParams are the parameters of argon2id (RFC 9106).
+type Argon2idParams struct {
+	// Memory is the amount of memory to use in KiB.
+	Memory int
+
+	// Iterations is the number of passes over the memory.
+	Iterations int
+
+	// Parallelism is the number of lanes that are filled concurrently.
+	Parallelism int
+
+	// KeyLength is the number of bytes to derive.
+	KeyLength int
+}
+
+// DefaultArgon2idParams returns the second recommended argon2id parameters of RFC 9106.
+func DefaultArgon2idParams() *Argon2idParams {
+	return &Argon2idParams{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 4,
+		KeyLength:   32,
+	}
+}
+
+// Derive derives a key from the password and salt.
+func (p *Argon2idParams) Derive(password []byte, salt []byte) ([]byte, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return argon2id(password, salt, nil, nil, uint32(p.Iterations), uint32(p.Memory), uint32(p.Parallelism), uint32(p.KeyLength)), nil
+}
+
+// validate checks that the parameters are within the bounds of the algorithm.
+func (p *Argon2idParams) validate() error {
+	if p.Parallelism < 1 || p.Parallelism > 0xFFFFFF {
+		return fmt.Errorf("the argon2id parallelism must be between 1 and %d", 0xFFFFFF)
+	}
+	if p.Iterations < 1 || p.Iterations > math.MaxUint32 {
+		return errors.New("the argon2id iterations must be at least 1")
+	}
+	if p.Memory < 8*p.Parallelism || p.Memory > math.MaxUint32 {
+		return errors.New("the argon2id memory must be at least 8 KiB times the parallelism")
+	}
+	if p.KeyLength < 4 || p.KeyLength > math.MaxUint32 {
+		return errors.New("the argon2id key length must be at least 4 bytes")
+	}
+	return nil
+}
+
+// algorithm returns the name of the algorithm in the encoded format.
+func (p *Argon2idParams) algorithm() string {
+	return argon2idName
+}
+
+// encode returns the parameters in the encoded format.
+func (p *Argon2idParams) encode() string {
+	return fmt.Sprintf("v=%d$m=%d,t=%d,p=%d", argon2Version, p.Memory, p.Iterations, p.Parallelism)
+}
+
+// keyLength returns the number of bytes to derive.
+func (p *Argon2idParams) keyLength() int {
+	return p.KeyLength
+}
+
+// setKeyLength sets the number of bytes to derive.
+func (p *Argon2idParams) setKeyLength(length int) {
+	p.KeyLength = length
+}
+
+// decodeArgon2idParams parses the version and parameters of an encoded argon2id derivation.
+func decodeArgon2idParams(segments []string) (*Argon2idParams, []string, error) {
+	if len(segments) < 2 {
+		return nil, nil, errors.New("the argon2id parameters are missing")
+	}
+	if segments[0] != "v="+strconv.Itoa(argon2Version) {
+		return nil, nil, fmt.Errorf("unsupported argon2id version %q", segments[0])
+	}
+	fields, err := parseFields(segments[1], "m", "t", "p")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "m", argon2idMaxDecodedMemory); err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "t", argon2idMaxDecodedIterations); err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "p", argon2idMaxDecodedParallelism); err != nil {
+		return nil, nil, err
+	}
+	params := &Argon2idParams{
+		Memory:      fields["m"],
+		Iterations:  fields["t"],
+		Parallelism: fields["p"],
+	}
+	return params, segments[2:], nil
+}
+
+// argon2id derives a key with argon2id version 1.3.
+func argon2id(password, salt, secret, data []byte, time, memory, threads, keyLength uint32) []byte {
+	h0 := argon2InitialHash(password, salt, secret, data, time, memory, threads, keyLength)
+	memory = memory / (argon2SyncPoints * threads) * (argon2SyncPoints * threads)
+	if memory < 2*argon2SyncPoints*threads {
+		memory = 2 * argon2SyncPoints * threads
+	}
+	blocks := argon2InitialBlocks(h0, memory, threads)
+	argon2FillBlocks(blocks, time, memory, threads)
+	return argon2FinalKey(blocks, memory, threads, keyLength)
+}
+
+// argon2InitialHash returns H0, the digest of the inputs and parameters.
+func argon2InitialHash(password, salt, secret, data []byte, time, memory, threads, keyLength uint32) []byte {
+	le32 := func(v uint32) []byte {
+		return binary.LittleEndian.AppendUint32(nil, v)
+	}
+	return blake2b(blake2bSize,
+		le32(threads), le32(keyLength), le32(memory), le32(time), le32(argon2Version), le32(argon2idType),
+		le32(uint32(len(password))), password,
+		le32(uint32(len(salt))), salt,
+		le32(uint32(len(secret))), secret,
+		le32(uint32(len(data))), data,
+	)
+}
+
+// argon2Hash is the variable-length hash function H' of Argon2.
+func argon2Hash(size int, inputs ...[]byte) []byte {
+	prefixed := append([][]byte{binary.LittleEndian.AppendUint32(nil, uint32(size))}, inputs...)
+	if size <= blake2bSize {
+		return blake2b(size, prefixed...)
+	}
+	out := make([]byte, 0, size)
+	previous := blake2b(blake2bSize, prefixed...)
+	out = append(out, previous[:32]...)
+	for size-len(out) > blake2bSize {
+		previous = blake2b(blake2bSize, previous)
+		out = append(out, previous[:32]...)
+	}
+	return append(out, blake2b(size-len(out), previous)...)
+}
+
+// argon2InitialBlocks allocates the memory and fills the first two blocks of each lane.
+func argon2InitialBlocks(h0 []byte, memory, threads uint32) []argon2Block {
+	blocks := make([]argon2Block, memory)
+	laneLength := memory / threads
+	for lane := uint32(0); lane < threads; lane++ {
+		for i := uint32(0); i < 2; i++ {
+			out := argon2Hash(1024, h0, binary.LittleEndian.AppendUint32(nil, i), binary.LittleEndian.AppendUint32(nil, lane))
+			block := &blocks[lane*laneLength+i]
+			for j := range block {
+				block[j] = binary.LittleEndian.Uint64(out[j*8:])
+			}
+		}
+	}
+	return blocks
+}
+
+// argon2FillBlocks makes the passes over the memory. The lanes of each slice are filled concurrently.
+func argon2FillBlocks(blocks []argon2Block, time, memory, threads uint32) {
+	laneLength := memory / threads
+	segmentLength := laneLength / argon2SyncPoints
+	for pass := uint32(0); pass < time; pass++ {
+		for slice := uint32(0); slice < argon2SyncPoints; slice++ {
+			var wg sync.WaitGroup
+			for lane := uint32(0); lane < threads; lane++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					argon2FillSegment(blocks, pass, slice, lane, time, memory, threads, laneLength, segmentLength)
+				}()
+			}
+			wg.Wait()
+		}
+	}
+}
+
+// argon2FillSegment fills one segment of a lane.
+// The first half of the first pass uses data-independent addressing, and the rest uses data-dependent addressing.
+func argon2FillSegment(blocks []argon2Block, pass, slice, lane, time, memory, threads, laneLength, segmentLength uint32) {
+	var addresses, input, zero argon2Block
+	independent := pass == 0 && slice < argon2SyncPoints/2
+	if independent {
+		input[0] = uint64(pass)
+		input[1] = uint64(lane)
+		input[2] = uint64(slice)
+		input[3] = uint64(memory)
+		input[4] = uint64(time)
+		input[5] = argon2idType
+	}
+	nextAddresses := func() {
+		input[6]++
+		argon2Compress(&addresses, &zero, &input, false)
+		argon2Compress(&addresses, &zero, &addresses, false)
+	}
+
+	index := uint32(0)
+	if pass == 0 && slice == 0 {
+		index = 2
+		if independent {
+			nextAddresses()
+		}
+	}
+
+	offset := lane*laneLength + slice*segmentLength + index
+	for ; index < segmentLength; index, offset = index+1, offset+1 {
+		previous := offset - 1
+		if index == 0 && slice == 0 {
+			previous += laneLength
+		}
+		var random uint64
+		if independent {
+			if index%argon2BlockWords == 0 {
+				nextAddresses()
+			}
+			random = addresses[index%argon2BlockWords]
+		} else {
+			random = blocks[previous][0]
+		}
+		reference := argon2ReferenceIndex(random, pass, slice, lane, index, threads, laneLength, segmentLength)
+		argon2Compress(&blocks[offset], &blocks[previous], &blocks[reference], pass > 0)
+	}
+}
+
+// argon2ReferenceIndex maps a pseudo-random value to the index of the block to reference.
+func argon2ReferenceIndex(random uint64, pass, slice, lane, index, threads, laneLength, segmentLength uint32) uint32 {
+	referenceLane := uint32(random>>32) % threads
+	if pass == 0 && slice == 0 {
+		referenceLane = lane
+	}
+
+	area, start := 3*segmentLength, ((slice+1)%argon2SyncPoints)*segmentLength
+	if lane == referenceLane {
+		area += index
+	}
+	if pass == 0 {
+		area, start = slice*segmentLength, 0
+		if slice == 0 || lane == referenceLane {
+			area += index
+		}
+	}
+	if index == 0 || lane == referenceLane {
+		area--
+	}
+
+	relative := random & 0xFFFFFFFF
+	relative = (relative * relative) >> 32
+	relative = (relative * uint64(area)) >> 32
+	return referenceLane*laneLength + uint32((uint64(start)+uint64(area)-(relative+1))%uint64(laneLength))
+}
+
+// argon2Compress is the compression function G of Argon2. The result is XORed into the output when xor is true.
+func argon2Compress(out, x, y *argon2Block, xor bool) {
+	var r, q argon2Block
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+	q = r
+	for i := 0; i < argon2BlockWords; i += 16 {
+		argon2Permute(&q[i], &q[i+1], &q[i+2], &q[i+3], &q[i+4], &q[i+5], &q[i+6], &q[i+7],
+			&q[i+8], &q[i+9], &q[i+10], &q[i+11], &q[i+12], &q[i+13], &q[i+14], &q[i+15])
+	}
+	for i := 0; i < argon2BlockWords/8; i += 2 {
+		argon2Permute(&q[i], &q[i+1], &q[16+i], &q[16+i+1], &q[32+i], &q[32+i+1], &q[48+i], &q[48+i+1],
+			&q[64+i], &q[64+i+1], &q[80+i], &q[80+i+1], &q[96+i], &q[96+i+1], &q[112+i], &q[112+i+1])
+	}
+	for i := range out {
+		if xor {
+			out[i] ^= r[i] ^ q[i]
+		} else {
+			out[i] = r[i] ^ q[i]
+		}
+	}
+}
+
+// argon2Permute is the permutation P of Argon2, built from the BlaMka variant of the BLAKE2b round.
+func argon2Permute(v0, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15 *uint64) {
+	argon2Mix(v0, v4, v8, v12)
+	argon2Mix(v1, v5, v9, v13)
+	argon2Mix(v2, v6, v10, v14)
+	argon2Mix(v3, v7, v11, v15)
+	argon2Mix(v0, v5, v10, v15)
+	argon2Mix(v1, v6, v11, v12)
+	argon2Mix(v2, v7, v8, v13)
+	argon2Mix(v3, v4, v9, v14)
+}
+
+// argon2Mix is the BlaMka mixing function GB.
+func argon2Mix(a, b, c, d *uint64) {
+	multiply := func(x, y uint64) uint64 {
+		return 2 * uint64(uint32(x)) * uint64(uint32(y))
+	}
+	*a = *a + *b + multiply(*a, *b)
+	*d = bits.RotateLeft64(*d^*a, -32)
+	*c = *c + *d + multiply(*c, *d)
+	*b = bits.RotateLeft64(*b^*c, -24)
+	*a = *a + *b + multiply(*a, *b)
+	*d = bits.RotateLeft64(*d^*a, -16)
+	*c = *c + *d + multiply(*c, *d)
+	*b = bits.RotateLeft64(*b^*c, -63)
+}
+
+// argon2FinalKey XORs the last block of each lane and hashes it into the key.
+func argon2FinalKey(blocks []argon2Block, memory, threads, keyLength uint32) []byte {
+	laneLength := memory / threads
+	final := blocks[laneLength-1]
+	for lane := uint32(1); lane < threads; lane++ {
+		for i, word := range blocks[lane*laneLength+laneLength-1] {
+			final[i] ^= word
+		}
+	}
+	out := make([]byte, 1024)
+	for i, word := range final {
+		binary.LittleEndian.PutUint64(out[i*8:], word)
+	}
+	return argon2Hash(int(keyLength), out)
+}
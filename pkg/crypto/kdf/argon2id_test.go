@@ -0,0 +1,68 @@
+package kdf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestArgon2id(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the inputs are the RFC 9106 test vector it should derive the expected tag", func(t *testing.T) {
+		t.Parallel()
+		password := bytes.Repeat([]byte{0x01}, 32)
+		salt := bytes.Repeat([]byte{0x02}, 16)
+		secret := bytes.Repeat([]byte{0x03}, 8)
+		data := bytes.Repeat([]byte{0x04}, 12)
+		tag := argon2id(password, salt, secret, data, 3, 32, 4, 32)
+		assert.Equals(t, hex.EncodeToString(tag), "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659")
+	})
+
+	t.Run("when the same inputs are derived twice it should return the same key", func(t *testing.T) {
+		t.Parallel()
+		params := &Argon2idParams{Memory: 64, Iterations: 2, Parallelism: 2, KeyLength: 48}
+		first, err := params.Derive([]byte("password"), []byte("saltsalt"))
+		assert.NoError(t, err)
+		second, err := params.Derive([]byte("password"), []byte("saltsalt"))
+		assert.NoError(t, err)
+		assert.Equals(t, first, second)
+		assert.Equals(t, len(first), 48)
+		other, err := params.Derive([]byte("other"), []byte("saltsalt"))
+		assert.NoError(t, err)
+		assert.NotEquals(t, first, other)
+	})
+
+	t.Run("when the key is longer than a BLAKE2b digest it should be derived", func(t *testing.T) {
+		t.Parallel()
+		params := &Argon2idParams{Memory: 16, Iterations: 1, Parallelism: 1, KeyLength: 100}
+		key, err := params.Derive([]byte("password"), []byte("saltsalt"))
+		assert.NoError(t, err)
+		assert.Equals(t, len(key), 100)
+	})
+
+	t.Run("when the default parameters are requested it should return the recommended values", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, DefaultArgon2idParams(), &Argon2idParams{Memory: 64 * 1024, Iterations: 3, Parallelism: 4, KeyLength: 32})
+	})
+
+	t.Run("when the parameters are invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			params   *Argon2idParams
+			expected string
+		}{
+			{&Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 0, KeyLength: 32}, "parallelism"},
+			{&Argon2idParams{Memory: 64, Iterations: 0, Parallelism: 1, KeyLength: 32}, "iterations"},
+			{&Argon2idParams{Memory: 7, Iterations: 1, Parallelism: 1, KeyLength: 32}, "memory"},
+			{&Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, KeyLength: 3}, "key length"},
+		}
+		for _, testCase := range testCases {
+			key, err := testCase.params.Derive([]byte("password"), []byte("saltsalt"))
+			assert.ErrorPart(t, err, testCase.expected)
+			assert.Nil(t, key)
+		}
+	})
+}
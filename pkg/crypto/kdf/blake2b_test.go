@@ -0,0 +1,36 @@
+package kdf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestBlake2b(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the input is abc it should match the RFC 7693 digest", func(t *testing.T) {
+		t.Parallel()
+		digest := blake2b(64, []byte("abc"))
+		assert.Equals(t, hex.EncodeToString(digest), "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d1"+
+			"7d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923")
+	})
+
+	t.Run("when the input is empty it should match the known digest", func(t *testing.T) {
+		t.Parallel()
+		digest := blake2b(64)
+		assert.Equals(t, hex.EncodeToString(digest), "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419"+
+			"d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce")
+	})
+
+	t.Run("when the input is split it should match the digest of the whole input", func(t *testing.T) {
+		t.Parallel()
+		data := make([]byte, 300)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		assert.Equals(t, blake2b(32, data[:100], data[100:]), blake2b(32, data))
+		assert.Equals(t, len(blake2b(32, data)), 32)
+	})
+}
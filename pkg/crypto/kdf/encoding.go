@@ -0,0 +1,152 @@
+package kdf
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// keyLengthField is the name of the field that holds the key length when the encoding has no key.
+	keyLengthField = "l"
+
+	// maxDecodedKeyLength is the largest key length in bytes accepted from an encoding.
+	maxDecodedKeyLength = 1024
+)
+
+// Encode returns the PHC string format of a derivation, for example "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>".
+// The key is optional. When it is omitted, the key length is appended to the parameters.
+func Encode(params Params, salt []byte, key []byte) string {
+	sb := strings.Builder{}
+	sb.WriteString("$")
+	sb.WriteString(params.algorithm())
+	sb.WriteString("$")
+	sb.WriteString(params.encode())
+	if len(key) == 0 {
+		sb.WriteString(",")
+		sb.WriteString(encodeFields([]string{keyLengthField}, []int{params.keyLength()}))
+	}
+	sb.WriteString("$")
+	sb.WriteString(base64.RawStdEncoding.EncodeToString(salt))
+	if len(key) > 0 {
+		sb.WriteString("$")
+		sb.WriteString(base64.RawStdEncoding.EncodeToString(key))
+	}
+	return sb.String()
+}
+
+// Decode parses a string created by Encode. The key is nil if the encoding does not have one.
+// Encodings can come from untrusted sources, so the parameters must be within upper bounds
+// that keep the cost of deriving the key reasonable.
+func Decode(encoded string) (Params, []byte, []byte, error) {
+	segments := strings.Split(encoded, "$")
+	if len(segments) < 2 || segments[0] != "" {
+		return nil, nil, nil, errors.New("the encoding must start with $ followed by the algorithm")
+	}
+
+	keyLength, segments := extractKeyLength(segments)
+	var params Params
+	var rest []string
+	var err error
+	switch segments[1] {
+	case argon2idName:
+		params, rest, err = decodeArgon2idParams(segments[2:])
+	case scryptName:
+		params, rest, err = decodeScryptParams(segments[2:])
+	case pbkdf2Name:
+		params, rest, err = decodePBKDF2Params(segments[2:])
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported algorithm %q", segments[1])
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(rest) < 1 || len(rest) > 2 {
+		return nil, nil, nil, errors.New("the encoding must have a salt and an optional key after the parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(rest[0])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode the salt (%w)", err)
+	}
+	var key []byte
+	if len(rest) == 2 {
+		key, err = base64.RawStdEncoding.DecodeString(rest[1])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode the key (%w)", err)
+		}
+		keyLength = len(key)
+	}
+	if keyLength == 0 {
+		return nil, nil, nil, errors.New("the encoding must have a key or a key length")
+	}
+	if keyLength > maxDecodedKeyLength {
+		return nil, nil, nil, fmt.Errorf("the key length must be at most %d bytes", maxDecodedKeyLength)
+	}
+	params.setKeyLength(keyLength)
+	return params, salt, key, nil
+}
+
+// extractKeyLength removes the key length field from the parameters and returns it.
+// The parameters are the segment that contains "=" and is not the argon2id version.
+func extractKeyLength(segments []string) (int, []string) {
+	result := make([]string, len(segments))
+	copy(result, segments)
+	for i, segment := range result {
+		if !strings.Contains(segment, "=") || strings.HasPrefix(segment, "v=") {
+			continue
+		}
+		fields := strings.Split(segment, ",")
+		last := fields[len(fields)-1]
+		if !strings.HasPrefix(last, keyLengthField+"=") {
+			return 0, result
+		}
+		length, err := strconv.Atoi(strings.TrimPrefix(last, keyLengthField+"="))
+		if err != nil || length <= 0 {
+			return 0, result
+		}
+		result[i] = strings.Join(fields[:len(fields)-1], ",")
+		return length, result
+	}
+	return 0, result
+}
+
+// encodeFields joins the names and values as comma separated name=value pairs.
+func encodeFields(names []string, values []int) string {
+	fields := make([]string, len(names))
+	for i := range names {
+		fields[i] = names[i] + "=" + strconv.Itoa(values[i])
+	}
+	return strings.Join(fields, ",")
+}
+
+// parseFields parses comma separated name=value pairs. The names must be exactly the expected names in order.
+func parseFields(segment string, names ...string) (map[string]int, error) {
+	fields := strings.Split(segment, ",")
+	if len(fields) != len(names) {
+		return nil, fmt.Errorf("the parameters %q must be %s", segment, strings.Join(names, ","))
+	}
+	values := make(map[string]int, len(names))
+	for i, field := range fields {
+		name, value, found := strings.Cut(field, "=")
+		if !found || name != names[i] {
+			return nil, fmt.Errorf("the parameters %q must be %s", segment, strings.Join(names, ","))
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("the parameter %s has an invalid value %q", name, value)
+		}
+		values[name] = parsed
+	}
+	return values, nil
+}
+
+// checkField verifies that a decoded parameter is between 1 and the maximum.
+func checkField(fields map[string]int, name string, maxValue int) error {
+	if value := fields[name]; value < 1 || value > maxValue {
+		return fmt.Errorf("the parameter %s must be between 1 and %d", name, maxValue)
+	}
+	return nil
+}
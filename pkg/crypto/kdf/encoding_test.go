@@ -0,0 +1,88 @@
+package kdf
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestEncoding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a derivation with a key is encoded it should use the PHC string format", func(t *testing.T) {
+		t.Parallel()
+		params := &Argon2idParams{Memory: 65536, Iterations: 2, Parallelism: 1, KeyLength: 4}
+		encoded := Encode(params, []byte("somesalt"), []byte("hash"))
+		assert.Equals(t, encoded, "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$aGFzaA")
+		decodedParams, salt, key, err := Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equals(t, decodedParams, Params(params))
+		assert.Equals(t, salt, []byte("somesalt"))
+		assert.Equals(t, key, []byte("hash"))
+	})
+
+	t.Run("when a derivation without a key is encoded it should include the key length", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			params   Params
+			expected string
+		}{
+			{&Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 2, KeyLength: 32}, "$argon2id$v=19$m=64,t=1,p=2,l=32$c2FsdA"},
+			{&ScryptParams{CostLog2: 15, BlockSize: 8, Parallelism: 1, KeyLength: 16}, "$scrypt$ln=15,r=8,p=1,l=16$c2FsdA"},
+			{&PBKDF2Params{Iterations: 1000, KeyLength: 64}, "$pbkdf2-sha256$i=1000,l=64$c2FsdA"},
+		}
+		for _, testCase := range testCases {
+			encoded := Encode(testCase.params, []byte("salt"), nil)
+			assert.Equals(t, encoded, testCase.expected)
+			params, salt, key, err := Decode(encoded)
+			assert.NoError(t, err)
+			assert.Equals(t, params, testCase.params)
+			assert.Equals(t, salt, []byte("salt"))
+			assert.Nil(t, key)
+		}
+	})
+
+	t.Run("when the encoding is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			encoded  string
+			expected string
+		}{
+			{"", "the encoding must start with $ followed by the algorithm"},
+			{"argon2id$v=19", "the encoding must start with $ followed by the algorithm"},
+			{"$md5$x", `unsupported algorithm "md5"`},
+			{"$argon2id$v=19", "the argon2id parameters are missing"},
+			{"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$aGFzaA", `unsupported argon2id version "v=16"`},
+			{"$argon2id$v=19$m=64,t=1$c2FsdA$aGFzaA", "must be m,t,p"},
+			{"$argon2id$v=19$m=64,x=1,p=1$c2FsdA$aGFzaA", "must be m,t,p"},
+			{"$argon2id$v=19$m=64,t=a,p=1$c2FsdA$aGFzaA", `the parameter t has an invalid value "a"`},
+			{"$scrypt", "the scrypt parameters are missing"},
+			{"$scrypt$ln=1$c2FsdA$aGFzaA", "must be ln,r,p"},
+			{"$pbkdf2-sha256", "the PBKDF2 parameters are missing"},
+			{"$pbkdf2-sha256$n=1$c2FsdA$aGFzaA", "must be i"},
+			{"$pbkdf2-sha256$i=1", "the encoding must have a salt and an optional key after the parameters"},
+			{"$pbkdf2-sha256$i=1$a$b$c", "the encoding must have a salt and an optional key after the parameters"},
+			{"$pbkdf2-sha256$i=1$!!!$aGFzaA", "failed to decode the salt"},
+			{"$pbkdf2-sha256$i=1$c2FsdA$!!!", "failed to decode the key"},
+			{"$pbkdf2-sha256$i=1$c2FsdA", "the encoding must have a key or a key length"},
+			{"$pbkdf2-sha256$i=1,l=0$c2FsdA", "must be i"},
+			{"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdA$aGFzaA", "the parameter m must be between 1 and 4194304"},
+			{"$argon2id$v=19$m=64,t=4294967295,p=1$c2FsdA$aGFzaA", "the parameter t must be between 1 and 16"},
+			{"$argon2id$v=19$m=64,t=1,p=256$c2FsdA$aGFzaA", "the parameter p must be between 1 and 255"},
+			{"$argon2id$v=19$m=0,t=1,p=1$c2FsdA$aGFzaA", "the parameter m must be between 1 and 4194304"},
+			{"$scrypt$ln=21,r=8,p=1$c2FsdA$aGFzaA", "the parameter ln must be between 1 and 20"},
+			{"$scrypt$ln=15,r=33,p=1$c2FsdA$aGFzaA", "the parameter r must be between 1 and 32"},
+			{"$scrypt$ln=15,r=8,p=17$c2FsdA$aGFzaA", "the parameter p must be between 1 and 16"},
+			{"$pbkdf2-sha256$i=10000001$c2FsdA$aGFzaA", "the parameter i must be between 1 and 10000000"},
+			{"$pbkdf2-sha256$i=-1$c2FsdA$aGFzaA", "the parameter i must be between 1 and 10000000"},
+			{"$pbkdf2-sha256$i=1,l=1025$c2FsdA", "the key length must be at most 1024 bytes"},
+		}
+		for _, testCase := range testCases {
+			params, salt, key, err := Decode(testCase.encoded)
+			assert.ErrorPart(t, err, testCase.expected)
+			assert.Nil(t, params)
+			assert.Nil(t, salt)
+			assert.Nil(t, key)
+		}
+	})
+}
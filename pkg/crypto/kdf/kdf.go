@@ -0,0 +1,103 @@
+package kdf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// Params are the parameters of a key derivation function.
+// The implementations are Argon2idParams, ScryptParams, and PBKDF2Params.
+type Params interface {
+	// Derive derives a key from the password and salt.
+	Derive(password []byte, salt []byte) ([]byte, error)
+
+	// algorithm returns the name of the algorithm in the encoded format.
+	algorithm() string
+
+	// encode returns the parameters in the encoded format.
+	encode() string
+
+	// keyLength returns the number of bytes to derive.
+	keyLength() int
+
+	// setKeyLength sets the number of bytes to derive.
+	setKeyLength(length int)
+}
+
+// config is the configuration for a derivation.
+type config struct {
+	params     Params
+	saltLength int
+	random     io.Reader
+}
+
+// Option is optional configuration of a derivation.
+type Option func(*config)
+
+// WithParams overwrites the key derivation function and its parameters. The default is argon2id.
+func WithParams(params Params) Option {
+	return func(c *config) {
+		c.params = params
+	}
+}
+
+// WithSaltLength overwrites the number of random bytes in the salt.
+func WithSaltLength(length int) Option {
+	if length <= 0 {
+		panic("The salt length must be greater than zero.")
+	}
+	return func(c *config) {
+		c.saltLength = length
+	}
+}
+
+// WithRandomReader overwrites the source of randomness of the salt.
+func WithRandomReader(random io.Reader) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		params:     DefaultArgon2idParams(),
+		saltLength: 16,
+		random:     rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Derive derives a key from the password with a random salt.
+// It returns the key, which can be given to symmetric.New, and the self-describing encoding of the algorithm, parameters, and salt.
+// The encoding is not secret and can be stored so the same key can be derived again with DeriveEncoded.
+func Derive(password []byte, opts ...Option) ([]byte, string, error) {
+	cfg := configure(opts...)
+	salt := make([]byte, cfg.saltLength)
+	if _, err := io.ReadFull(cfg.random, salt); err != nil {
+		return nil, "", fmt.Errorf("failed to generate the salt (%w)", err)
+	}
+	key, err := cfg.params.Derive(password, salt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to derive the key (%w)", err)
+	}
+	return key, Encode(cfg.params, salt, nil), nil
+}
+
+// DeriveEncoded derives a key from the password using the algorithm, parameters, and salt of an encoding.
+// If the encoding has a key, it is ignored.
+func DeriveEncoded(password []byte, encoded string) ([]byte, error) {
+	params, salt, _, err := Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	key, err := params.Derive(password, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the key (%w)", err)
+	}
+	return key, nil
+}
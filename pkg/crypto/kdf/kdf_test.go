@@ -0,0 +1,95 @@
+package kdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// failingReader is a source of randomness that always fails.
+type failingReader struct{}
+
+// Read returns an error.
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("random reader error")
+}
+
+func TestDerive(t *testing.T) {
+	t.Parallel()
+
+	fastArgon2id := &Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, KeyLength: 32}
+
+	t.Run("when no options are provided it should use argon2id with the default parameters", func(t *testing.T) {
+		t.Parallel()
+		key, encoded, err := Derive([]byte("password"))
+		assert.NoError(t, err)
+		assert.Equals(t, len(key), 32)
+		assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=65536,t=3,p=4,l=32$"))
+	})
+
+	t.Run("when the encoding is used it should derive the same key", func(t *testing.T) {
+		t.Parallel()
+		for _, params := range []Params{
+			fastArgon2id,
+			&ScryptParams{CostLog2: 4, BlockSize: 1, Parallelism: 1, KeyLength: 16},
+			&PBKDF2Params{Iterations: 10, KeyLength: 64},
+		} {
+			key, encoded, err := Derive([]byte("password"), WithParams(params))
+			assert.NoError(t, err)
+			rederived, err := DeriveEncoded([]byte("password"), encoded)
+			assert.NoError(t, err)
+			assert.Equals(t, rederived, key)
+			other, err := DeriveEncoded([]byte("other"), encoded)
+			assert.NoError(t, err)
+			assert.NotEquals(t, other, key)
+		}
+	})
+
+	t.Run("when the same password is derived twice it should use different salts", func(t *testing.T) {
+		t.Parallel()
+		first, firstEncoded, err := Derive([]byte("password"), WithParams(fastArgon2id))
+		assert.NoError(t, err)
+		second, secondEncoded, err := Derive([]byte("password"), WithParams(fastArgon2id))
+		assert.NoError(t, err)
+		assert.NotEquals(t, first, second)
+		assert.NotEquals(t, firstEncoded, secondEncoded)
+	})
+
+	t.Run("when the salt length is set it should generate a salt of that length", func(t *testing.T) {
+		t.Parallel()
+		_, encoded, err := Derive([]byte("password"), WithParams(fastArgon2id), WithSaltLength(32))
+		assert.NoError(t, err)
+		_, salt, _, err := Decode(encoded)
+		assert.NoError(t, err)
+		assert.Equals(t, len(salt), 32)
+	})
+
+	t.Run("when the salt length is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() { WithSaltLength(0) }, "The salt length must be greater than zero.")
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		key, encoded, err := Derive([]byte("password"), WithRandomReader(failingReader{}))
+		assert.ErrorExact(t, err, "failed to generate the salt (random reader error)")
+		assert.Nil(t, key)
+		assert.Equals(t, encoded, "")
+	})
+
+	t.Run("when the parameters are invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := Derive([]byte("password"), WithParams(&PBKDF2Params{}))
+		assert.ErrorPart(t, err, "failed to derive the key")
+		_, err = DeriveEncoded([]byte("password"), "$argon2id$v=19$m=1,t=1,p=1$c2FsdA$a2V5")
+		assert.ErrorPart(t, err, "failed to derive the key")
+	})
+
+	t.Run("when the encoding is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := DeriveEncoded([]byte("password"), "invalid")
+		assert.ErrorPart(t, err, "the encoding must start with $")
+	})
+}
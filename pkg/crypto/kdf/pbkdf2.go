@@ -0,0 +1,110 @@
+package kdf
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+const (
+	// pbkdf2Name is the name of PBKDF2 with HMAC-SHA256 in the encoded format.
+	pbkdf2Name = "pbkdf2-sha256"
+
+	// pbkdf2MaxDecodedIterations is the largest number of iterations accepted from an encoding.
+	pbkdf2MaxDecodedIterations = 10_000_000
+)
+
+// PBKDF2Params are the parameters of PBKDF2 (RFC 8018) with HMAC-SHA256.
+type PBKDF2Params struct {
+	// Iterations is the number of HMAC iterations.
+	Iterations int
+
+	// KeyLength is the number of bytes to derive.
+	KeyLength int
+}
+
+// DefaultPBKDF2Params returns the PBKDF2-HMAC-SHA256 parameters recommended by OWASP.
+func DefaultPBKDF2Params() *PBKDF2Params {
+	return &PBKDF2Params{
+		Iterations: 600_000,
+		KeyLength:  32,
+	}
+}
+
+// Derive derives a key from the password and salt.
+func (p *PBKDF2Params) Derive(password []byte, salt []byte) ([]byte, error) {
+	if p.Iterations < 1 {
+		return nil, errors.New("the PBKDF2 iterations must be at least 1")
+	}
+	if p.KeyLength < 1 {
+		return nil, errors.New("the PBKDF2 key length must be at least 1 byte")
+	}
+	return pbkdf2(sha256.New, password, salt, p.Iterations, p.KeyLength), nil
+}
+
+// algorithm returns the name of the algorithm in the encoded format.
+func (p *PBKDF2Params) algorithm() string {
+	return pbkdf2Name
+}
+
+// encode returns the parameters in the encoded format.
+func (p *PBKDF2Params) encode() string {
+	return encodeFields([]string{"i"}, []int{p.Iterations})
+}
+
+// keyLength returns the number of bytes to derive.
+func (p *PBKDF2Params) keyLength() int {
+	return p.KeyLength
+}
+
+// setKeyLength sets the number of bytes to derive.
+func (p *PBKDF2Params) setKeyLength(length int) {
+	p.KeyLength = length
+}
+
+// decodePBKDF2Params parses the parameters of an encoded PBKDF2 derivation.
+func decodePBKDF2Params(segments []string) (*PBKDF2Params, []string, error) {
+	if len(segments) < 1 {
+		return nil, nil, errors.New("the PBKDF2 parameters are missing")
+	}
+	fields, err := parseFields(segments[0], "i")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "i", pbkdf2MaxDecodedIterations); err != nil {
+		return nil, nil, err
+	}
+	params := &PBKDF2Params{
+		Iterations: fields["i"],
+	}
+	return params, segments[1:], nil
+}
+
+// pbkdf2 derives a key with PBKDF2 using HMAC with the given hash.
+func pbkdf2(newHash func() hash.Hash, password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(newHash, password)
+	hashSize := prf.Size()
+	blockCount := (keyLength + hashSize - 1) / hashSize
+
+	out := make([]byte, 0, blockCount*hashSize)
+	u := make([]byte, hashSize)
+	for block := 1; block <= blockCount; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, uint32(block)))
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		out = append(out, t...)
+	}
+	return out[:keyLength]
+}
@@ -0,0 +1,43 @@
+package kdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestPBKDF2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the inputs are known test vectors it should derive the expected keys", func(t *testing.T) {
+		t.Parallel()
+		key := pbkdf2(sha256.New, []byte("passwd"), []byte("salt"), 1, 64)
+		assert.Equals(t, hex.EncodeToString(key), "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc"+
+			"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783")
+		key = pbkdf2(sha256.New, []byte("password"), []byte("salt"), 4096, 32)
+		assert.Equals(t, hex.EncodeToString(key), "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a")
+	})
+
+	t.Run("when the parameters are valid it should derive a key of the requested length", func(t *testing.T) {
+		t.Parallel()
+		params := &PBKDF2Params{Iterations: 10, KeyLength: 20}
+		key, err := params.Derive([]byte("password"), []byte("salt"))
+		assert.NoError(t, err)
+		assert.Equals(t, len(key), 20)
+	})
+
+	t.Run("when the default parameters are requested it should return the recommended values", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, DefaultPBKDF2Params(), &PBKDF2Params{Iterations: 600_000, KeyLength: 32})
+	})
+
+	t.Run("when the parameters are invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := (&PBKDF2Params{Iterations: 0, KeyLength: 32}).Derive([]byte("password"), []byte("salt"))
+		assert.ErrorPart(t, err, "iterations")
+		_, err = (&PBKDF2Params{Iterations: 1, KeyLength: 0}).Derive([]byte("password"), []byte("salt"))
+		assert.ErrorPart(t, err, "key length")
+	})
+}
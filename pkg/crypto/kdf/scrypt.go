@@ -0,0 +1,184 @@
+package kdf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+const (
+	// scryptName is the name of scrypt in the encoded format.
+	scryptName = "scrypt"
+
+	// scryptMaxDecodedCostLog2 is the largest base 2 logarithm of N accepted from an encoding.
+	scryptMaxDecodedCostLog2 = 20
+
+	// scryptMaxDecodedBlockSize is the largest block size accepted from an encoding.
+	scryptMaxDecodedBlockSize = 32
+
+	// scryptMaxDecodedParallelism is the largest parallelism accepted from an encoding.
+	scryptMaxDecodedParallelism = 16
+)
+
+// ScryptParams are the parameters of scrypt (RFC 7914).
+type ScryptParams struct {
+	// CostLog2 is the base 2 logarithm of the CPU and memory cost N.
+	CostLog2 int
+
+	// BlockSize is the block size r.
+	BlockSize int
+
+	// Parallelism is the parallelization parameter p.
+	Parallelism int
+
+	// KeyLength is the number of bytes to derive.
+	KeyLength int
+}
+
+// DefaultScryptParams returns scrypt parameters with N=2^15, r=8, and p=1.
+func DefaultScryptParams() *ScryptParams {
+	return &ScryptParams{
+		CostLog2:    15,
+		BlockSize:   8,
+		Parallelism: 1,
+		KeyLength:   32,
+	}
+}
+
+// Derive derives a key from the password and salt.
+func (p *ScryptParams) Derive(password []byte, salt []byte) ([]byte, error) {
+	if p.CostLog2 < 1 || p.CostLog2 > 30 {
+		return nil, errors.New("the scrypt cost must be between 2^1 and 2^30")
+	}
+	if p.BlockSize < 1 || p.Parallelism < 1 || p.BlockSize*p.Parallelism >= 1<<30 {
+		return nil, errors.New("the scrypt block size and parallelism must be at least 1 and their product less than 2^30")
+	}
+	if p.BlockSize > (1<<31)/128/(1<<p.CostLog2) {
+		return nil, errors.New("the scrypt parameters require too much memory")
+	}
+	if p.KeyLength < 1 {
+		return nil, errors.New("the scrypt key length must be at least 1 byte")
+	}
+	return scrypt(password, salt, 1<<p.CostLog2, p.BlockSize, p.Parallelism, p.KeyLength), nil
+}
+
+// algorithm returns the name of the algorithm in the encoded format.
+func (p *ScryptParams) algorithm() string {
+	return scryptName
+}
+
+// encode returns the parameters in the encoded format.
+func (p *ScryptParams) encode() string {
+	return encodeFields([]string{"ln", "r", "p"}, []int{p.CostLog2, p.BlockSize, p.Parallelism})
+}
+
+// keyLength returns the number of bytes to derive.
+func (p *ScryptParams) keyLength() int {
+	return p.KeyLength
+}
+
+// setKeyLength sets the number of bytes to derive.
+func (p *ScryptParams) setKeyLength(length int) {
+	p.KeyLength = length
+}
+
+// decodeScryptParams parses the parameters of an encoded scrypt derivation.
+func decodeScryptParams(segments []string) (*ScryptParams, []string, error) {
+	if len(segments) < 1 {
+		return nil, nil, errors.New("the scrypt parameters are missing")
+	}
+	fields, err := parseFields(segments[0], "ln", "r", "p")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "ln", scryptMaxDecodedCostLog2); err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "r", scryptMaxDecodedBlockSize); err != nil {
+		return nil, nil, err
+	}
+	if err := checkField(fields, "p", scryptMaxDecodedParallelism); err != nil {
+		return nil, nil, err
+	}
+	params := &ScryptParams{
+		CostLog2:    fields["ln"],
+		BlockSize:   fields["r"],
+		Parallelism: fields["p"],
+	}
+	return params, segments[1:], nil
+}
+
+// scrypt derives a key with scrypt. N must be a power of two.
+func scrypt(password, salt []byte, n, r, p, keyLength int) []byte {
+	blockWords := 32 * r
+	b := pbkdf2(sha256.New, password, salt, 1, p*128*r)
+	x := make([]uint32, blockWords)
+	y := make([]uint32, blockWords)
+	v := make([]uint32, n*blockWords)
+	var scratch [16]uint32
+
+	for chunk := 0; chunk < p; chunk++ {
+		data := b[chunk*128*r : (chunk+1)*128*r]
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(data[i*4:])
+		}
+		for i := 0; i < n; i++ {
+			copy(v[i*blockWords:], x)
+			scryptBlockMix(&scratch, x, y, r)
+			x, y = y, x
+		}
+		for i := 0; i < n; i++ {
+			j := int(x[(2*r-1)*16] & uint32(n-1))
+			for k, word := range v[j*blockWords : (j+1)*blockWords] {
+				x[k] ^= word
+			}
+			scryptBlockMix(&scratch, x, y, r)
+			x, y = y, x
+		}
+		for i, word := range x {
+			binary.LittleEndian.PutUint32(data[i*4:], word)
+		}
+	}
+
+	return pbkdf2(sha256.New, password, b, 1, keyLength)
+}
+
+// scryptBlockMix is the BlockMix function of scrypt with Salsa20/8 as the hash.
+func scryptBlockMix(scratch *[16]uint32, in, out []uint32, r int) {
+	copy(scratch[:], in[(2*r-1)*16:])
+	for i := 0; i < 2*r; i += 2 {
+		salsa208XOR(scratch, in[i*16:])
+		copy(out[i*8:], scratch[:])
+		salsa208XOR(scratch, in[i*16+16:])
+		copy(out[i*8+r*16:], scratch[:])
+	}
+}
+
+// salsa208XOR XORs the input into the state and applies the Salsa20/8 core to it.
+func salsa208XOR(state *[16]uint32, in []uint32) {
+	var w [16]uint32
+	for i := range w {
+		w[i] = state[i] ^ in[i]
+	}
+	x := w
+	quarter := func(a, b, c, d int) {
+		x[b] ^= bits.RotateLeft32(x[a]+x[d], 7)
+		x[c] ^= bits.RotateLeft32(x[b]+x[a], 9)
+		x[d] ^= bits.RotateLeft32(x[c]+x[b], 13)
+		x[a] ^= bits.RotateLeft32(x[d]+x[c], 18)
+	}
+	for i := 0; i < 8; i += 2 {
+		quarter(0, 4, 8, 12)
+		quarter(5, 9, 13, 1)
+		quarter(10, 14, 2, 6)
+		quarter(15, 3, 7, 11)
+		quarter(0, 1, 2, 3)
+		quarter(5, 6, 7, 4)
+		quarter(10, 11, 8, 9)
+		quarter(15, 12, 13, 14)
+	}
+	for i := range state {
+		state[i] = x[i] + w[i]
+	}
+}
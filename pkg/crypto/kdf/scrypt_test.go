@@ -0,0 +1,55 @@
+package kdf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestScrypt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the inputs are the RFC 7914 test vectors it should derive the expected keys", func(t *testing.T) {
+		t.Parallel()
+		key := scrypt([]byte(""), []byte(""), 16, 1, 1, 64)
+		assert.Equals(t, hex.EncodeToString(key), "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442"+
+			"fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906")
+		key = scrypt([]byte("password"), []byte("NaCl"), 1024, 8, 16, 64)
+		assert.Equals(t, hex.EncodeToString(key), "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162"+
+			"2eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640")
+	})
+
+	t.Run("when the parameters are valid it should derive a key of the requested length", func(t *testing.T) {
+		t.Parallel()
+		params := &ScryptParams{CostLog2: 10, BlockSize: 8, Parallelism: 1, KeyLength: 24}
+		key, err := params.Derive([]byte("password"), []byte("salt"))
+		assert.NoError(t, err)
+		assert.Equals(t, len(key), 24)
+	})
+
+	t.Run("when the default parameters are requested it should return the recommended values", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, DefaultScryptParams(), &ScryptParams{CostLog2: 15, BlockSize: 8, Parallelism: 1, KeyLength: 32})
+	})
+
+	t.Run("when the parameters are invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			params   *ScryptParams
+			expected string
+		}{
+			{&ScryptParams{CostLog2: 0, BlockSize: 8, Parallelism: 1, KeyLength: 32}, "cost"},
+			{&ScryptParams{CostLog2: 31, BlockSize: 8, Parallelism: 1, KeyLength: 32}, "cost"},
+			{&ScryptParams{CostLog2: 10, BlockSize: 0, Parallelism: 1, KeyLength: 32}, "block size"},
+			{&ScryptParams{CostLog2: 10, BlockSize: 8, Parallelism: 0, KeyLength: 32}, "block size"},
+			{&ScryptParams{CostLog2: 30, BlockSize: 8, Parallelism: 1, KeyLength: 32}, "too much memory"},
+			{&ScryptParams{CostLog2: 10, BlockSize: 8, Parallelism: 1, KeyLength: 0}, "key length"},
+		}
+		for _, testCase := range testCases {
+			key, err := testCase.params.Derive([]byte("password"), []byte("salt"))
+			assert.ErrorPart(t, err, testCase.expected)
+			assert.Nil(t, key)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package keyprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileConfig is the configuration of the file provider.
+type fileConfig struct {
+	keySize int
+	random  io.Reader
+}
+
+// FileOption configures the file provider.
+type FileOption func(*fileConfig)
+
+// WithKeySize overwrites the number of bytes of key material in new keys. The default is 32.
+func WithKeySize(size int) FileOption {
+	if size <= 0 {
+		panic("The key size must be greater than zero.")
+	}
+	return func(c *fileConfig) {
+		c.keySize = size
+	}
+}
+
+// WithRandomReader overwrites the source of randomness of new keys.
+func WithRandomReader(random io.Reader) FileOption {
+	return func(c *fileConfig) {
+		c.random = random
+	}
+}
+
+// configureFile creates a fileConfig out of the provided options.
+func configureFile(opts ...FileOption) *fileConfig {
+	cfg := &fileConfig{
+		keySize: 32,
+		random:  rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// keyFile is the format of the file of the file provider.
+type keyFile struct {
+	Current string     `json:"current"`
+	Keys    []*fileKey `json:"keys"`
+}
+
+// fileKey is the format of a key in the file of the file provider.
+type fileKey struct {
+	ID        string    `json:"id"`
+	Material  []byte    `json:"material"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FileProvider is a KeyProvider that stores its keys in a JSON file that only the owner can read.
+type FileProvider struct {
+	path   string
+	config *fileConfig
+	lock   sync.RWMutex
+	keys   map[string]*Key
+	file   keyFile
+}
+
+// Ensure FileProvider implements KeyProvider.
+var _ KeyProvider = (*FileProvider)(nil)
+
+// NewFileProvider loads the keys in the file. If the file does not exist, it is created with a new key.
+func NewFileProvider(path string, opts ...FileOption) (*FileProvider, error) {
+	provider := &FileProvider{
+		path:   path,
+		config: configureFile(opts...),
+		keys:   make(map[string]*Key),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if _, err := provider.Rotate(context.Background()); err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key file (%w)", err)
+	}
+
+	if err := json.Unmarshal(data, &provider.file); err != nil {
+		return nil, fmt.Errorf("failed to decode the key file (%w)", err)
+	}
+	for _, key := range provider.file.Keys {
+		provider.keys[key.ID] = &Key{ID: key.ID, Material: key.Material, CreatedAt: key.CreatedAt}
+	}
+	if _, found := provider.keys[provider.file.Current]; !found {
+		return nil, fmt.Errorf("the current key %q is not in the key file", provider.file.Current)
+	}
+	return provider, nil
+}
+
+// GetKey returns the key with the ID, or ErrKeyNotFound if there is no such key.
+func (p *FileProvider) GetKey(_ context.Context, id string) (*Key, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	key, found := p.keys[id]
+	if !found {
+		return nil, fmt.Errorf("no key with ID %q (%w)", id, ErrKeyNotFound)
+	}
+	return copyKey(key), nil
+}
+
+// CurrentKey returns the key that should be used to protect new data.
+func (p *FileProvider) CurrentKey(_ context.Context) (*Key, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return copyKey(p.keys[p.file.Current]), nil
+}
+
+// Rotate creates a new key, makes it the current key, and saves it to the file.
+func (p *FileProvider) Rotate(_ context.Context) (*Key, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	idBytes := make([]byte, 8)
+	if _, err := io.ReadFull(p.config.random, idBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate the key ID (%w)", err)
+	}
+	material := make([]byte, p.config.keySize)
+	if _, err := io.ReadFull(p.config.random, material); err != nil {
+		return nil, fmt.Errorf("failed to generate the key material (%w)", err)
+	}
+	key := &Key{
+		ID:        hex.EncodeToString(idBytes),
+		Material:  material,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	file := keyFile{
+		Current: key.ID,
+		Keys:    append(append([]*fileKey{}, p.file.Keys...), &fileKey{ID: key.ID, Material: key.Material, CreatedAt: key.CreatedAt}),
+	}
+	if err := p.save(&file); err != nil {
+		return nil, err
+	}
+	p.file = file
+	p.keys[key.ID] = key
+	return copyKey(key), nil
+}
+
+// save atomically replaces the key file.
+func (p *FileProvider) save(file *keyFile) error {
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode the key file (%w)", err)
+	}
+	temp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary key file (%w)", err)
+	}
+	defer func() {
+		_ = os.Remove(temp.Name())
+	}()
+	_, writeErr := temp.Write(data)
+	closeErr := temp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write the temporary key file (%w)", err)
+	}
+	if err := os.Rename(temp.Name(), p.path); err != nil {
+		return fmt.Errorf("failed to replace the key file (%w)", err)
+	}
+	return nil
+}
+
+// copyKey returns a copy of the key so callers cannot modify the stored material.
+func copyKey(key *Key) *Key {
+	return &Key{
+		ID:        key.ID,
+		Material:  append([]byte(nil), key.Material...),
+		CreatedAt: key.CreatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package keyprovider_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/keyprovider"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("when the file does not exist it should be created with a current key", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "keys.json")
+		provider, err := keyprovider.NewFileProvider(path)
+		assert.NoError(t, err)
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equals(t, info.Mode().Perm(), os.FileMode(0600))
+		key, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		assert.Equals(t, len(key.Material), 32)
+		assert.Equals(t, len(key.ID), 16)
+		assert.False(t, key.CreatedAt.IsZero())
+		byID, err := provider.GetKey(ctx, key.ID)
+		assert.NoError(t, err)
+		assert.Equals(t, byID, key)
+	})
+
+	t.Run("when the keys are rotated the old keys should still be available", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"), keyprovider.WithKeySize(16))
+		assert.NoError(t, err)
+		original, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		rotated, err := provider.Rotate(ctx)
+		assert.NoError(t, err)
+		assert.NotEquals(t, rotated.ID, original.ID)
+		assert.NotEquals(t, rotated.Material, original.Material)
+		assert.Equals(t, len(rotated.Material), 16)
+		current, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		assert.Equals(t, current, rotated)
+		old, err := provider.GetKey(ctx, original.ID)
+		assert.NoError(t, err)
+		assert.Equals(t, old, original)
+	})
+
+	t.Run("when the file is loaded again it should have the same keys", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "keys.json")
+		provider, err := keyprovider.NewFileProvider(path)
+		assert.NoError(t, err)
+		original, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		rotated, err := provider.Rotate(ctx)
+		assert.NoError(t, err)
+
+		reloaded, err := keyprovider.NewFileProvider(path)
+		assert.NoError(t, err)
+		current, err := reloaded.CurrentKey(ctx)
+		assert.NoError(t, err)
+		assert.Equals(t, current, rotated)
+		old, err := reloaded.GetKey(ctx, original.ID)
+		assert.NoError(t, err)
+		assert.Equals(t, old, original)
+	})
+
+	t.Run("when a returned key is modified it should not change the stored key", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"))
+		assert.NoError(t, err)
+		key, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		expected := append([]byte(nil), key.Material...)
+		key.Material[0] ^= 0xFF
+		again, err := provider.CurrentKey(ctx)
+		assert.NoError(t, err)
+		assert.Equals(t, again.Material, expected)
+	})
+
+	t.Run("when the key does not exist it should return ErrKeyNotFound", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"))
+		assert.NoError(t, err)
+		key, err := provider.GetKey(ctx, "missing")
+		assert.ErrorExact(t, err, `no key with ID "missing" (key not found)`)
+		assert.True(t, errors.Is(err, keyprovider.ErrKeyNotFound))
+		assert.Nil(t, key)
+	})
+
+	t.Run("when the file is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		invalidJSON := filepath.Join(dir, "invalid.json")
+		assert.NoError(t, os.WriteFile(invalidJSON, []byte("{"), 0600))
+		_, err := keyprovider.NewFileProvider(invalidJSON)
+		assert.ErrorPart(t, err, "failed to decode the key file")
+
+		missingCurrent := filepath.Join(dir, "missing_current.json")
+		assert.NoError(t, os.WriteFile(missingCurrent, []byte(`{"current":"a","keys":[]}`), 0600))
+		_, err = keyprovider.NewFileProvider(missingCurrent)
+		assert.ErrorExact(t, err, `the current key "a" is not in the key file`)
+
+		_, err = keyprovider.NewFileProvider(dir)
+		assert.ErrorPart(t, err, "failed to read the key file")
+	})
+
+	t.Run("when the file cannot be written it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "missing", "keys.json"))
+		assert.ErrorPart(t, err, "failed to create the temporary key file")
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "keys.json")
+		provider, err := keyprovider.NewFileProvider(path)
+		assert.NoError(t, err)
+		assert.NoError(t, os.Remove(path))
+		assert.NoError(t, os.Mkdir(path, 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(path, "file"), nil, 0600))
+		_, err = provider.Rotate(ctx)
+		assert.ErrorPart(t, err, "failed to replace the key file")
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		random := keyprovider.WithRandomReader(iotest.ErrReader(errors.New("random error")))
+		_, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"), random)
+		assert.ErrorExact(t, err, "failed to generate the key ID (random error)")
+
+		random = keyprovider.WithRandomReader(io.MultiReader(bytes.NewReader(make([]byte, 8)), iotest.ErrReader(errors.New("random error"))))
+		_, err = keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"), random)
+		assert.ErrorExact(t, err, "failed to generate the key material (random error)")
+	})
+
+	t.Run("when keys are rotated and read concurrently it should be safe", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"))
+		assert.NoError(t, err)
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := provider.Rotate(ctx)
+				assert.NoError(t, err, assert.Continue())
+			}()
+			go func() {
+				defer wg.Done()
+				key, err := provider.CurrentKey(ctx)
+				assert.NoError(t, err, assert.Continue())
+				_, err = provider.GetKey(ctx, key.ID)
+				assert.NoError(t, err, assert.Continue())
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("when the key size is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() { keyprovider.WithKeySize(0) }, "The key size must be greater than zero.")
+	})
+}
@@ -0,0 +1,36 @@
+package keyprovider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrKeyNotFound is returned when a provider does not have a key with the requested ID.
+var ErrKeyNotFound = errors.New("key not found")
+
+// Key is versioned key material.
+type Key struct {
+	// ID identifies the key. It can be stored with data that was protected by the key.
+	ID string
+
+	// Material is the secret key material.
+	Material []byte
+
+	// CreatedAt is when the key was created.
+	CreatedAt time.Time
+}
+
+// KeyProvider stores key material so that it does not need to be passed around as configuration strings.
+// Old keys remain available after a rotation so data protected by them can still be read.
+// Implementations must be safe for concurrent use.
+type KeyProvider interface {
+	// GetKey returns the key with the ID, or ErrKeyNotFound if there is no such key.
+	GetKey(ctx context.Context, id string) (*Key, error)
+
+	// CurrentKey returns the key that should be used to protect new data.
+	CurrentKey(ctx context.Context) (*Key, error)
+
+	// Rotate creates a new key and makes it the current key.
+	Rotate(ctx context.Context) (*Key, error)
+}
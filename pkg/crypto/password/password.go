@@ -0,0 +1,112 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/kdf"
+)
+
+// config is the configuration for hashing passwords.
+type config struct {
+	params     *kdf.Argon2idParams
+	saltLength int
+	random     io.Reader
+}
+
+// Option is optional configuration for hashing passwords.
+type Option func(*config)
+
+// WithParams overwrites the argon2id parameters of new hashes.
+// Hashes that were made with other parameters are upgraded when they are verified.
+func WithParams(params *kdf.Argon2idParams) Option {
+	if params == nil {
+		panic("The argon2id parameters cannot be nil.")
+	}
+	return func(c *config) {
+		c.params = params
+	}
+}
+
+// WithSaltLength overwrites the number of random bytes in the salt of new hashes.
+func WithSaltLength(length int) Option {
+	if length <= 0 {
+		panic("The salt length must be greater than zero.")
+	}
+	return func(c *config) {
+		c.saltLength = length
+	}
+}
+
+// WithRandomReader overwrites the source of randomness of the salt.
+func WithRandomReader(random io.Reader) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		params:     kdf.DefaultArgon2idParams(),
+		saltLength: 16,
+		random:     rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Hash hashes the password with argon2id and a random salt.
+// The result is in the PHC string format and contains everything needed to verify the password.
+func Hash(password string, opts ...Option) (string, error) {
+	return hash(password, configure(opts...))
+}
+
+// Verify checks if the password matches the hash. Hashes of any algorithm supported by the kdf package can be verified.
+// When the password matches, but the hash was not made with the configured argon2id parameters and salt length,
+// a new hash of the password is returned so it can replace the stored one. Otherwise, the returned hash is empty.
+func Verify(password string, encoded string, opts ...Option) (bool, string, error) {
+	cfg := configure(opts...)
+
+	params, salt, expected, err := kdf.Decode(encoded)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to decode the hash (%w)", err)
+	}
+	if len(expected) == 0 {
+		return false, "", errors.New("the hash does not contain a key")
+	}
+	actual, err := params.Derive([]byte(password), salt)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to hash the password (%w)", err)
+	}
+	if subtle.ConstantTimeCompare(actual, expected) != 1 {
+		return false, "", nil
+	}
+
+	if kdf.Encode(params, nil, nil) == kdf.Encode(cfg.params, nil, nil) && len(salt) >= cfg.saltLength {
+		return true, "", nil
+	}
+	upgraded, err := hash(password, cfg)
+	if err != nil {
+		return true, "", fmt.Errorf("failed to upgrade the hash (%w)", err)
+	}
+	return true, upgraded, nil
+}
+
+// hash hashes the password with the configuration.
+func hash(password string, cfg *config) (string, error) {
+	salt := make([]byte, cfg.saltLength)
+	if _, err := io.ReadFull(cfg.random, salt); err != nil {
+		return "", fmt.Errorf("failed to generate the salt (%w)", err)
+	}
+	key, err := cfg.params.Derive([]byte(password), salt)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the password (%w)", err)
+	}
+	return kdf.Encode(cfg.params, salt, key), nil
+}
@@ -0,0 +1,132 @@
+package password_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/kdf"
+	"github.com/TriangleSide/GoTools/pkg/crypto/password"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// failingReader is a source of randomness that always fails.
+type failingReader struct{}
+
+// Read returns an error.
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("random reader error")
+}
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	fastParams := &kdf.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, KeyLength: 32}
+	upgradedParams := &kdf.Argon2idParams{Memory: 128, Iterations: 2, Parallelism: 1, KeyLength: 32}
+
+	t.Run("when a password is hashed with the defaults it should be verified", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$"))
+		match, upgraded, err := password.Verify("secret", hash)
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.Equals(t, upgraded, "")
+	})
+
+	t.Run("when the password is wrong it should not match", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret", password.WithParams(fastParams))
+		assert.NoError(t, err)
+		match, upgraded, err := password.Verify("wrong", hash, password.WithParams(fastParams))
+		assert.NoError(t, err)
+		assert.False(t, match)
+		assert.Equals(t, upgraded, "")
+	})
+
+	t.Run("when the same password is hashed twice it should have different hashes", func(t *testing.T) {
+		t.Parallel()
+		first, err := password.Hash("secret", password.WithParams(fastParams))
+		assert.NoError(t, err)
+		second, err := password.Hash("secret", password.WithParams(fastParams))
+		assert.NoError(t, err)
+		assert.NotEquals(t, first, second)
+	})
+
+	t.Run("when the parameters have changed it should return an upgraded hash", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret", password.WithParams(fastParams))
+		assert.NoError(t, err)
+		match, upgraded, err := password.Verify("secret", hash, password.WithParams(upgradedParams))
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.True(t, strings.HasPrefix(upgraded, "$argon2id$v=19$m=128,t=2,p=1$"))
+		match, upgradedAgain, err := password.Verify("secret", upgraded, password.WithParams(upgradedParams))
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.Equals(t, upgradedAgain, "")
+	})
+
+	t.Run("when the salt is shorter than configured it should return an upgraded hash", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret", password.WithParams(fastParams), password.WithSaltLength(8))
+		assert.NoError(t, err)
+		match, upgraded, err := password.Verify("secret", hash, password.WithParams(fastParams))
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.NotEquals(t, upgraded, "")
+	})
+
+	t.Run("when the hash uses another algorithm it should be verified and upgraded to argon2id", func(t *testing.T) {
+		t.Parallel()
+		params := &kdf.PBKDF2Params{Iterations: 10, KeyLength: 32}
+		salt := []byte("0123456789abcdef")
+		key, err := params.Derive([]byte("secret"), salt)
+		assert.NoError(t, err)
+		match, upgraded, err := password.Verify("secret", kdf.Encode(params, salt, key), password.WithParams(fastParams))
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.True(t, strings.HasPrefix(upgraded, "$argon2id$"))
+	})
+
+	t.Run("when the upgrade fails it should return the match and an error", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret", password.WithParams(fastParams))
+		assert.NoError(t, err)
+		match, upgraded, err := password.Verify("secret", hash, password.WithParams(upgradedParams), password.WithRandomReader(failingReader{}))
+		assert.ErrorExact(t, err, "failed to upgrade the hash (failed to generate the salt (random reader error))")
+		assert.True(t, match)
+		assert.Equals(t, upgraded, "")
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		hash, err := password.Hash("secret", password.WithRandomReader(failingReader{}))
+		assert.ErrorExact(t, err, "failed to generate the salt (random reader error)")
+		assert.Equals(t, hash, "")
+	})
+
+	t.Run("when the parameters are invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := password.Hash("secret", password.WithParams(&kdf.Argon2idParams{}))
+		assert.ErrorPart(t, err, "failed to hash the password")
+		_, _, err = password.Verify("secret", "$argon2id$v=19$m=1,t=1,p=1$c2FsdA$aGFzaA")
+		assert.ErrorPart(t, err, "failed to hash the password")
+	})
+
+	t.Run("when the hash is malformed it should return an error", func(t *testing.T) {
+		t.Parallel()
+		match, _, err := password.Verify("secret", "not a hash")
+		assert.ErrorPart(t, err, "failed to decode the hash")
+		assert.False(t, match)
+		_, _, err = password.Verify("secret", "$pbkdf2-sha256$i=1,l=32$c2FsdA")
+		assert.ErrorExact(t, err, "the hash does not contain a key")
+	})
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() { password.WithParams(nil) }, "The argon2id parameters cannot be nil.")
+		assert.PanicExact(t, func() { password.WithSaltLength(0) }, "The salt length must be greater than zero.")
+	})
+}
@@ -0,0 +1,277 @@
+package symmetric
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// streamVersion is the version of the stream format.
+	streamVersion = 1
+
+	// defaultChunkSize is the default number of plaintext bytes in each chunk of a stream.
+	defaultChunkSize = 64 * 1024
+
+	// maxChunkSize is the largest number of plaintext bytes in each chunk of a stream.
+	maxChunkSize = 16 * 1024 * 1024
+
+	// streamSaltSize is the number of random bytes used to derive the key of a stream.
+	streamSaltSize = 32
+
+	// streamHeaderSize is the size of the version, chunk size, and salt at the start of a stream.
+	streamHeaderSize = 1 + 4 + streamSaltSize
+
+	// streamKeyInfo binds the keys derived for streams to their use.
+	streamKeyInfo = "GoTools symmetric stream"
+
+	// counterOffset is the position of the chunk counter in a chunk nonce.
+	counterOffset = 7
+)
+
+// chunkNonces creates the nonce of each chunk of a stream.
+// Each stream has its own key, so the nonce is deterministic. It is the chunk counter followed by a flag
+// that marks the last chunk. The flag prevents a truncated stream from being decrypted, and the counter
+// prevents chunks from being reordered.
+type chunkNonces struct {
+	nonce   [counterOffset + 4 + 1]byte
+	counter uint32
+}
+
+// next returns the nonce of the next chunk.
+func (n *chunkNonces) next(last bool) ([]byte, error) {
+	if n.counter == ^uint32(0) {
+		return nil, errors.New("the stream has too many chunks")
+	}
+	binary.BigEndian.PutUint32(n.nonce[counterOffset:], n.counter)
+	n.nonce[len(n.nonce)-1] = 0
+	if last {
+		n.nonce[len(n.nonce)-1] = 1
+	}
+	n.counter++
+	return n.nonce[:], nil
+}
+
+// streamEncrypter encrypts the data written to it in authenticated chunks.
+type streamEncrypter struct {
+	dst    io.Writer
+	aead   cipher.AEAD
+	header []byte
+	nonces chunkNonces
+	buffer []byte
+	sealed []byte
+	closed bool
+}
+
+// NewStreamEncrypter returns a writer that encrypts the data written to it with AES-GCM and writes it to dst.
+// The data is split into chunks that are authenticated individually, so streams of any size can be encrypted
+// without holding them in memory. Each stream is encrypted with its own key, derived from the key and a
+// random salt in the stream header. Close must be called to write the last chunk. It does not close dst.
+func NewStreamEncrypter(dst io.Writer, key string, opts ...Option) (io.WriteCloser, error) {
+	cfg := configure(opts...)
+	hash, err := hashKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, streamHeaderSize)
+	header[0] = streamVersion
+	binary.BigEndian.PutUint32(header[1:], uint32(cfg.chunkSize))
+	if err := cfg.randomDataFunc(header[5:]); err != nil {
+		return nil, fmt.Errorf("failed to generate the stream salt (%w)", err)
+	}
+	aead, err := newStreamAEAD(hash, header[5:], cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write the stream header (%w)", err)
+	}
+
+	encrypter := &streamEncrypter{
+		dst:    dst,
+		aead:   aead,
+		header: header,
+		buffer: make([]byte, 0, cfg.chunkSize),
+		sealed: make([]byte, 0, cfg.chunkSize+aead.Overhead()),
+	}
+	return encrypter, nil
+}
+
+// Write encrypts the data. A chunk is only written once the data after it is known, since the last chunk is marked.
+func (e *streamEncrypter) Write(data []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("the stream encrypter is closed")
+	}
+	written := 0
+	for len(data) > 0 {
+		if len(e.buffer) == cap(e.buffer) {
+			if err := e.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(e.buffer[len(e.buffer):cap(e.buffer)], data)
+		e.buffer = e.buffer[:len(e.buffer)+n]
+		data = data[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk of the stream.
+func (e *streamEncrypter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+// flush encrypts and writes the buffered chunk.
+func (e *streamEncrypter) flush(last bool) error {
+	nonce, err := e.nonces.next(last)
+	if err != nil {
+		return err
+	}
+	e.sealed = e.aead.Seal(e.sealed[:0], nonce, e.buffer, e.header)
+	e.buffer = e.buffer[:0]
+	if _, err := e.dst.Write(e.sealed); err != nil {
+		return fmt.Errorf("failed to write the chunk (%w)", err)
+	}
+	return nil
+}
+
+// streamDecrypter decrypts a stream created by a stream encrypter.
+type streamDecrypter struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	header  []byte
+	nonces  chunkNonces
+	sealed  []byte
+	plain   []byte
+	done    bool
+	failure error
+}
+
+// NewStreamDecrypter returns a reader that decrypts a stream written by the writer of NewStreamEncrypter.
+// Each chunk is authenticated before it is returned. An error is returned if the stream was modified,
+// reordered, or truncated, so data must not be trusted until the reader returns io.EOF.
+func NewStreamDecrypter(src io.Reader, key string, opts ...Option) (io.Reader, error) {
+	cfg := configure(opts...)
+	hash, err := hashKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		return nil, fmt.Errorf("failed to read the stream header (%w)", err)
+	}
+	if header[0] != streamVersion {
+		return nil, fmt.Errorf("unsupported stream version %d", header[0])
+	}
+	chunkSize := binary.BigEndian.Uint32(header[1:])
+	if chunkSize == 0 || chunkSize > maxChunkSize {
+		return nil, fmt.Errorf("invalid stream chunk size %d", chunkSize)
+	}
+	aead, err := newStreamAEAD(hash, header[5:], cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypter := &streamDecrypter{
+		src:    bufio.NewReader(src),
+		aead:   aead,
+		header: header,
+		sealed: make([]byte, int(chunkSize)+aead.Overhead()),
+	}
+	return decrypter, nil
+}
+
+// Read returns the decrypted data of the stream.
+func (d *streamDecrypter) Read(data []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.failure != nil {
+			return 0, d.failure
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.nextChunk(); err != nil {
+			d.failure = err
+			return 0, err
+		}
+	}
+	n := copy(data, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+// nextChunk reads and decrypts the next chunk of the stream.
+// A chunk is the last one if it is shorter than a full chunk, or if no data follows it.
+func (d *streamDecrypter) nextChunk() error {
+	n, err := io.ReadFull(d.src, d.sealed)
+	last := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF):
+		last = true
+	case err != nil:
+		return fmt.Errorf("failed to read the chunk (%w)", err)
+	default:
+		if _, peekErr := d.src.Peek(1); errors.Is(peekErr, io.EOF) {
+			last = true
+		} else if peekErr != nil {
+			return fmt.Errorf("failed to read the chunk (%w)", peekErr)
+		}
+	}
+
+	nonce, err := d.nonces.next(last)
+	if err != nil {
+		return err
+	}
+	plain, err := d.aead.Open(d.sealed[:0], nonce, d.sealed[:n], d.header)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate the chunk (%w)", err)
+	}
+	d.plain = plain
+	d.done = last
+	return nil
+}
+
+// newStreamAEAD creates the AES-GCM cipher of a stream with a key derived from the hashed key and the stream salt.
+func newStreamAEAD(hash []byte, salt []byte, cfg *config) (cipher.AEAD, error) {
+	streamKey := hkdfSHA256(hash, salt, []byte(streamKeyInfo), sha256.Size)
+	block, err := cfg.blockCypherProvider(streamKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the block cipher (%w)", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GCM cipher (%w)", err)
+	}
+	return aead, nil
+}
+
+// hkdfSHA256 derives a key of the requested length with HKDF (RFC 5869) using SHA-256.
+func hkdfSHA256(secret []byte, salt []byte, info []byte, length int) []byte {
+	extractor := hmac.New(sha256.New, salt)
+	extractor.Write(secret)
+	pseudoRandomKey := extractor.Sum(nil)
+
+	out := make([]byte, 0, length+sha256.Size)
+	var previous []byte
+	for counter := byte(1); len(out) < length; counter++ {
+		expander := hmac.New(sha256.New, pseudoRandomKey)
+		expander.Write(previous)
+		expander.Write(info)
+		expander.Write([]byte{counter})
+		previous = expander.Sum(nil)
+		out = append(out, previous...)
+	}
+	return out[:length]
+}
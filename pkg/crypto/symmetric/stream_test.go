@@ -0,0 +1,264 @@
+package symmetric_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/symmetric"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// failingWriter fails after a number of successful writes.
+type failingWriter struct {
+	successes int
+}
+
+// Write returns an error once the successful writes are used up.
+func (w *failingWriter) Write(data []byte) (int, error) {
+	if w.successes == 0 {
+		return 0, errors.New("writer error")
+	}
+	w.successes--
+	return len(data), nil
+}
+
+// failingReader returns an error after the data is read.
+type failingReader struct {
+	data io.Reader
+}
+
+// Read returns the data and then an error.
+func (r *failingReader) Read(data []byte) (int, error) {
+	n, err := r.data.Read(data)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("reader error")
+	}
+	return n, err
+}
+
+func encryptStream(t *testing.T, key string, plaintext []byte, opts ...symmetric.Option) []byte {
+	t.Helper()
+	encrypted := &bytes.Buffer{}
+	writer, err := symmetric.NewStreamEncrypter(encrypted, key, opts...)
+	assert.NoError(t, err)
+	n, err := writer.Write(plaintext)
+	assert.NoError(t, err)
+	assert.Equals(t, n, len(plaintext))
+	assert.NoError(t, writer.Close())
+	return encrypted.Bytes()
+}
+
+func decryptStream(key string, ciphertext []byte) ([]byte, error) {
+	reader, err := symmetric.NewStreamDecrypter(bytes.NewReader(ciphertext), key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func TestStreamEncryption(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when data of different sizes is streamed it should be decrypted", func(t *testing.T) {
+		t.Parallel()
+		const chunkSize = 16
+		for size := 0; size <= 4*chunkSize+1; size++ {
+			plaintext := make([]byte, size)
+			_, err := rand.Read(plaintext)
+			assert.NoError(t, err)
+			ciphertext := encryptStream(t, "key", plaintext, symmetric.WithChunkSize(chunkSize))
+			decrypted, err := decryptStream("key", ciphertext)
+			assert.NoError(t, err)
+			assert.Equals(t, decrypted, plaintext)
+		}
+	})
+
+	t.Run("when data is written in small pieces it should be decrypted", func(t *testing.T) {
+		t.Parallel()
+		plaintext := make([]byte, 200_000)
+		_, err := rand.Read(plaintext)
+		assert.NoError(t, err)
+		encrypted := &bytes.Buffer{}
+		writer, err := symmetric.NewStreamEncrypter(encrypted, "key")
+		assert.NoError(t, err)
+		for offset := 0; offset < len(plaintext); offset += 999 {
+			_, err := writer.Write(plaintext[offset:min(offset+999, len(plaintext))])
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, writer.Close())
+		_, err = writer.Write([]byte("more"))
+		assert.ErrorExact(t, err, "the stream encrypter is closed")
+
+		reader, err := symmetric.NewStreamDecrypter(encrypted, "key")
+		assert.NoError(t, err)
+		decrypted := &bytes.Buffer{}
+		buffer := make([]byte, 1234)
+		for {
+			n, err := reader.Read(buffer)
+			decrypted.Write(buffer[:n])
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.NoError(t, err)
+		}
+		assert.Equals(t, decrypted.Bytes(), plaintext)
+	})
+
+	t.Run("when the same data is streamed twice it should be encrypted with different stream keys", func(t *testing.T) {
+		t.Parallel()
+		var streamKeys [][]byte
+		recordKey := symmetric.WithBlockCypherProvider(func(key []byte) (cipher.Block, error) {
+			streamKeys = append(streamKeys, append([]byte{}, key...))
+			return aes.NewCipher(key)
+		})
+		plaintext := []byte("the same plaintext")
+		first := encryptStream(t, "key", plaintext, recordKey)
+		second := encryptStream(t, "key", plaintext, recordKey)
+		assert.Equals(t, len(streamKeys), 2)
+		hash := sha256.Sum256([]byte("key"))
+		assert.False(t, bytes.Equal(streamKeys[0], streamKeys[1]))
+		assert.False(t, bytes.Equal(streamKeys[0], hash[:]))
+		assert.False(t, bytes.Equal(first[5:], second[5:]))
+		for _, ciphertext := range [][]byte{first, second} {
+			decrypted, err := decryptStream("key", ciphertext)
+			assert.NoError(t, err)
+			assert.Equals(t, decrypted, plaintext)
+		}
+	})
+
+	t.Run("when the stream salt is modified it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext := encryptStream(t, "key", []byte("data"))
+		ciphertext[5] ^= 0xFF
+		_, err := decryptStream("key", ciphertext)
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+	})
+
+	t.Run("when the stream is decrypted with a different key it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext := encryptStream(t, "key", []byte("data"))
+		_, err := decryptStream("other", ciphertext)
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+	})
+
+	t.Run("when the stream is modified it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext := encryptStream(t, "key", []byte("data"))
+		ciphertext[len(ciphertext)-1] ^= 0xFF
+		_, err := decryptStream("key", ciphertext)
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+	})
+
+	t.Run("when the stream is truncated at a chunk boundary it should return an error", func(t *testing.T) {
+		t.Parallel()
+		const chunkSize = 16
+		ciphertext := encryptStream(t, "key", make([]byte, 3*chunkSize), symmetric.WithChunkSize(chunkSize))
+		const header = 37
+		const sealedChunk = chunkSize + 16
+		decrypted, err := decryptStream("key", ciphertext[:header+2*sealedChunk])
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+		assert.Equals(t, len(decrypted), chunkSize)
+		_, err = decryptStream("key", ciphertext[:header])
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+	})
+
+	t.Run("when the chunks are reordered it should return an error", func(t *testing.T) {
+		t.Parallel()
+		const chunkSize = 16
+		ciphertext := encryptStream(t, "key", make([]byte, 3*chunkSize), symmetric.WithChunkSize(chunkSize))
+		const header = 37
+		const sealedChunk = chunkSize + 16
+		reordered := append([]byte{}, ciphertext[:header]...)
+		reordered = append(reordered, ciphertext[header+sealedChunk:header+2*sealedChunk]...)
+		reordered = append(reordered, ciphertext[header:header+sealedChunk]...)
+		reordered = append(reordered, ciphertext[header+2*sealedChunk:]...)
+		_, err := decryptStream("key", reordered)
+		assert.ErrorPart(t, err, "failed to authenticate the chunk")
+	})
+
+	t.Run("when the header is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		ciphertext := encryptStream(t, "key", []byte("data"))
+		_, err := decryptStream("key", ciphertext[:5])
+		assert.ErrorPart(t, err, "failed to read the stream header")
+		badVersion := append([]byte{}, ciphertext...)
+		badVersion[0] = 9
+		_, err = decryptStream("key", badVersion)
+		assert.ErrorExact(t, err, "unsupported stream version 9")
+		badChunkSize := append([]byte{}, ciphertext...)
+		copy(badChunkSize[1:5], []byte{0xFF, 0xFF, 0xFF, 0xFF})
+		_, err = decryptStream("key", badChunkSize)
+		assert.ErrorExact(t, err, "invalid stream chunk size 4294967295")
+	})
+
+	t.Run("when the key is empty it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := symmetric.NewStreamEncrypter(&bytes.Buffer{}, "")
+		assert.ErrorExact(t, err, "invalid key")
+		_, err = symmetric.NewStreamDecrypter(&bytes.Buffer{}, "")
+		assert.ErrorExact(t, err, "invalid key")
+	})
+
+	t.Run("when the block cipher cannot be used with GCM it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := symmetric.NewStreamEncrypter(&bytes.Buffer{}, "key", symmetric.WithBlockCypherProvider(func([]byte) (cipher.Block, error) {
+			return smallBlock{}, nil
+		}))
+		assert.ErrorPart(t, err, "failed to create the GCM cipher")
+	})
+
+	t.Run("when the random data func fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := symmetric.NewStreamEncrypter(&bytes.Buffer{}, "key", symmetric.WithRandomDataFunc(func([]byte) error {
+			return errors.New("random data error")
+		}))
+		assert.ErrorExact(t, err, "failed to generate the stream salt (random data error)")
+	})
+
+	t.Run("when the destination fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := symmetric.NewStreamEncrypter(&failingWriter{}, "key")
+		assert.ErrorExact(t, err, "failed to write the stream header (writer error)")
+		writer, err := symmetric.NewStreamEncrypter(&failingWriter{successes: 1}, "key", symmetric.WithChunkSize(4))
+		assert.NoError(t, err)
+		n, err := writer.Write([]byte("more than one chunk"))
+		assert.ErrorExact(t, err, "failed to write the chunk (writer error)")
+		assert.Equals(t, n, 4)
+	})
+
+	t.Run("when the source fails it should return the error on every read", func(t *testing.T) {
+		t.Parallel()
+		ciphertext := encryptStream(t, "key", make([]byte, 100), symmetric.WithChunkSize(16))
+		reader, err := symmetric.NewStreamDecrypter(&failingReader{data: bytes.NewReader(ciphertext)}, "key")
+		assert.NoError(t, err)
+		_, err = io.ReadAll(reader)
+		assert.ErrorPart(t, err, "reader error")
+		_, err = reader.Read(make([]byte, 1))
+		assert.ErrorPart(t, err, "reader error")
+	})
+
+	t.Run("when the chunk size is invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() { symmetric.WithChunkSize(0) }, "The chunk size must be between 1 and 16777216.")
+		assert.PanicExact(t, func() { symmetric.WithChunkSize(16*1024*1024 + 1) }, "The chunk size must be between 1 and 16777216.")
+	})
+}
+
+// smallBlock is a block cipher with a block size that GCM does not support.
+type smallBlock struct{}
+
+// BlockSize returns a size that is not 16.
+func (smallBlock) BlockSize() int { return 8 }
+
+// Encrypt does nothing.
+func (smallBlock) Encrypt(_, _ []byte) {}
+
+// Decrypt does nothing.
+func (smallBlock) Decrypt(_, _ []byte) {}
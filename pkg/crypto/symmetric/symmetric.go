@@ -1,6 +1,7 @@
 package symmetric
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -8,12 +9,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/keyprovider"
 )
 
 // config is the configuration for the encryptor.
 type config struct {
 	blockCypherProvider func(key []byte) (cipher.Block, error)
 	randomDataFunc      func(buffer []byte) error
+	chunkSize           int
 }
 
 // Option is optional configuration of the encryptor.
@@ -33,41 +37,75 @@ func WithRandomDataFunc(randomDataFunc func(buffer []byte) error) Option {
 	}
 }
 
-// Encryptor does symmetric encryption and decryption.
-type Encryptor interface {
-	Encrypt(plaintext []byte) ([]byte, error)
-	Decrypt(ciphertext []byte) ([]byte, error)
-}
-
-// aesEncryptor holds the data needed to do AES symmetric encryption.
-type aesEncryptor struct {
-	aesBlock       cipher.Block
-	randomDataFunc func(buffer []byte) error
+// WithChunkSize overwrites the number of plaintext bytes in each chunk of a stream.
+func WithChunkSize(size int) Option {
+	if size <= 0 || size > maxChunkSize {
+		panic(fmt.Sprintf("The chunk size must be between 1 and %d.", maxChunkSize))
+	}
+	return func(c *config) {
+		c.chunkSize = size
+	}
 }
 
-// New allocates and configures an Encryptor.
-func New(key string, opts ...Option) (Encryptor, error) {
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
 	cfg := &config{
 		blockCypherProvider: aes.NewCipher,
 		randomDataFunc: func(buffer []byte) error {
 			_, err := io.ReadFull(rand.Reader, buffer)
 			return err
 		},
+		chunkSize: defaultChunkSize,
 	}
-
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	return cfg
+}
 
+// hashKey returns the SHA-256 hash of the key, which is the material the ciphers are created with.
+func hashKey(key string) ([]byte, error) {
 	if len(key) == 0 {
 		return nil, errors.New("invalid key")
 	}
 	hash := sha256.Sum256([]byte(key))
+	return hash[:], nil
+}
 
-	block, err := cfg.blockCypherProvider(hash[:])
+// newBlock creates the block cipher of the key.
+func newBlock(key string, cfg *config) (cipher.Block, error) {
+	hash, err := hashKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := cfg.blockCypherProvider(hash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the block cipher (%w)", err)
 	}
+	return block, nil
+}
+
+// Encryptor does symmetric encryption and decryption.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// aesEncryptor holds the data needed to do AES symmetric encryption.
+type aesEncryptor struct {
+	aesBlock       cipher.Block
+	randomDataFunc func(buffer []byte) error
+}
+
+// New allocates and configures an Encryptor.
+func New(key string, opts ...Option) (Encryptor, error) {
+	cfg := configure(opts...)
+
+	block, err := newBlock(key, cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	return &aesEncryptor{
 		aesBlock:       block,
@@ -75,6 +113,15 @@ func New(key string, opts ...Option) (Encryptor, error) {
 	}, nil
 }
 
+// NewFromKeyProvider allocates and configures an Encryptor with the material of a key from the provider.
+func NewFromKeyProvider(ctx context.Context, provider keyprovider.KeyProvider, id string, opts ...Option) (Encryptor, error) {
+	key, err := provider.GetKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the key (%w)", err)
+	}
+	return New(string(key.Material), opts...)
+}
+
 // Encrypt takes a slice of data and returns an encrypted version of that data using the AES algorithm.
 // It returns a cypher-text slice of data and an error if any occurs during the encryption process.
 func (encryptor *aesEncryptor) Encrypt(data []byte) ([]byte, error) {
@@ -1,13 +1,16 @@
 package symmetric_test
 
 import (
+	"context"
 	"crypto/cipher"
 	"crypto/rand"
 	"errors"
 	mathrand "math/rand"
+	"path/filepath"
 	"strconv"
 	"testing"
 
+	"github.com/TriangleSide/GoTools/pkg/crypto/keyprovider"
 	"github.com/TriangleSide/GoTools/pkg/crypto/symmetric"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
 )
@@ -103,3 +106,34 @@ func TestSymmetricEncryption(t *testing.T) {
 		assert.Nil(t, cypher)
 	})
 }
+
+func TestNewFromKeyProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the key exists it should create an encryptor with its material", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"))
+		assert.NoError(t, err)
+		key, err := provider.CurrentKey(context.Background())
+		assert.NoError(t, err)
+		encryptor, err := symmetric.NewFromKeyProvider(context.Background(), provider, key.ID)
+		assert.NoError(t, err)
+		encrypted, err := encryptor.Encrypt([]byte("data"))
+		assert.NoError(t, err)
+		sameKey, err := symmetric.New(string(key.Material))
+		assert.NoError(t, err)
+		decrypted, err := sameKey.Decrypt(encrypted)
+		assert.NoError(t, err)
+		assert.Equals(t, decrypted, []byte("data"))
+	})
+
+	t.Run("when the key does not exist it should return an error", func(t *testing.T) {
+		t.Parallel()
+		provider, err := keyprovider.NewFileProvider(filepath.Join(t.TempDir(), "keys.json"))
+		assert.NoError(t, err)
+		encryptor, err := symmetric.NewFromKeyProvider(context.Background(), provider, "missing")
+		assert.ErrorPart(t, err, "failed to get the key")
+		assert.True(t, errors.Is(err, keyprovider.ErrKeyNotFound))
+		assert.Nil(t, encryptor)
+	})
+}
@@ -0,0 +1,121 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Encoding is the alphabet of a token.
+type Encoding string
+
+const (
+	// EncodingHex encodes the token with lowercase hexadecimal digits.
+	EncodingHex Encoding = "HEX"
+
+	// EncodingBase64URL encodes the token with the unpadded URL-safe base64 alphabet.
+	EncodingBase64URL Encoding = "BASE64URL"
+
+	// EncodingAlphanumeric encodes the token with uppercase letters, lowercase letters, and digits.
+	EncodingAlphanumeric Encoding = "ALPHANUMERIC"
+)
+
+// alphanumeric is the alphabet of EncodingAlphanumeric.
+const alphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// config is the configuration for generating a token.
+type config struct {
+	encoding Encoding
+	entropy  int
+	random   io.Reader
+}
+
+// Option is optional configuration for generating a token.
+type Option func(*config)
+
+// WithEncoding overwrites the alphabet of the token. The default is EncodingBase64URL.
+func WithEncoding(encoding Encoding) Option {
+	switch encoding {
+	case EncodingHex, EncodingBase64URL, EncodingAlphanumeric:
+	default:
+		panic(fmt.Sprintf("The token encoding %q is not supported.", encoding))
+	}
+	return func(c *config) {
+		c.encoding = encoding
+	}
+}
+
+// WithEntropy overwrites the minimum number of random bits in the token. The default is 256.
+func WithEntropy(bits int) Option {
+	if bits <= 0 {
+		panic("The entropy must be greater than zero.")
+	}
+	return func(c *config) {
+		c.entropy = bits
+	}
+}
+
+// WithRandomReader overwrites the source of randomness of the token.
+func WithRandomReader(random io.Reader) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		encoding: EncodingBase64URL,
+		entropy:  256,
+		random:   rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Generate returns a cryptographically random token, for example, for session IDs, API keys, or nonces.
+func Generate(opts ...Option) (string, error) {
+	cfg := configure(opts...)
+	if cfg.encoding == EncodingAlphanumeric {
+		return generateAlphanumeric(cfg)
+	}
+
+	data := make([]byte, (cfg.entropy+7)/8)
+	if _, err := io.ReadFull(cfg.random, data); err != nil {
+		return "", fmt.Errorf("failed to read random data (%w)", err)
+	}
+	if cfg.encoding == EncodingHex {
+		return hex.EncodeToString(data), nil
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// generateAlphanumeric returns a token of alphanumeric characters.
+// Random bytes that would bias the characters are discarded.
+func generateAlphanumeric(cfg *config) (string, error) {
+	length := int(math.Ceil(float64(cfg.entropy) / math.Log2(float64(len(alphanumeric)))))
+	limit := byte(256 - 256%len(alphanumeric))
+
+	out := make([]byte, 0, length)
+	buffer := make([]byte, length)
+	for len(out) < length {
+		if _, err := io.ReadFull(cfg.random, buffer); err != nil {
+			return "", fmt.Errorf("failed to read random data (%w)", err)
+		}
+		for _, b := range buffer {
+			if b >= limit {
+				continue
+			}
+			out = append(out, alphanumeric[int(b)%len(alphanumeric)])
+			if len(out) == length {
+				break
+			}
+		}
+	}
+	return string(out), nil
+}
@@ -0,0 +1,90 @@
+package token_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/iotest"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/token"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when no options are provided it should return a base64url token with 256 bits", func(t *testing.T) {
+		t.Parallel()
+		generated, err := token.Generate()
+		assert.NoError(t, err)
+		decoded, err := base64.RawURLEncoding.DecodeString(generated)
+		assert.NoError(t, err)
+		assert.Equals(t, len(decoded), 32)
+	})
+
+	t.Run("when the encoding is hex it should return hex digits", func(t *testing.T) {
+		t.Parallel()
+		generated, err := token.Generate(token.WithEncoding(token.EncodingHex), token.WithEntropy(128))
+		assert.NoError(t, err)
+		decoded, err := hex.DecodeString(generated)
+		assert.NoError(t, err)
+		assert.Equals(t, len(decoded), 16)
+	})
+
+	t.Run("when the entropy is not a multiple of 8 it should round up to whole bytes", func(t *testing.T) {
+		t.Parallel()
+		generated, err := token.Generate(token.WithEncoding(token.EncodingHex), token.WithEntropy(65))
+		assert.NoError(t, err)
+		assert.Equals(t, len(generated), 18)
+	})
+
+	t.Run("when the encoding is alphanumeric it should have enough characters for the entropy", func(t *testing.T) {
+		t.Parallel()
+		pattern := regexp.MustCompile("^[A-Za-z0-9]+$")
+		for bits, length := range map[int]int{1: 1, 128: 22, 256: 43} {
+			generated, err := token.Generate(token.WithEncoding(token.EncodingAlphanumeric), token.WithEntropy(bits))
+			assert.NoError(t, err)
+			assert.Equals(t, len(generated), length)
+			assert.True(t, pattern.MatchString(generated))
+		}
+	})
+
+	t.Run("when random bytes would bias the alphanumeric characters it should discard them", func(t *testing.T) {
+		t.Parallel()
+		random := bytes.NewReader([]byte{255, 248, 0, 61, 62, 1, 1, 1})
+		generated, err := token.Generate(token.WithEncoding(token.EncodingAlphanumeric), token.WithEntropy(20), token.WithRandomReader(random))
+		assert.NoError(t, err)
+		assert.Equals(t, generated, "A9AB")
+	})
+
+	t.Run("when tokens are generated repeatedly it should not repeat", func(t *testing.T) {
+		t.Parallel()
+		seen := make(map[string]struct{})
+		for range 1000 {
+			generated, err := token.Generate(token.WithEntropy(64))
+			assert.NoError(t, err)
+			_, exists := seen[generated]
+			assert.False(t, exists)
+			seen[generated] = struct{}{}
+		}
+	})
+
+	t.Run("when the random reader fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		reader := iotest.ErrReader(errors.New("random error"))
+		for _, encoding := range []token.Encoding{token.EncodingHex, token.EncodingAlphanumeric} {
+			generated, err := token.Generate(token.WithEncoding(encoding), token.WithRandomReader(reader))
+			assert.ErrorExact(t, err, "failed to read random data (random error)")
+			assert.Equals(t, generated, "")
+		}
+	})
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() { token.WithEncoding("BASE32") }, `The token encoding "BASE32" is not supported.`)
+		assert.PanicExact(t, func() { token.WithEntropy(0) }, "The entropy must be greater than zero.")
+	})
+}
@@ -0,0 +1,102 @@
+package x509util
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"io"
+	"net"
+	"time"
+)
+
+// config is the configuration of a certificate.
+type config struct {
+	commonName   string
+	organization []string
+	validity     time.Duration
+	dnsNames     []string
+	ipAddresses  []net.IP
+	extKeyUsages []x509.ExtKeyUsage
+	keyGenerator func(random io.Reader) (crypto.Signer, error)
+	random       io.Reader
+}
+
+// Option configures a certificate.
+type Option func(*config)
+
+// WithCommonName sets the common name of the subject of the certificate.
+func WithCommonName(commonName string) Option {
+	return func(c *config) {
+		c.commonName = commonName
+	}
+}
+
+// WithOrganization sets the organizations of the subject of the certificate.
+func WithOrganization(organization ...string) Option {
+	return func(c *config) {
+		c.organization = organization
+	}
+}
+
+// WithValidity sets how long the certificate is valid for. The default is one year.
+func WithValidity(validity time.Duration) Option {
+	if validity <= 0 {
+		panic("The validity must be greater than zero.")
+	}
+	return func(c *config) {
+		c.validity = validity
+	}
+}
+
+// WithDNSNames adds DNS subject alternative names to the certificate.
+func WithDNSNames(dnsNames ...string) Option {
+	return func(c *config) {
+		c.dnsNames = append(c.dnsNames, dnsNames...)
+	}
+}
+
+// WithIPAddresses adds IP subject alternative names to the certificate.
+func WithIPAddresses(ipAddresses ...net.IP) Option {
+	return func(c *config) {
+		c.ipAddresses = append(c.ipAddresses, ipAddresses...)
+	}
+}
+
+// WithExtKeyUsages sets the extended key usages of a leaf certificate. The default is server authentication.
+func WithExtKeyUsages(usages ...x509.ExtKeyUsage) Option {
+	return func(c *config) {
+		c.extKeyUsages = usages
+	}
+}
+
+// WithKeyGenerator overwrites how the private key of the certificate is generated. The default is ECDSA P-256.
+func WithKeyGenerator(keyGenerator func(random io.Reader) (crypto.Signer, error)) Option {
+	return func(c *config) {
+		c.keyGenerator = keyGenerator
+	}
+}
+
+// WithRandomReader overwrites the source of randomness of the keys, serial numbers, and signatures.
+func WithRandomReader(random io.Reader) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		validity:     365 * 24 * time.Hour,
+		extKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		keyGenerator: func(random io.Reader) (crypto.Signer, error) {
+			return ecdsa.GenerateKey(elliptic.P256(), random)
+		},
+		random: rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,177 @@
+package x509util
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/asymmetric"
+)
+
+// certificateBlockType is the PEM block type of certificates.
+const certificateBlockType = "CERTIFICATE"
+
+// Certificate is a certificate and its private key.
+type Certificate struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+}
+
+// NewCA creates a self-signed certificate authority.
+func NewCA(opts ...Option) (*Certificate, error) {
+	cfg := configure(opts...)
+	template, err := newTemplate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	return create(cfg, template, nil)
+}
+
+// NewLeaf creates a certificate that is signed by the issuer.
+func NewLeaf(issuer *Certificate, opts ...Option) (*Certificate, error) {
+	if issuer == nil {
+		return nil, errors.New("the issuer cannot be nil")
+	}
+	cfg := configure(opts...)
+	template, err := newTemplate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = cfg.extKeyUsages
+	return create(cfg, template, issuer)
+}
+
+// newTemplate creates the parts of a certificate that are common to CAs and leaves.
+func newTemplate(cfg *config) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(cfg.random, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the serial number (%w)", err)
+	}
+	notBefore := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   cfg.commonName,
+			Organization: cfg.organization,
+		},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(cfg.validity),
+		DNSNames:              cfg.dnsNames,
+		IPAddresses:           cfg.ipAddresses,
+		BasicConstraintsValid: true,
+	}, nil
+}
+
+// create generates the key of the certificate and signs it by the issuer, or by itself if the issuer is nil.
+func create(cfg *config, template *x509.Certificate, issuer *Certificate) (*Certificate, error) {
+	privateKey, err := cfg.keyGenerator(cfg.random)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the private key (%w)", err)
+	}
+
+	parent := template
+	signer := privateKey
+	if issuer != nil {
+		parent = issuer.Certificate
+		signer = issuer.PrivateKey
+	}
+	der, err := x509.CreateCertificate(cfg.random, template, parent, privateKey.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate (%w)", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate (%w)", err)
+	}
+	return &Certificate{
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+	}, nil
+}
+
+// CertificatePEM returns the certificate as a PEM block.
+func (c *Certificate) CertificatePEM() []byte {
+	return EncodeCertificatePEM(c.Certificate)
+}
+
+// PrivateKeyPEM returns the private key as a PKCS #8 PEM block.
+func (c *Certificate) PrivateKeyPEM() ([]byte, error) {
+	return asymmetric.MarshalPrivateKeyPEM(c.PrivateKey)
+}
+
+// TLSCertificate returns the certificate and its private key for a tls.Config.
+func (c *Certificate) TLSCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{c.Certificate.Raw},
+		PrivateKey:  c.PrivateKey,
+		Leaf:        c.Certificate,
+	}
+}
+
+// WriteFiles writes the certificate and its private key as PEM files.
+// The private key can only be read by the owner.
+func (c *Certificate) WriteFiles(certificatePath string, privateKeyPath string) error {
+	if err := os.WriteFile(certificatePath, c.CertificatePEM(), 0644); err != nil {
+		return fmt.Errorf("failed to write the certificate (%w)", err)
+	}
+	if err := asymmetric.SavePrivateKey(privateKeyPath, c.PrivateKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Load reads a certificate and its private key from PEM files.
+func Load(certificatePath string, privateKeyPath string) (*Certificate, error) {
+	certificatePEM, err := os.ReadFile(certificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the certificate (%w)", err)
+	}
+	certificate, err := ParseCertificatePEM(certificatePEM)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := asymmetric.LoadPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("the private key of type %T cannot sign", privateKey)
+	}
+	return &Certificate{
+		Certificate: certificate,
+		PrivateKey:  signer,
+	}, nil
+}
+
+// EncodeCertificatePEM returns the certificate as a PEM block.
+func EncodeCertificatePEM(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: certificateBlockType, Bytes: certificate.Raw})
+}
+
+// ParseCertificatePEM decodes the first certificate in PEM data.
+func ParseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != certificateBlockType {
+		return nil, fmt.Errorf("PEM block type %q is not %q", block.Type, certificateBlockType)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate (%w)", err)
+	}
+	return certificate, nil
+}
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 )
 
 const (
@@ -9,23 +10,32 @@ const (
 )
 
 // Config holds parameters for running a migration.
+//
+// The durations are strings like "1h" or "500ms". They replace the previous millisecond fields, so
+// MIGRATION_DEADLINE_MILLISECONDS=3600000 becomes MIGRATION_DEADLINE=1h, and likewise for the unlock deadline,
+// the heartbeat interval, and the migration timeout.
 type Config struct {
-	// DeadlineMilliseconds is the maximum time for the migrations to complete.
-	DeadlineMilliseconds int `config_format:"snake" config_default:"3600000" validate:"gt=0"`
+	// Deadline is the maximum time for the migrations to complete.
+	Deadline timestamp.Duration `config_format:"snake" config_default:"1h" validate:"gt=0"`
 
-	// UnlockDeadlineMilliseconds is the maximum time for a release operation to complete.
-	UnlockDeadlineMilliseconds int `config_format:"snake" config_default:"120000" validate:"gt=0"`
+	// UnlockDeadline is the maximum time for a release operation to complete.
+	UnlockDeadline timestamp.Duration `config_format:"snake" config_default:"2m" validate:"gt=0"`
 
-	// HeartbeatIntervalMilliseconds is how often a heart beat is sent to the migration lock.
-	HeartbeatIntervalMilliseconds int `config_format:"snake" config_default:"10000" validate:"gt=0"`
+	// HeartbeatInterval is how often a heart beat is sent to the migration lock.
+	HeartbeatInterval timestamp.Duration `config_format:"snake" config_default:"10s" validate:"gt=0"`
 
 	// HeartbeatFailureRetryCount is how many times to retry the heart beat before quitting.
 	HeartbeatFailureRetryCount int `config_format:"snake" config_default:"1" validate:"gte=0"`
+
+	// MigrationTimeout is the default maximum time for a single migration to complete.
+	// It is used when a Registration does not define a timeout. Zero means there is no per-migration timeout.
+	MigrationTimeout timestamp.Duration `config_format:"snake" config_default:"0s" validate:"gte=0"`
 }
 
 // migrateConfig is configured by the Option type.
 type migrateConfig struct {
 	configProvider func() (*Config, error)
+	targetOrder    *Order
 }
 
 // Option configures a migrateConfig instance.
@@ -37,6 +47,7 @@ func configure(opts ...Option) *migrateConfig {
 		configProvider: func() (*Config, error) {
 			return config.ProcessAndValidate[Config](config.WithPrefix(ConfigPrefix))
 		},
+		targetOrder: nil,
 	}
 	for _, opt := range opts {
 		opt(migrateCfg)
@@ -50,3 +61,11 @@ func WithConfigProvider(callback func() (*Config, error)) Option {
 		cfg.configProvider = callback
 	}
 }
+
+// WithTargetOrder provides an Option to stop the migrations after the given order.
+// Registered migrations with a greater order are not run.
+func WithTargetOrder(order Order) Option {
+	return func(cfg *migrateConfig) {
+		cfg.targetOrder = &order
+	}
+}
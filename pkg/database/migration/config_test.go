@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("when the defaults are used it should parse the durations", func(t *testing.T) {
+		cfg, err := config.ProcessAndValidate[Config](config.WithPrefix(ConfigPrefix))
+		assert.NoError(t, err)
+		assert.Equals(t, cfg.Deadline, timestamp.Duration(time.Hour))
+		assert.Equals(t, cfg.UnlockDeadline, timestamp.Duration(time.Minute*2))
+		assert.Equals(t, cfg.HeartbeatInterval, timestamp.Duration(time.Second*10))
+		assert.Equals(t, cfg.MigrationTimeout, timestamp.Duration(0))
+	})
+
+	t.Run("when the durations are set in the environment it should parse them", func(t *testing.T) {
+		t.Setenv("MIGRATION_DEADLINE", "30m")
+		t.Setenv("MIGRATION_MIGRATION_TIMEOUT", "5m")
+		cfg, err := config.ProcessAndValidate[Config](config.WithPrefix(ConfigPrefix))
+		assert.NoError(t, err)
+		assert.Equals(t, cfg.Deadline, timestamp.Duration(time.Minute*30))
+		assert.Equals(t, cfg.MigrationTimeout, timestamp.Duration(time.Minute*5))
+	})
+
+	t.Run("when a duration is zero and must be positive it should fail validation", func(t *testing.T) {
+		t.Setenv("MIGRATION_HEARTBEAT_INTERVAL", "0s")
+		_, err := config.ProcessAndValidate[Config](config.WithPrefix(ConfigPrefix))
+		assert.ErrorPart(t, err, "validation failed")
+	})
+}
@@ -0,0 +1,89 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Hook is invoked around a migration with its order and status.
+type Hook func(ctx context.Context, order Order, status Status) error
+
+// Hooks are callbacks invoked around each migration. Any of the hooks can be nil.
+type Hooks struct {
+	// BeforeMigrate is invoked once the status is persisted as STARTED and before the migration is run.
+	// If it returns an error, the migration is not run and is treated as failed.
+	BeforeMigrate Hook
+
+	// AfterMigrate is invoked once the migration is done and its status is persisted as COMPLETED.
+	AfterMigrate Hook
+
+	// OnFailure is invoked once the migration failed and its status is persisted as FAILED.
+	OnFailure Hook
+}
+
+var (
+	// registryHooks are the hooks invoked around every migration.
+	registryHooks     = make([]*Hooks, 0)
+	registryHooksLock = sync.RWMutex{}
+)
+
+// RegisterHooks stores hooks that are invoked around every migration in the registry.
+// Registry hooks are invoked before the hooks of the Registration.
+func RegisterHooks(hooks *Hooks) {
+	if hooks == nil {
+		panic("The hooks cannot be nil.")
+	}
+	registryHooksLock.Lock()
+	defer registryHooksLock.Unlock()
+	registryHooks = append(registryHooks, hooks)
+}
+
+// clearRegistryHooks removes all the registry hooks.
+func clearRegistryHooks() {
+	registryHooksLock.Lock()
+	defer registryHooksLock.Unlock()
+	registryHooks = make([]*Hooks, 0)
+}
+
+// hooksFor returns the registry hooks followed by the hooks of the registration.
+func hooksFor(registration *Registration) []*Hooks {
+	registryHooksLock.RLock()
+	defer registryHooksLock.RUnlock()
+	hooks := make([]*Hooks, 0, len(registryHooks)+1)
+	hooks = append(hooks, registryHooks...)
+	if registration.Hooks != nil {
+		hooks = append(hooks, registration.Hooks)
+	}
+	return hooks
+}
+
+// runHooks invokes the selected hook of each Hooks. All the hooks are run and their errors are joined.
+func runHooks(ctx context.Context, hooks []*Hooks, selector func(*Hooks) Hook, order Order, status Status) error {
+	var errs []error
+	for _, h := range hooks {
+		hook := selector(h)
+		if hook == nil {
+			continue
+		}
+		if err := hook(ctx, order, status); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// beforeMigrate selects the BeforeMigrate hook.
+func beforeMigrate(hooks *Hooks) Hook {
+	return hooks.BeforeMigrate
+}
+
+// afterMigrate selects the AfterMigrate hook.
+func afterMigrate(hooks *Hooks) Hook {
+	return hooks.AfterMigrate
+}
+
+// onFailure selects the OnFailure hook.
+func onFailure(hooks *Hooks) Hook {
+	return hooks.OnFailure
+}
@@ -0,0 +1,134 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestHooks(t *testing.T) {
+	recordingHooks := func(manager *managerRecorder, name string, beforeErr error, afterErr error, failureErr error) *Hooks {
+		record := func(hookName string, err error) Hook {
+			return func(ctx context.Context, order Order, status Status) error {
+				manager.Operations = append(manager.Operations, fmt.Sprintf("%s.%s(order=%d, status=%s)", name, hookName, order, status))
+				return err
+			}
+		}
+		return &Hooks{
+			BeforeMigrate: record("BeforeMigrate", beforeErr),
+			AfterMigrate:  record("AfterMigrate", afterErr),
+			OnFailure:     record("OnFailure", failureErr),
+		}
+	}
+
+	recordingRegistration := func(manager *managerRecorder, order Order, migrateErr error, hooks *Hooks) *Registration {
+		return &Registration{
+			Order: order,
+			Migrate: func(ctx context.Context) error {
+				manager.Operations = append(manager.Operations, fmt.Sprintf("Migration%d.Migrate()", order))
+				return migrateErr
+			},
+			Enabled: true,
+			Hooks:   hooks,
+		}
+	}
+
+	setup := func(t *testing.T) *managerRecorder {
+		t.Helper()
+		registry.Clear()
+		clearRegistryHooks()
+		t.Cleanup(func() {
+			registry.Clear()
+			clearRegistryHooks()
+		})
+		return &managerRecorder{}
+	}
+
+	t.Run("when the hooks are nil it should panic", func(t *testing.T) {
+		assert.PanicExact(t, func() {
+			RegisterHooks(nil)
+		}, "The hooks cannot be nil.")
+	})
+
+	t.Run("when the migrations succeed it should call the registry hooks then the registration hooks", func(t *testing.T) {
+		manager := setup(t)
+		RegisterHooks(recordingHooks(manager, "Registry", nil, nil, nil))
+		RegisterHooks(&Hooks{})
+		MustRegister(recordingRegistration(manager, 1, nil, recordingHooks(manager, "Registration", nil, nil, nil)))
+		MustRegister(recordingRegistration(manager, 2, nil, nil))
+		assert.NoError(t, Migrate(manager))
+		assert.Equals(t, manager.Operations, []string{
+			"AcquireDBLock()",
+			"EnsureDataStores()",
+			"ReleaseDBLock()",
+			"AcquireMigrationLock()",
+			"ListStatuses()",
+			"PersistStatus(order=1, status=PENDING)",
+			"PersistStatus(order=2, status=PENDING)",
+			"PersistStatus(order=1, status=STARTED)",
+			"Registry.BeforeMigrate(order=1, status=STARTED)",
+			"Registration.BeforeMigrate(order=1, status=STARTED)",
+			"Migration1.Migrate()",
+			"PersistStatus(order=1, status=COMPLETED)",
+			"Registry.AfterMigrate(order=1, status=COMPLETED)",
+			"Registration.AfterMigrate(order=1, status=COMPLETED)",
+			"PersistStatus(order=2, status=STARTED)",
+			"Registry.BeforeMigrate(order=2, status=STARTED)",
+			"Migration2.Migrate()",
+			"PersistStatus(order=2, status=COMPLETED)",
+			"Registry.AfterMigrate(order=2, status=COMPLETED)",
+		})
+	})
+
+	t.Run("when the migration fails it should call the failure hooks", func(t *testing.T) {
+		manager := setup(t)
+		RegisterHooks(recordingHooks(manager, "Registry", nil, nil, nil))
+		MustRegister(recordingRegistration(manager, 1, errors.New("migrate error"), recordingHooks(manager, "Registration", nil, nil, nil)))
+		err := Migrate(manager)
+		assert.ErrorPart(t, err, "failed to complete the migration with order 1 (migrate error)")
+		assert.Equals(t, manager.Operations[len(manager.Operations)-4:], []string{
+			"Migration1.Migrate()",
+			"PersistStatus(order=1, status=FAILED)",
+			"Registry.OnFailure(order=1, status=FAILED)",
+			"Registration.OnFailure(order=1, status=FAILED)",
+		})
+	})
+
+	t.Run("when a before migrate hook fails it should not run the migration and call the failure hooks", func(t *testing.T) {
+		manager := setup(t)
+		RegisterHooks(recordingHooks(manager, "Registry", errors.New("before error"), nil, nil))
+		MustRegister(recordingRegistration(manager, 1, nil, recordingHooks(manager, "Registration", nil, nil, nil)))
+		err := Migrate(manager)
+		assert.ErrorPart(t, err, "failed to complete the migration with order 1 (failed to run the before migrate hooks (before error))")
+		assert.Equals(t, manager.Operations[len(manager.Operations)-6:], []string{
+			"PersistStatus(order=1, status=STARTED)",
+			"Registry.BeforeMigrate(order=1, status=STARTED)",
+			"Registration.BeforeMigrate(order=1, status=STARTED)",
+			"PersistStatus(order=1, status=FAILED)",
+			"Registry.OnFailure(order=1, status=FAILED)",
+			"Registration.OnFailure(order=1, status=FAILED)",
+		})
+	})
+
+	t.Run("when a failure hook fails it should return both errors", func(t *testing.T) {
+		manager := setup(t)
+		MustRegister(recordingRegistration(manager, 1, errors.New("migrate error"), recordingHooks(manager, "Registration", nil, nil, errors.New("failure error"))))
+		err := Migrate(manager)
+		assert.ErrorPart(t, err, "failed to complete the migration with order 1 (migrate error) and failed to run the failure hooks (failure error)")
+	})
+
+	t.Run("when an after migrate hook fails it should return an error and not run the next migration", func(t *testing.T) {
+		manager := setup(t)
+		MustRegister(recordingRegistration(manager, 1, nil, recordingHooks(manager, "Registration", nil, errors.New("after error"), nil)))
+		MustRegister(recordingRegistration(manager, 2, nil, nil))
+		err := Migrate(manager)
+		assert.ErrorPart(t, err, "failed to run the after migrate hooks for the migration order 1 (after error)")
+		assert.Equals(t, manager.Operations[len(manager.Operations)-2:], []string{
+			"PersistStatus(order=1, status=COMPLETED)",
+			"Registration.AfterMigrate(order=1, status=COMPLETED)",
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 	"time"
 
@@ -70,7 +71,7 @@ func Migrate(manager Manager, opts ...Option) (returnErr error) {
 	var releaseMigrationLockErr error = nil
 	releaseMigrationLockWG := sync.WaitGroup{}
 
-	ctxDeadline := time.Now().Add(time.Millisecond * time.Duration(cfg.DeadlineMilliseconds))
+	ctxDeadline := time.Now().Add(cfg.Deadline.Std())
 	ctx, cancel := context.WithDeadline(context.Background(), ctxDeadline)
 	defer func() {
 		cancel()
@@ -97,11 +98,11 @@ func Migrate(manager Manager, opts ...Option) (returnErr error) {
 	}()
 
 	var migrationsToRun []*Registration
-	if migrationsToRun, err = listMigrationsToRun(ctx, manager); err != nil {
+	if migrationsToRun, _, err = listMigrationsToRun(ctx, manager, migrateCfg.targetOrder); err != nil {
 		return fmt.Errorf("failed to list the migrations to run (%w)", err)
 	}
 
-	if err = runMigrations(ctx, migrationsToRun, manager); err != nil {
+	if err = runMigrations(ctx, migrationsToRun, manager, cfg); err != nil {
 		return fmt.Errorf("error while running migrations (%w)", err)
 	}
 
@@ -115,7 +116,7 @@ func ensureDataStores(ctx context.Context, manager Manager, cfg *Config) (return
 	}
 
 	defer func() {
-		releaseDeadline := time.Now().Add(time.Millisecond * time.Duration(cfg.UnlockDeadlineMilliseconds))
+		releaseDeadline := time.Now().Add(cfg.UnlockDeadline.Std())
 		releaseCtx, releaseCancel := context.WithDeadline(context.Background(), releaseDeadline)
 		defer releaseCancel()
 		if releaseErr := manager.ReleaseDBLock(releaseCtx); releaseErr != nil {
@@ -134,7 +135,7 @@ func ensureDataStores(ctx context.Context, manager Manager, cfg *Config) (return
 // Once the context is canceled, it calls ReleaseMigrationLock.
 func heartbeatAndRelease(ctx context.Context, manager Manager, cfg *Config) (returnErr error) {
 	defer func() {
-		releaseDeadline := time.Now().Add(time.Millisecond * time.Duration(cfg.UnlockDeadlineMilliseconds))
+		releaseDeadline := time.Now().Add(cfg.UnlockDeadline.Std())
 		releaseCtx, releaseCancel := context.WithDeadline(context.Background(), releaseDeadline)
 		defer releaseCancel()
 		if releaseErr := manager.ReleaseMigrationLock(releaseCtx); releaseErr != nil {
@@ -142,7 +143,7 @@ func heartbeatAndRelease(ctx context.Context, manager Manager, cfg *Config) (ret
 		}
 	}()
 
-	heartbeatInterval := time.Millisecond * time.Duration(cfg.HeartbeatIntervalMilliseconds)
+	heartbeatInterval := cfg.HeartbeatInterval.Std()
 	heartbeatTicker := time.NewTicker(heartbeatInterval)
 	defer heartbeatTicker.Stop()
 
@@ -167,22 +168,24 @@ func heartbeatAndRelease(ctx context.Context, manager Manager, cfg *Config) (ret
 }
 
 // listMigrationsToRun compares the registered migrations to the persisted statutes.
-// It returns the list of migrations that need to be run.
-func listMigrationsToRun(ctx context.Context, manager Manager) ([]*Registration, error) {
+// It returns the list of migrations that need to be run and the persisted status of each order.
+// If the target order is not nil, migrations with a greater order are not run.
+func listMigrationsToRun(ctx context.Context, manager Manager, targetOrder *Order) ([]*Registration, map[Order]Status, error) {
 	persistedStatuses, err := manager.ListStatuses(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list the persisted statuses (%w)", err)
+		return nil, nil, fmt.Errorf("failed to list the persisted statuses (%w)", err)
 	}
 	orderToPersistedStatus := make(map[Order]Status)
 	for _, persistedStatus := range persistedStatuses {
 		if err := validation.Struct(persistedStatus); err != nil {
-			return nil, fmt.Errorf("failed while validating the persisted status (%w)", err)
+			return nil, nil, fmt.Errorf("failed while validating the persisted status (%w)", err)
 		}
 		if _, alreadyFound := orderToPersistedStatus[persistedStatus.Order]; alreadyFound {
-			return nil, fmt.Errorf("found two persisted statuses with order %d", persistedStatus.Order)
+			return nil, nil, fmt.Errorf("found two persisted statuses with order %d", persistedStatus.Order)
 		}
 		orderToPersistedStatus[persistedStatus.Order] = persistedStatus.Status
 	}
+	allPersistedStatuses := maps.Clone(orderToPersistedStatus)
 
 	latestCompletedMigration := Order(-1)
 	migrationsToRun := make([]*Registration, 0)
@@ -199,10 +202,14 @@ func listMigrationsToRun(ctx context.Context, manager Manager) ([]*Registration,
 				if registeredMigration.Order > latestCompletedMigration {
 					latestCompletedMigration = registeredMigration.Order
 				}
+			} else if isAfterTarget(registeredMigration.Order, targetOrder) {
+				logger.Debugf("Registration with order %d is after the target order. Skipping.", registeredMigration.Order)
 			} else {
 				logger.Debugf("Will attempt to run the migration with order %d and status %s again.", registeredMigration.Order, migrationStatus)
 				migrationsToRun = append(migrationsToRun, registeredMigration)
 			}
+		} else if isAfterTarget(registeredMigration.Order, targetOrder) {
+			logger.Debugf("New migration with order %d is after the target order. Skipping.", registeredMigration.Order)
 		} else {
 			logger.Debugf("New migration with order %d found.", registeredMigration.Order)
 			migrationsToRun = append(migrationsToRun, registeredMigration)
@@ -210,21 +217,27 @@ func listMigrationsToRun(ctx context.Context, manager Manager) ([]*Registration,
 	}
 
 	if len(orderToPersistedStatus) != 0 {
-		return nil, fmt.Errorf("found persisted migration(s) that are not in the registry (%+v)", orderToPersistedStatus)
+		return nil, nil, fmt.Errorf("found persisted migration(s) that are not in the registry (%+v)", orderToPersistedStatus)
 	}
 
 	for _, migrationToRun := range migrationsToRun {
 		if migrationToRun.Order < latestCompletedMigration {
-			return nil, fmt.Errorf("cannot run migrations out of order (found %d but latest completed is %d)", migrationToRun.Order, latestCompletedMigration)
+			return nil, nil, fmt.Errorf("cannot run migrations out of order (found %d but latest completed is %d)", migrationToRun.Order, latestCompletedMigration)
 		}
 	}
 
-	return migrationsToRun, nil
+	return migrationsToRun, allPersistedStatuses, nil
+}
+
+// isAfterTarget returns true if the order is greater than the target order.
+// A nil target order means there is no target.
+func isAfterTarget(order Order, targetOrder *Order) bool {
+	return targetOrder != nil && order > *targetOrder
 }
 
 // runMigrations first persists the statuses of all the migrations as PENDING.
 // Then it attempts to run the migrations while keeping the statuses updated.
-func runMigrations(ctx context.Context, migrationsToRun []*Registration, manager Manager) error {
+func runMigrations(ctx context.Context, migrationsToRun []*Registration, manager Manager, cfg *Config) error {
 	for _, registered := range migrationsToRun {
 		if err := manager.PersistStatus(ctx, registered.Order, Pending); err != nil {
 			return fmt.Errorf("failed to persist the status %s for the migration order %d (%w)", Pending, registered.Order, err)
@@ -238,18 +251,80 @@ func runMigrations(ctx context.Context, migrationsToRun []*Registration, manager
 		if err := manager.PersistStatus(ctx, migrationToRun.Order, Started); err != nil {
 			return fmt.Errorf("failed to persist the status %s for the migration order %d (%w)", Started, migrationToRun.Order, err)
 		}
-		if err := migrationToRun.Migrate(ctx); err != nil {
-			err = fmt.Errorf("failed to complete the migration with order %d (%w)", migrationToRun.Order, err)
+		hooks := hooksFor(migrationToRun)
+		migrateErr := runHooks(ctx, hooks, beforeMigrate, migrationToRun.Order, Started)
+		if migrateErr != nil {
+			migrateErr = fmt.Errorf("failed to run the before migrate hooks (%w)", migrateErr)
+		} else {
+			migrateErr = migrateWithTimeout(ctx, migrationToRun, cfg)
+		}
+		if migrateErr != nil {
+			err := fmt.Errorf("failed to complete the migration with order %d (%w)", migrationToRun.Order, migrateErr)
 			if failedStatusErr := manager.PersistStatus(ctx, migrationToRun.Order, Failed); failedStatusErr != nil {
 				return fmt.Errorf("%w and failed to persist its status to %s (%w)", err, Failed, failedStatusErr)
 			}
+			if hookErr := runHooks(ctx, hooks, onFailure, migrationToRun.Order, Failed); hookErr != nil {
+				return fmt.Errorf("%w and failed to run the failure hooks (%w)", err, hookErr)
+			}
 			return err
 		}
 		if err := manager.PersistStatus(ctx, migrationToRun.Order, Completed); err != nil {
 			return fmt.Errorf("failed to persist the status %s for the migration order %d (%w)", Completed, migrationToRun.Order, err)
 		}
+		if err := runHooks(ctx, hooks, afterMigrate, migrationToRun.Order, Completed); err != nil {
+			return fmt.Errorf("failed to run the after migrate hooks for the migration order %d (%w)", migrationToRun.Order, err)
+		}
 		logEntry.Debugf("Migration finished in %s.", time.Since(startTime))
 	}
 
 	return nil
 }
+
+// migrateWithTimeout runs the migration with the timeout of the registration, or the default of the config.
+// Once the timeout expires, or the context is canceled, the context of the migration is canceled and an error
+// is returned right away so the migration can be persisted as failed. The migration is not waited for, so a
+// migration that ignores its context can still be running after the migration lock is released.
+func migrateWithTimeout(ctx context.Context, registration *Registration, cfg *Config) error {
+	timeout := registration.Timeout.Std()
+	if timeout == 0 {
+		timeout = cfg.MigrationTimeout.Std()
+	}
+	if timeout == 0 {
+		return registration.Migrate(ctx)
+	}
+
+	migrateCtx, migrateCancel := context.WithTimeout(ctx, timeout)
+	defer migrateCancel()
+
+	migrateResult := make(chan error, 1)
+	go func() {
+		migrateResult <- registration.Migrate(migrateCtx)
+	}()
+
+	var migrateErr error
+	returned := false
+	select {
+	case migrateErr = <-migrateResult:
+		returned = true
+	case <-migrateCtx.Done():
+		select {
+		case migrateErr = <-migrateResult:
+			returned = true
+		default:
+		}
+	}
+	if returned && (migrateErr == nil || migrateCtx.Err() == nil) {
+		return migrateErr
+	}
+
+	var stoppedErr error
+	if ctx.Err() != nil {
+		stoppedErr = fmt.Errorf("the migration was canceled before it completed (%w)", ctx.Err())
+	} else {
+		stoppedErr = fmt.Errorf("the migration did not complete within %s (%w)", timeout, migrateCtx.Err())
+	}
+	if migrateErr != nil && !errors.Is(migrateErr, context.Canceled) && !errors.Is(migrateErr, context.DeadlineExceeded) {
+		return errors.Join(stoppedErr, migrateErr)
+	}
+	return stoppedErr
+}
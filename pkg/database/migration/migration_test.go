@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/TriangleSide/GoTools/pkg/config"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 	"github.com/TriangleSide/GoTools/pkg/validation"
 )
 
@@ -103,6 +105,11 @@ func (r *managerRecorder) ReleaseMigrationLock(ctx context.Context) error {
 }
 
 func TestMigrate(t *testing.T) {
+	hungMigration := make(chan struct{})
+	t.Cleanup(func() {
+		close(hungMigration)
+	})
+
 	standardRegisteredMigration := func(manager *managerRecorder, order Order) *Registration {
 		return &Registration{
 			Order: order,
@@ -463,7 +470,7 @@ func TestMigrate(t *testing.T) {
 			options: []Option{
 				WithConfigProvider(func() (*Config, error) {
 					cfg, _ := config.Process[Config](config.WithPrefix(ConfigPrefix))
-					cfg.HeartbeatIntervalMilliseconds = 1
+					cfg.HeartbeatInterval = timestamp.Duration(time.Millisecond)
 					cfg.HeartbeatFailureRetryCount = 2
 					return cfg, nil
 				}),
@@ -510,7 +517,7 @@ func TestMigrate(t *testing.T) {
 			options: []Option{
 				WithConfigProvider(func() (*Config, error) {
 					cfg, _ := config.Process[Config](config.WithPrefix(ConfigPrefix))
-					cfg.HeartbeatIntervalMilliseconds = 1
+					cfg.HeartbeatInterval = timestamp.Duration(time.Millisecond)
 					cfg.HeartbeatFailureRetryCount = 2
 					return cfg, nil
 				}),
@@ -552,7 +559,7 @@ func TestMigrate(t *testing.T) {
 			options: []Option{
 				WithConfigProvider(func() (*Config, error) {
 					cfg, _ := config.Process[Config](config.WithPrefix(ConfigPrefix))
-					cfg.HeartbeatIntervalMilliseconds = 10
+					cfg.HeartbeatInterval = timestamp.Duration(time.Millisecond * 10)
 					return cfg, nil
 				}),
 			},
@@ -680,6 +687,154 @@ func TestMigrate(t *testing.T) {
 				assert.Equals(t, manager.MigrationUnlockCount, 1)
 			},
 		},
+		{
+			name: "when a target order is set it should not run the migrations after it",
+			manager: &managerRecorder{
+				PersistedMigrations: []PersistedStatus{
+					{Order: 1, Status: Completed},
+					{Order: 3, Status: Failed},
+				},
+			},
+			setupRegistry: func(manager *managerRecorder) {
+				MustRegister(standardRegisteredMigration(manager, Order(1)))
+				MustRegister(standardRegisteredMigration(manager, Order(2)))
+				MustRegister(standardRegisteredMigration(manager, Order(3)))
+				MustRegister(standardRegisteredMigration(manager, Order(4)))
+			},
+			options:      []Option{WithTargetOrder(2)},
+			expectedErrs: nil,
+			expectedOps: []string{
+				"AcquireDBLock()",
+				"EnsureDataStores()",
+				"ReleaseDBLock()",
+				"AcquireMigrationLock()",
+				"ListStatuses()",
+				"PersistStatus(order=2, status=PENDING)",
+				"PersistStatus(order=2, status=STARTED)",
+				"Migration2.Migrate()",
+				"PersistStatus(order=2, status=COMPLETED)",
+			},
+			asserts: func(t *testing.T, manager *managerRecorder) {
+				t.Helper()
+				assert.Equals(t, manager.MigrationUnlockCount, 1)
+			},
+		},
+		{
+			name: "when the target order is already completed it should not run any migrations",
+			manager: &managerRecorder{
+				PersistedMigrations: []PersistedStatus{
+					{Order: 1, Status: Completed},
+				},
+			},
+			setupRegistry: func(manager *managerRecorder) {
+				MustRegister(standardRegisteredMigration(manager, Order(1)))
+				MustRegister(standardRegisteredMigration(manager, Order(2)))
+			},
+			options:      []Option{WithTargetOrder(1)},
+			expectedErrs: nil,
+			expectedOps: []string{
+				"AcquireDBLock()",
+				"EnsureDataStores()",
+				"ReleaseDBLock()",
+				"AcquireMigrationLock()",
+				"ListStatuses()",
+			},
+			asserts: func(t *testing.T, manager *managerRecorder) {
+				t.Helper()
+				assert.Equals(t, manager.MigrationUnlockCount, 1)
+			},
+		},
+		{
+			name:    "when a migration exceeds its timeout it should cancel its context and persist it as failed",
+			manager: &managerRecorder{},
+			setupRegistry: func(manager *managerRecorder) {
+				MustRegister(&Registration{
+					Order: 1,
+					Migrate: func(ctx context.Context) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+					Enabled: true,
+					Timeout: timestamp.Duration(time.Millisecond),
+				})
+			},
+			expectedErrs: []string{"failed to complete the migration with order 1 (the migration did not complete within 1ms (context deadline exceeded))"},
+			expectedOps: []string{
+				"AcquireDBLock()",
+				"EnsureDataStores()",
+				"ReleaseDBLock()",
+				"AcquireMigrationLock()",
+				"ListStatuses()",
+				"PersistStatus(order=1, status=PENDING)",
+				"PersistStatus(order=1, status=STARTED)",
+				"PersistStatus(order=1, status=FAILED)",
+			},
+			asserts: func(t *testing.T, manager *managerRecorder) {
+				t.Helper()
+				assert.Equals(t, manager.MigrationUnlockCount, 1)
+			},
+		},
+		{
+			name:    "when a migration ignores its context and exceeds the default timeout it should be persisted as failed without waiting for it",
+			manager: &managerRecorder{},
+			setupRegistry: func(manager *managerRecorder) {
+				MustRegister(&Registration{
+					Order: 1,
+					Migrate: func(ctx context.Context) error {
+						<-hungMigration
+						return nil
+					},
+					Enabled: true,
+				})
+			},
+			options: []Option{
+				WithConfigProvider(func() (*Config, error) {
+					cfg, _ := config.Process[Config](config.WithPrefix(ConfigPrefix))
+					cfg.MigrationTimeout = timestamp.Duration(time.Millisecond)
+					return cfg, nil
+				}),
+			},
+			expectedErrs: []string{"the migration did not complete within 1ms (context deadline exceeded)"},
+			expectedOps: []string{
+				"AcquireDBLock()",
+				"EnsureDataStores()",
+				"ReleaseDBLock()",
+				"AcquireMigrationLock()",
+				"ListStatuses()",
+				"PersistStatus(order=1, status=PENDING)",
+				"PersistStatus(order=1, status=STARTED)",
+				"PersistStatus(order=1, status=FAILED)",
+			},
+			asserts: func(t *testing.T, manager *managerRecorder) {
+				t.Helper()
+				assert.Equals(t, manager.MigrationUnlockCount, 1)
+			},
+		},
+		{
+			name:    "when a migration completes within its timeout it should be persisted as completed",
+			manager: &managerRecorder{},
+			setupRegistry: func(manager *managerRecorder) {
+				registration := standardRegisteredMigration(manager, Order(1))
+				registration.Timeout = timestamp.Duration(time.Minute)
+				MustRegister(registration)
+			},
+			expectedErrs: nil,
+			expectedOps: []string{
+				"AcquireDBLock()",
+				"EnsureDataStores()",
+				"ReleaseDBLock()",
+				"AcquireMigrationLock()",
+				"ListStatuses()",
+				"PersistStatus(order=1, status=PENDING)",
+				"PersistStatus(order=1, status=STARTED)",
+				"Migration1.Migrate()",
+				"PersistStatus(order=1, status=COMPLETED)",
+			},
+			asserts: func(t *testing.T, manager *managerRecorder) {
+				t.Helper()
+				assert.Equals(t, manager.MigrationUnlockCount, 1)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -704,6 +859,49 @@ func TestMigrate(t *testing.T) {
 	}
 }
 
+func TestMigrateWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the context is canceled before the timeout it should return a cancellation error", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := migrateWithTimeout(ctx, &Registration{
+			Migrate: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Timeout: timestamp.Duration(time.Minute),
+		}, &Config{})
+		assert.ErrorExact(t, err, "the migration was canceled before it completed (context canceled)")
+	})
+
+	t.Run("when the migration returns its own error before the timeout it should return the error", func(t *testing.T) {
+		t.Parallel()
+		err := migrateWithTimeout(context.Background(), &Registration{
+			Migrate: func(ctx context.Context) error {
+				return errors.New("migrate error")
+			},
+			Timeout: timestamp.Duration(time.Minute),
+		}, &Config{})
+		assert.ErrorExact(t, err, "migrate error")
+	})
+
+	t.Run("when the migration ignores its context it should return once the timeout expires", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		defer close(release)
+		err := migrateWithTimeout(context.Background(), &Registration{
+			Migrate: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+			Timeout: timestamp.Duration(time.Millisecond),
+		}, &Config{})
+		assert.ErrorExact(t, err, "the migration did not complete within 1ms (context deadline exceeded)")
+	})
+}
+
 func TestPersistStatus(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,51 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Step is a migration that Migrate would run.
+type Step struct {
+	// Order is the order of the registered migration.
+	Order Order
+
+	// PersistedStatus is the status currently stored by the Manager.
+	// It is nil if the migration has never been run.
+	PersistedStatus *Status
+}
+
+// Plan returns the ordered list of migrations that Migrate would run, without running them.
+// It does not acquire any locks nor does it ensure the data stores exist. The only Manager
+// function it calls is ListStatuses. It is meant to let operators review the changes before a deployment.
+func Plan(manager Manager, opts ...Option) ([]Step, error) {
+	migrateCfg := configure(opts...)
+	cfg, err := migrateCfg.configProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the migration configuration (%w)", err)
+	}
+
+	ctxDeadline := time.Now().Add(cfg.Deadline.Std())
+	ctx, cancel := context.WithDeadline(context.Background(), ctxDeadline)
+	defer cancel()
+
+	migrationsToRun, orderToPersistedStatus, err := listMigrationsToRun(ctx, manager, migrateCfg.targetOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the migrations to run (%w)", err)
+	}
+
+	steps := make([]Step, 0, len(migrationsToRun))
+	for _, migrationToRun := range migrationsToRun {
+		step := Step{
+			Order:           migrationToRun.Order,
+			PersistedStatus: nil,
+		}
+		if persistedStatus, hasPersistedStatus := orderToPersistedStatus[migrationToRun.Order]; hasPersistedStatus {
+			step.PersistedStatus = &persistedStatus
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
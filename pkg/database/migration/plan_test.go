@@ -0,0 +1,120 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestPlan(t *testing.T) {
+	registerNoop := func(order Order, enabled bool) {
+		MustRegister(&Registration{
+			Order: order,
+			Migrate: func(ctx context.Context) error {
+				return errors.New("migrate should not be called by plan")
+			},
+			Enabled: enabled,
+		})
+	}
+
+	tests := []struct {
+		name          string
+		manager       *managerRecorder
+		setupRegistry func()
+		options       []Option
+		expectedErr   string
+		expectedSteps []Step
+	}{
+		{
+			name:          "when configProvider fails it should return an error",
+			manager:       &managerRecorder{},
+			setupRegistry: func() {},
+			options: []Option{
+				WithConfigProvider(func() (*Config, error) {
+					return nil, errors.New("configProvider error")
+				}),
+			},
+			expectedErr: "failed to get the migration configuration (configProvider error)",
+		},
+		{
+			name:          "when there are no registered migrations it should return an empty plan",
+			manager:       &managerRecorder{},
+			setupRegistry: func() {},
+			expectedSteps: []Step{},
+		},
+		{
+			name: "when there are completed, failed, and new migrations it should return the failed and new migrations in order",
+			manager: &managerRecorder{
+				PersistedMigrations: []PersistedStatus{
+					{Order: 1, Status: Completed},
+					{Order: 2, Status: Failed},
+				},
+			},
+			setupRegistry: func() {
+				registerNoop(3, true)
+				registerNoop(1, true)
+				registerNoop(2, true)
+				registerNoop(4, false)
+			},
+			expectedSteps: []Step{
+				{Order: 2, PersistedStatus: ptr.Of(Failed)},
+				{Order: 3, PersistedStatus: nil},
+			},
+		},
+		{
+			name:    "when a target order is set it should not plan the migrations after it",
+			manager: &managerRecorder{},
+			setupRegistry: func() {
+				registerNoop(1, true)
+				registerNoop(2, true)
+				registerNoop(3, true)
+			},
+			options: []Option{WithTargetOrder(2)},
+			expectedSteps: []Step{
+				{Order: 1, PersistedStatus: nil},
+				{Order: 2, PersistedStatus: nil},
+			},
+		},
+		{
+			name: "when ListStatuses fails it should return an error",
+			manager: &managerRecorder{
+				ListStatusesError: errors.New("ListStatuses error"),
+			},
+			setupRegistry: func() {},
+			expectedErr:   "failed to list the persisted statuses (ListStatuses error)",
+		},
+		{
+			name: "when a persisted status is not registered it should return an error",
+			manager: &managerRecorder{
+				PersistedMigrations: []PersistedStatus{
+					{Order: 1, Status: Completed},
+				},
+			},
+			setupRegistry: func() {},
+			expectedErr:   "found persisted migration(s) that are not in the registry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry.Clear()
+			tt.setupRegistry()
+			steps, err := Plan(tt.manager, tt.options...)
+			if tt.expectedErr != "" {
+				assert.ErrorPart(t, err, tt.expectedErr)
+				assert.Nil(t, steps)
+			} else {
+				assert.NoError(t, err)
+				assert.Equals(t, steps, tt.expectedSteps)
+			}
+			for _, operation := range tt.manager.Operations {
+				assert.Equals(t, operation, "ListStatuses()")
+			}
+			assert.Equals(t, tt.manager.MigrationUnlockCount, 0)
+			assert.Equals(t, tt.manager.HeartbeatCount, 0)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 	"github.com/TriangleSide/GoTools/pkg/validation"
 )
 
@@ -24,6 +25,15 @@ type Registration struct {
 	// Enabled indicates if this migration is to be run or not.
 	// A migration could be disabled if another migration covers it.
 	Enabled bool
+
+	// Timeout is the maximum time the migration can run before its context is canceled and it is marked as failed.
+	// If it is zero, the MigrationTimeout of the Config is used. The migration is not waited for once it times out,
+	// so it must return when its context is canceled.
+	Timeout timestamp.Duration `validate:"gte=0"`
+
+	// Hooks are optional callbacks invoked around this migration.
+	// They are invoked after the hooks registered with RegisterHooks.
+	Hooks *Hooks
 }
 
 var (
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 )
 
 func TestRegistry(t *testing.T) {
@@ -41,6 +43,19 @@ func TestRegistry(t *testing.T) {
 		}, "Validation failed for registration")
 	})
 
+	t.Run("when the registration has a negative timeout it should panic", func(t *testing.T) {
+		t.Parallel()
+		registrationOrder := Order(order.Add(1))
+		assert.PanicPart(t, func() {
+			MustRegister(&Registration{
+				Order:   registrationOrder,
+				Migrate: func(ctx context.Context) error { return nil },
+				Enabled: true,
+				Timeout: timestamp.Duration(-time.Second),
+			})
+		}, "Validation failed for registration")
+	})
+
 	t.Run("when orderedRegistrations is called the registration should be in order", func(t *testing.T) {
 		t.Parallel()
 		const count = 32
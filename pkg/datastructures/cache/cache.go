@@ -1,12 +1,16 @@
 package cache
 
 import (
+	"container/list"
 	"sync"
 	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 )
 
 // GetOrSetFn is used in the GetOrSet function of the Cache interface.
 // If the value is not present, or if it's expired, the function gets called.
+// A nil TTL uses the default TTL of the Cache.
 type GetOrSetFn[Key comparable, Value any] func(Key) (Value, *time.Duration, error)
 
 // getOrSetKeyLock is used by the GetOrSet function to make sure the function is not executed in parallel.
@@ -22,43 +26,154 @@ type Cache[Key comparable, Value any] struct {
 	getOrSetLock     sync.Mutex
 	getOrSetKeyLocks map[Key]*getOrSetKeyLock[Value]
 	keyToItem        map[Key]*item[Value]
+	defaultTTL       *time.Duration
+	maxEntries       int
+	maxWeight        int64
+	weigher          func(Key, Value) int64
+	onRemoval        func(Key, Value, RemovalReason)
+	clock            timestamp.Clock
+	totalWeight      int64
+	recency          *list.List
+	counters         counters
+	closeOnce        sync.Once
+	closeChan        chan struct{}
 }
 
 // New creates a new Cache instance. The benefit of using Cache instead of a regular map is that
 // Cache is thread safe. It also handles expiring items.
-func New[Key comparable, Value any]() *Cache[Key, Value] {
-	return &Cache[Key, Value]{
+func New[Key comparable, Value any](opts ...Option[Key, Value]) *Cache[Key, Value] {
+	cfg := configure(opts...)
+	c := &Cache[Key, Value]{
 		rwMutex:          sync.RWMutex{},
 		getOrSetLock:     sync.Mutex{},
 		getOrSetKeyLocks: make(map[Key]*getOrSetKeyLock[Value]),
 		keyToItem:        make(map[Key]*item[Value]),
+		defaultTTL:       cfg.defaultTTL,
+		maxEntries:       cfg.maxEntries,
+		maxWeight:        cfg.maxWeight,
+		weigher:          cfg.weigher,
+		onRemoval:        cfg.onRemoval,
+		clock:            cfg.clock,
+		totalWeight:      0,
+		recency:          nil,
+		counters:         counters{},
+		closeOnce:        sync.Once{},
+		closeChan:        make(chan struct{}),
+	}
+	if c.bounded() {
+		c.recency = list.New()
+	}
+	if cfg.expirationInterval != nil {
+		go c.expireOnInterval(*cfg.expirationInterval)
 	}
+	return c
 }
 
 // item are the values that are held in the Cache's map.
 type item[Value any] struct {
-	value  Value
-	expiry *time.Time
+	value   Value
+	ttl     *time.Duration
+	expiry  *time.Time
+	weight  int64
+	element *list.Element
+}
+
+// expired returns true if the item has an expiry time that has passed.
+func (i *item[Value]) expired(now time.Time) bool {
+	return i.expiry != nil && now.After(*i.expiry)
 }
 
 // Set is the implementation of the Cache interface.
+// If the ttl is nil, the default TTL is used. Without a default TTL, the item does not expire.
 func (c *Cache[Key, Value]) Set(key Key, value Value, ttl *time.Duration) {
-	var itemToAdd *item[Value]
+	if ttl == nil {
+		ttl = c.defaultTTL
+	}
+	c.set(key, value, ttl)
+}
+
+// set stores the value. If the ttl is nil, the item does not expire.
+func (c *Cache[Key, Value]) set(key Key, value Value, ttl *time.Duration) {
+	itemToAdd := &item[Value]{
+		value:   value,
+		ttl:     ttl,
+		expiry:  nil,
+		weight:  0,
+		element: nil,
+	}
 	if ttl != nil {
-		expireTime := time.Now().Add(*ttl)
-		itemToAdd = &item[Value]{
-			value:  value,
-			expiry: &expireTime,
-		}
-	} else {
-		itemToAdd = &item[Value]{
-			value:  value,
-			expiry: nil,
-		}
+		expireTime := c.clock.Now().Add(*ttl)
+		itemToAdd.expiry = &expireTime
+	}
+	if c.weigher != nil {
+		itemToAdd.weight = c.weigher(key, value)
 	}
+	var removals []removal[Key, Value]
 	c.rwMutex.Lock()
+	if existingItem, loaded := c.keyToItem[key]; loaded {
+		c.deleteItem(key, existingItem, RemovalReasonReplaced, &removals)
+	}
 	c.keyToItem[key] = itemToAdd
+	if c.bounded() {
+		itemToAdd.element = c.recency.PushFront(key)
+		c.totalWeight += itemToAdd.weight
+		c.evictOverflow(&removals)
+	}
 	c.rwMutex.Unlock()
+	c.notifyRemovals(removals)
+}
+
+// bounded returns true if the Cache has a max number of entries or a max weight.
+func (c *Cache[Key, Value]) bounded() bool {
+	return c.maxEntries > 0 || c.maxWeight > 0
+}
+
+// overflowing returns true if the Cache has more entries or weight than its bounds.
+func (c *Cache[Key, Value]) overflowing() bool {
+	return (c.maxEntries > 0 && len(c.keyToItem) > c.maxEntries) || (c.maxWeight > 0 && c.totalWeight > c.maxWeight)
+}
+
+// evictOverflow evicts the least recently used items until the Cache is within its bounds.
+// The write lock must be held.
+func (c *Cache[Key, Value]) evictOverflow(removals *[]removal[Key, Value]) {
+	for c.overflowing() {
+		leastRecentKey := c.recency.Back().Value.(Key)
+		c.deleteItem(leastRecentKey, c.keyToItem[leastRecentKey], RemovalReasonEvicted, removals)
+		c.counters.evictions.Add(1)
+	}
+}
+
+// deleteItem removes the item from the map and the recency list. The write lock must be held.
+// If there is a removal callback, the removal is appended to the removals to be notified once the lock is released.
+func (c *Cache[Key, Value]) deleteItem(key Key, itemValue *item[Value], reason RemovalReason, removals *[]removal[Key, Value]) {
+	delete(c.keyToItem, key)
+	if itemValue.element != nil {
+		c.recency.Remove(itemValue.element)
+		c.totalWeight -= itemValue.weight
+	}
+	if c.onRemoval != nil {
+		*removals = append(*removals, removal[Key, Value]{
+			key:    key,
+			value:  itemValue.value,
+			reason: reason,
+		})
+	}
+}
+
+// markUsed moves the item to the front of the recency list if it is still in the Cache.
+func (c *Cache[Key, Value]) markUsed(key Key, itemValue *item[Value]) {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	if c.keyToItem[key] == itemValue {
+		c.recency.MoveToFront(itemValue.element)
+	}
+}
+
+// Len returns the number of entries in the Cache. Expired entries that have not been removed yet are counted.
+func (c *Cache[Key, Value]) Len() int {
+	c.rwMutex.RLock()
+	defer c.rwMutex.RUnlock()
+	return len(c.keyToItem)
 }
 
 // Get is the implementation of the Cache interface.
@@ -68,30 +183,137 @@ func (c *Cache[Key, Value]) Get(key Key) (Value, bool) {
 	c.rwMutex.RUnlock()
 
 	if loaded {
-		if itemValue.expiry != nil && time.Now().After(*itemValue.expiry) {
+		if itemValue.expired(c.clock.Now()) {
 			c.clearIfExpired(key)
+			c.counters.misses.Add(1)
 			var zeroValue Value
 			return zeroValue, false
 		}
+		if c.bounded() {
+			c.markUsed(key, itemValue)
+		}
+		c.counters.hits.Add(1)
 		return itemValue.value, true
 	} else {
+		c.counters.misses.Add(1)
+		var zeroValue Value
+		return zeroValue, false
+	}
+}
+
+// getPresent returns the value of the key if it is present and not expired. Unlike Get,
+// it does not count a miss, since the caller looks up the key again when it is not found.
+func (c *Cache[Key, Value]) getPresent(key Key) (Value, bool) {
+	c.rwMutex.RLock()
+	itemValue, loaded := c.keyToItem[key]
+	c.rwMutex.RUnlock()
+
+	if !loaded || itemValue.expired(c.clock.Now()) {
 		var zeroValue Value
 		return zeroValue, false
 	}
+	if c.bounded() {
+		c.markUsed(key, itemValue)
+	}
+	c.counters.hits.Add(1)
+	return itemValue.value, true
 }
 
 // clearIfExpired removes the key from the Cache if it is expired.
 func (c *Cache[Key, Value]) clearIfExpired(key Key) {
+	var removals []removal[Key, Value]
 	c.rwMutex.Lock()
 	itemValue, loaded := c.keyToItem[key]
-	if loaded && itemValue.expiry != nil && time.Now().After(*itemValue.expiry) {
-		delete(c.keyToItem, key)
+	if loaded && itemValue.expired(c.clock.Now()) {
+		c.deleteItem(key, itemValue, RemovalReasonExpired, &removals)
+		c.counters.expirations.Add(1)
 	}
 	c.rwMutex.Unlock()
+	c.notifyRemovals(removals)
+}
+
+// Touch resets the expiry time of the key to now plus the TTL it was set with.
+// It returns false if the key is not in the Cache or is expired.
+func (c *Cache[Key, Value]) Touch(key Key) bool {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	now := c.clock.Now()
+	itemValue, loaded := c.keyToItem[key]
+	if !loaded || itemValue.expired(now) {
+		return false
+	}
+	if itemValue.ttl != nil {
+		expireTime := now.Add(*itemValue.ttl)
+		itemValue.expiry = &expireTime
+	}
+	return true
+}
+
+// Extend adds the duration to the expiry time of the key. Keys that do not expire are left as is.
+// It returns false if the key is not in the Cache or is expired.
+func (c *Cache[Key, Value]) Extend(key Key, duration time.Duration) bool {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+	itemValue, loaded := c.keyToItem[key]
+	if !loaded || itemValue.expired(c.clock.Now()) {
+		return false
+	}
+	if itemValue.expiry != nil {
+		expireTime := itemValue.expiry.Add(duration)
+		itemValue.expiry = &expireTime
+	}
+	return true
+}
+
+// removeExpired removes all the expired keys from the Cache.
+func (c *Cache[Key, Value]) removeExpired() {
+	var removals []removal[Key, Value]
+	c.rwMutex.Lock()
+	now := c.clock.Now()
+	for key, itemValue := range c.keyToItem {
+		if itemValue.expired(now) {
+			c.deleteItem(key, itemValue, RemovalReasonExpired, &removals)
+			c.counters.expirations.Add(1)
+		}
+	}
+	c.rwMutex.Unlock()
+	c.notifyRemovals(removals)
+}
+
+// expireOnInterval removes the expired keys on the interval until the Cache is closed.
+func (c *Cache[Key, Value]) expireOnInterval(interval time.Duration) {
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeChan:
+			return
+		case <-ticker.C():
+			c.removeExpired()
+		}
+	}
+}
+
+// Stats returns a snapshot of the counters of the Cache.
+func (c *Cache[Key, Value]) Stats() Stats {
+	return c.counters.snapshot()
+}
+
+// Close stops the background expiration routine started with WithExpirationInterval.
+// The Cache can still be used after it is closed. It is safe to call Close multiple times.
+func (c *Cache[Key, Value]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closeChan)
+	})
 }
 
 // GetOrSet is the implementation of the Cache interface.
+// A value that is present is returned under the read lock without allocating.
 func (c *Cache[Key, Value]) GetOrSet(key Key, fn GetOrSetFn[Key, Value]) (Value, error) {
+	if value, found := c.getPresent(key); found {
+		return value, nil
+	}
+
 	c.getOrSetLock.Lock()
 	keyLock, keyLockFound := c.getOrSetKeyLocks[key]
 	if !keyLockFound {
@@ -121,9 +343,13 @@ func (c *Cache[Key, Value]) GetOrSet(key Key, fn GetOrSetFn[Key, Value]) (Value,
 	}
 
 	var ttl *time.Duration
+	loadStart := c.clock.Now()
 	keyLock.FnValue, ttl, keyLock.FnError = fn(key)
+	c.counters.loadDuration.Add(int64(c.clock.Since(loadStart)))
+	c.counters.loads.Add(1)
 	defer close(keyLock.WaitChan)
 	if keyLock.FnError != nil {
+		c.counters.loadErrors.Add(1)
 		return keyLock.FnValue, keyLock.FnError
 	}
 
@@ -133,14 +359,33 @@ func (c *Cache[Key, Value]) GetOrSet(key Key, fn GetOrSetFn[Key, Value]) (Value,
 
 // Remove is the implementation of the Cache interface.
 func (c *Cache[Key, Value]) Remove(key Key) {
+	var removals []removal[Key, Value]
 	c.rwMutex.Lock()
-	delete(c.keyToItem, key)
+	if itemValue, loaded := c.keyToItem[key]; loaded {
+		c.deleteItem(key, itemValue, RemovalReasonRemoved, &removals)
+	}
 	c.rwMutex.Unlock()
+	c.notifyRemovals(removals)
 }
 
 // Reset is the implementation of the Cache interface.
 func (c *Cache[Key, Value]) Reset() {
+	var removals []removal[Key, Value]
 	c.rwMutex.Lock()
+	if c.onRemoval != nil {
+		for key, itemValue := range c.keyToItem {
+			removals = append(removals, removal[Key, Value]{
+				key:    key,
+				value:  itemValue.value,
+				reason: RemovalReasonRemoved,
+			})
+		}
+	}
 	c.keyToItem = make(map[Key]*item[Value])
+	c.totalWeight = 0
+	if c.recency != nil {
+		c.recency.Init()
+	}
 	c.rwMutex.Unlock()
+	c.notifyRemovals(removals)
 }
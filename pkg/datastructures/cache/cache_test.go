@@ -9,6 +9,7 @@ import (
 
 	"github.com/TriangleSide/GoTools/pkg/ptr"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 )
 
 func cacheMustHaveKeyAndValue[Key comparable, Value any](t *testing.T, testCache *Cache[Key, Value], key Key, value Value) {
@@ -162,6 +163,235 @@ func TestCache(t *testing.T) {
 		assert.Equals(t, len(testCache.keyToItem), 1)
 	})
 
+	t.Run("when the default TTL is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			New[string, string](WithDefaultTTL[string, string](0))
+		}, "The default TTL must be greater than zero.")
+	})
+
+	t.Run("when the expiration interval is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			New[string, string](WithExpirationInterval[string, string](-time.Second))
+		}, "The expiration interval must be greater than zero.")
+	})
+
+	t.Run("when a default TTL is set it should be used for items set without a TTL", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithDefaultTTL[string, string](time.Nanosecond))
+		testCache.Set("default", "value", nil)
+		testCache.Set("explicit", "value", ptr.Of(time.Minute))
+		time.Sleep(time.Nanosecond * 2)
+		_, gotten := testCache.Get("default")
+		assert.False(t, gotten)
+		cacheMustHaveKeyAndValue(t, testCache, "explicit", "value")
+	})
+
+	t.Run("when a default TTL is set it should be used for get or set functions that return no TTL", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithDefaultTTL[string, string](time.Minute))
+		_, err := testCache.GetOrSet("key", func(key string) (string, *time.Duration, error) {
+			return "value", nil, nil
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, testCache.keyToItem["key"].expiry)
+		assert.Equals(t, *testCache.keyToItem["key"].ttl, time.Minute)
+	})
+
+	t.Run("when an item is touched it should reset its expiry time", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		testCache.Set("key", "value", ptr.Of(time.Minute))
+		firstExpiry := *testCache.keyToItem["key"].expiry
+		time.Sleep(time.Millisecond)
+		assert.True(t, testCache.Touch("key"))
+		assert.True(t, testCache.keyToItem["key"].expiry.After(firstExpiry))
+		cacheMustHaveKeyAndValue(t, testCache, "key", "value")
+	})
+
+	t.Run("when an item without a TTL is touched it should not expire", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		testCache.Set("key", "value", nil)
+		assert.True(t, testCache.Touch("key"))
+		assert.Nil(t, testCache.keyToItem["key"].expiry)
+	})
+
+	t.Run("when an item is extended it should add the duration to its expiry time", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		testCache.Set("key", "value", ptr.Of(time.Minute))
+		firstExpiry := *testCache.keyToItem["key"].expiry
+		assert.True(t, testCache.Extend("key", time.Hour))
+		assert.Equals(t, *testCache.keyToItem["key"].expiry, firstExpiry.Add(time.Hour))
+	})
+
+	t.Run("when a missing or expired item is touched or extended it should return false", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		assert.False(t, testCache.Touch("missing"))
+		assert.False(t, testCache.Extend("missing", time.Minute))
+		testCache.Set("expired", "value", ptr.Of(time.Nanosecond))
+		time.Sleep(time.Nanosecond * 2)
+		assert.False(t, testCache.Touch("expired"))
+		assert.False(t, testCache.Extend("expired", time.Minute))
+	})
+
+	t.Run("when an expiration interval is set it should remove expired items in the background", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithExpirationInterval[string, string](time.Millisecond))
+		defer testCache.Close()
+		testCache.Set("expired", "value", ptr.Of(time.Nanosecond))
+		testCache.Set("kept", "value", nil)
+		assert.Eventually(t, func() bool {
+			testCache.rwMutex.RLock()
+			defer testCache.rwMutex.RUnlock()
+			_, expiredFound := testCache.keyToItem["expired"]
+			return !expiredFound
+		}, time.Second, time.Millisecond)
+		cacheMustHaveKeyAndValue(t, testCache, "kept", "value")
+	})
+
+	t.Run("when a clock is set it should expire items as the clock advances", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		testCache := New[string, string](WithClock[string, string](clock), WithExpirationInterval[string, string](time.Minute))
+		defer testCache.Close()
+		testCache.Set("short", "value", ptr.Of(time.Second))
+		testCache.Set("long", "value", ptr.Of(time.Hour))
+		clock.Advance(time.Second)
+		cacheMustHaveKeyAndValue(t, testCache, "short", "value")
+		clock.Advance(time.Nanosecond)
+		_, found := testCache.Get("short")
+		assert.False(t, found)
+		testCache.Set("background", "value", ptr.Of(time.Second))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		assert.Eventually(t, func() bool {
+			return testCache.Len() == 1
+		}, time.Second, time.Millisecond)
+		cacheMustHaveKeyAndValue(t, testCache, "long", "value")
+	})
+
+	t.Run("when the cache is closed multiple times it should not panic", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithExpirationInterval[string, string](time.Millisecond))
+		testCache.Close()
+		testCache.Close()
+		testCache.Set("key", "value", nil)
+		cacheMustHaveKeyAndValue(t, testCache, "key", "value")
+	})
+
+	t.Run("when the max entries or max weight is negative it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			New[string, string](WithMaxEntries[string, string](-1))
+		}, "The max entries cannot be negative.")
+		assert.PanicExact(t, func() {
+			New[string, string](WithMaxWeight(-1, func(string, string) int64 { return 1 }))
+		}, "The max weight cannot be negative.")
+	})
+
+	t.Run("when the max entries is exceeded it should evict the least recently used entry", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, int](WithMaxEntries[string, int](2))
+		testCache.Set("a", 1, nil)
+		testCache.Set("b", 2, nil)
+		cacheMustHaveKeyAndValue(t, testCache, "a", 1)
+		testCache.Set("c", 3, nil)
+		assert.Equals(t, testCache.Len(), 2)
+		_, gotten := testCache.Get("b")
+		assert.False(t, gotten)
+		cacheMustHaveKeyAndValue(t, testCache, "a", 1)
+		cacheMustHaveKeyAndValue(t, testCache, "c", 3)
+	})
+
+	t.Run("when an entry is overwritten it should not be counted twice", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, int](WithMaxEntries[string, int](2))
+		testCache.Set("a", 1, nil)
+		testCache.Set("a", 2, nil)
+		testCache.Set("b", 3, nil)
+		assert.Equals(t, testCache.Len(), 2)
+		assert.Equals(t, testCache.recency.Len(), 2)
+		cacheMustHaveKeyAndValue(t, testCache, "a", 2)
+		cacheMustHaveKeyAndValue(t, testCache, "b", 3)
+	})
+
+	t.Run("when the max weight is exceeded it should evict the least recently used entries", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithMaxWeight(10, func(key string, value string) int64 {
+			return int64(len(value))
+		}))
+		testCache.Set("a", "1234", nil)
+		testCache.Set("b", "1234", nil)
+		testCache.Set("c", "12", nil)
+		assert.Equals(t, testCache.totalWeight, int64(10))
+		testCache.Set("d", "123456", nil)
+		assert.Equals(t, testCache.Len(), 2)
+		assert.Equals(t, testCache.totalWeight, int64(8))
+		cacheMustHaveKeyAndValue(t, testCache, "c", "12")
+		cacheMustHaveKeyAndValue(t, testCache, "d", "123456")
+	})
+
+	t.Run("when an entry is heavier than the max weight it should be evicted right away", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithMaxWeight(2, func(key string, value string) int64 {
+			return int64(len(value))
+		}))
+		testCache.Set("a", "12", nil)
+		testCache.Set("b", "123", nil)
+		assert.Equals(t, testCache.Len(), 0)
+		assert.Equals(t, testCache.totalWeight, int64(0))
+	})
+
+	t.Run("when entries are removed from a bounded cache it should free their space", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string](WithMaxEntries[string, string](2), WithMaxWeight(100, func(key string, value string) int64 {
+			return int64(len(value))
+		}))
+		testCache.Set("a", "value", nil)
+		testCache.Set("b", "value", ptr.Of(time.Nanosecond))
+		time.Sleep(time.Nanosecond * 2)
+		_, gotten := testCache.Get("b")
+		assert.False(t, gotten)
+		testCache.Remove("a")
+		assert.Equals(t, testCache.recency.Len(), 0)
+		assert.Equals(t, testCache.totalWeight, int64(0))
+		testCache.Set("c", "value", nil)
+		testCache.Reset()
+		assert.Equals(t, testCache.Len(), 0)
+		assert.Equals(t, testCache.recency.Len(), 0)
+		assert.Equals(t, testCache.totalWeight, int64(0))
+	})
+
+	t.Run("when a bounded cache is accessed concurrently it should stay within its bounds", func(t *testing.T) {
+		t.Parallel()
+		const maxEntries = 16
+		testCache := New[int, int](WithMaxEntries[int, int](maxEntries))
+		const threadCount = 4
+		const loopCount = 2000
+		wg := sync.WaitGroup{}
+		startChan := make(chan struct{})
+		for i := 0; i < threadCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-startChan
+				for k := 0; k < loopCount; k++ {
+					testCache.Set(k%(maxEntries*2), k, nil)
+					testCache.Get(k % maxEntries)
+					assert.True(t, testCache.Len() <= maxEntries, assert.Continue())
+				}
+			}()
+		}
+		close(startChan)
+		wg.Wait()
+		assert.Equals(t, testCache.Len(), maxEntries)
+		assert.Equals(t, testCache.recency.Len(), maxEntries)
+	})
+
 	t.Run("it should be able to handle concurrency on unique sequential operations", func(t *testing.T) {
 		t.Parallel()
 		testCache := New[string, string]()
@@ -263,3 +493,20 @@ func TestCache(t *testing.T) {
 		assert.Equals(t, len(testCache.getOrSetKeyLocks), 0)
 	})
 }
+
+func TestCacheGetOrSetAllocations(t *testing.T) {
+	testCache := New[string, int]()
+	load := func(string) (int, *time.Duration, error) {
+		return 1, nil, nil
+	}
+	_, err := testCache.GetOrSet("key", load)
+	assert.NoError(t, err)
+	allocs := testing.AllocsPerRun(100, func() {
+		value, _ := testCache.GetOrSet("key", load)
+		if value != 1 {
+			panic("The value is not the loaded one.")
+		}
+	})
+	assert.Equals(t, allocs, float64(0))
+	assert.Equals(t, testCache.Stats().Loads, uint64(1))
+}
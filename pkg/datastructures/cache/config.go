@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// config holds the configuration of a Cache.
+type config[Key comparable, Value any] struct {
+	defaultTTL         *time.Duration
+	expirationInterval *time.Duration
+	maxEntries         int
+	maxWeight          int64
+	weigher            func(Key, Value) int64
+	onRemoval          func(Key, Value, RemovalReason)
+	clock              timestamp.Clock
+}
+
+// Option configures a Cache. It has the key and value types of the Cache, so that the functions
+// given to the options are checked against the Cache when compiling.
+type Option[Key comparable, Value any] func(*config[Key, Value])
+
+// WithDefaultTTL sets the time-to-live of the entries that are set without one.
+func WithDefaultTTL[Key comparable, Value any](ttl time.Duration) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.defaultTTL = &ttl
+	}
+}
+
+// WithExpirationInterval starts a background routine that removes the expired entries on the interval.
+// Without it, expired entries are only removed when they are accessed. Close must be called to stop the routine.
+func WithExpirationInterval[Key comparable, Value any](interval time.Duration) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.expirationInterval = &interval
+	}
+}
+
+// WithMaxEntries bounds the number of entries in the Cache.
+// When the bound is exceeded, the least recently used entries are evicted.
+func WithMaxEntries[Key comparable, Value any](maxEntries int) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.maxEntries = maxEntries
+	}
+}
+
+// WithMaxWeight bounds the total weight of the entries in the Cache. The weigher returns the weight of an entry.
+// When the bound is exceeded, the least recently used entries are evicted. An entry that is heavier than
+// the max weight is evicted as soon as it is set.
+func WithMaxWeight[Key comparable, Value any](maxWeight int64, weigher func(Key, Value) int64) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.maxWeight = maxWeight
+		cfg.weigher = weigher
+	}
+}
+
+// WithRemovalCallback sets a callback that is invoked with the key, value, and reason whenever an entry
+// is removed from the Cache. It is invoked after the lock of the Cache is released. Expired entries are
+// reported when they are removed, either on access or by the background routine of WithExpirationInterval.
+func WithRemovalCallback[Key comparable, Value any](callback func(key Key, value Value, reason RemovalReason)) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.onRemoval = callback
+	}
+}
+
+// WithClock sets the Clock used for the expiry of the entries and the background expiration routine.
+// It is meant for tests that control the time with a timestamp.FakeClock.
+func WithClock[Key comparable, Value any](clock timestamp.Clock) Option[Key, Value] {
+	return func(cfg *config[Key, Value]) {
+		cfg.clock = clock
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure[Key comparable, Value any](opts ...Option[Key, Value]) *config[Key, Value] {
+	cfg := &config[Key, Value]{
+		defaultTTL:         nil,
+		expirationInterval: nil,
+		maxEntries:         0,
+		maxWeight:          0,
+		weigher:            nil,
+		onRemoval:          nil,
+		clock:              timestamp.SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.defaultTTL != nil && *cfg.defaultTTL <= 0 {
+		panic("The default TTL must be greater than zero.")
+	}
+	if cfg.expirationInterval != nil && *cfg.expirationInterval <= 0 {
+		panic("The expiration interval must be greater than zero.")
+	}
+	if cfg.maxEntries < 0 {
+		panic("The max entries cannot be negative.")
+	}
+	if cfg.maxWeight < 0 {
+		panic("The max weight cannot be negative.")
+	}
+	return cfg
+}
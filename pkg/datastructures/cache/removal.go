@@ -0,0 +1,33 @@
+package cache
+
+// RemovalReason is why an entry was removed from a Cache.
+type RemovalReason string
+
+const (
+	// RemovalReasonRemoved is when the entry is removed with Remove or Reset.
+	RemovalReasonRemoved RemovalReason = "REMOVED"
+
+	// RemovalReasonReplaced is when the entry is overwritten by a new value for the same key.
+	RemovalReasonReplaced RemovalReason = "REPLACED"
+
+	// RemovalReasonExpired is when the TTL of the entry has passed.
+	RemovalReasonExpired RemovalReason = "EXPIRED"
+
+	// RemovalReasonEvicted is when the entry is evicted to keep the Cache within its bounds.
+	RemovalReasonEvicted RemovalReason = "EVICTED"
+)
+
+// removal is an entry removed from a Cache that is waiting for the removal callback to be invoked.
+type removal[Key comparable, Value any] struct {
+	key    Key
+	value  Value
+	reason RemovalReason
+}
+
+// notifyRemovals invokes the removal callback for each removal.
+// It must be called without holding the lock, so the callback can use the Cache.
+func (c *Cache[Key, Value]) notifyRemovals(removals []removal[Key, Value]) {
+	for _, r := range removals {
+		c.onRemoval(r.key, r.value, r.reason)
+	}
+}
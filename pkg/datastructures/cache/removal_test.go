@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type removalRecorder struct {
+	lock     sync.Mutex
+	removals []string
+}
+
+func (r *removalRecorder) callback(key string, value int, reason RemovalReason) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.removals = append(r.removals, fmt.Sprintf("%s=%d:%s", key, value, reason))
+}
+
+func (r *removalRecorder) sorted() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	sorted := append([]string{}, r.removals...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestRemovalCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when an entry is removed it should invoke the callback with the removed reason", func(t *testing.T) {
+		t.Parallel()
+		recorder := &removalRecorder{}
+		testCache := New[string, int](WithRemovalCallback(recorder.callback))
+		testCache.Set("a", 1, nil)
+		testCache.Remove("a")
+		testCache.Remove("missing")
+		assert.Equals(t, recorder.sorted(), []string{"a=1:REMOVED"})
+	})
+
+	t.Run("when an entry is replaced it should invoke the callback with the old value", func(t *testing.T) {
+		t.Parallel()
+		recorder := &removalRecorder{}
+		testCache := New[string, int](WithRemovalCallback(recorder.callback))
+		testCache.Set("a", 1, nil)
+		testCache.Set("a", 2, nil)
+		assert.Equals(t, recorder.sorted(), []string{"a=1:REPLACED"})
+	})
+
+	t.Run("when entries expire it should invoke the callback with the expired reason", func(t *testing.T) {
+		t.Parallel()
+		recorder := &removalRecorder{}
+		testCache := New[string, int](WithRemovalCallback(recorder.callback))
+		testCache.Set("a", 1, ptr.Of(time.Nanosecond))
+		testCache.Set("b", 2, ptr.Of(time.Nanosecond))
+		time.Sleep(time.Nanosecond * 2)
+		testCache.Get("a")
+		testCache.removeExpired()
+		assert.Equals(t, recorder.sorted(), []string{"a=1:EXPIRED", "b=2:EXPIRED"})
+	})
+
+	t.Run("when entries are evicted it should invoke the callback with the evicted reason", func(t *testing.T) {
+		t.Parallel()
+		recorder := &removalRecorder{}
+		testCache := New[string, int](WithMaxEntries[string, int](1), WithRemovalCallback(recorder.callback))
+		testCache.Set("a", 1, nil)
+		testCache.Set("b", 2, nil)
+		assert.Equals(t, recorder.sorted(), []string{"a=1:EVICTED"})
+	})
+
+	t.Run("when the cache is reset it should invoke the callback for each entry", func(t *testing.T) {
+		t.Parallel()
+		recorder := &removalRecorder{}
+		testCache := New[string, int](WithRemovalCallback(recorder.callback))
+		testCache.Set("a", 1, nil)
+		testCache.Set("b", 2, nil)
+		testCache.Reset()
+		assert.Equals(t, recorder.sorted(), []string{"a=1:REMOVED", "b=2:REMOVED"})
+	})
+
+	t.Run("when the callback uses the cache it should not deadlock", func(t *testing.T) {
+		t.Parallel()
+		var testCache *Cache[string, int]
+		testCache = New[string, int](WithRemovalCallback(func(key string, value int, reason RemovalReason) {
+			if reason == RemovalReasonRemoved {
+				testCache.Set(key+"-removed", value, nil)
+			}
+		}))
+		testCache.Set("a", 1, nil)
+		testCache.Remove("a")
+		cacheMustHaveKeyAndValue(t, testCache, "a-removed", 1)
+	})
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"io"
+	"time"
+)
+
+// Sharded spreads its keys over many Cache instances to reduce lock contention under concurrent writes.
+// It has the same functions as Cache.
+type Sharded[Key comparable, Value any] struct {
+	shards []*Cache[Key, Value]
+	hash   func(Key) uint64
+}
+
+// NewSharded creates a Sharded cache with shardCount shards. The hash function picks the shard of a key
+// and should spread the keys evenly. For string keys, hash/maphash.String is a good fit.
+// The options are applied to each shard, so bounds like WithMaxEntries are per shard.
+func NewSharded[Key comparable, Value any](shardCount int, hash func(Key) uint64, opts ...Option[Key, Value]) *Sharded[Key, Value] {
+	if shardCount <= 0 {
+		panic("The shard count must be greater than zero.")
+	}
+	if hash == nil {
+		panic("The hash function cannot be nil.")
+	}
+	shards := make([]*Cache[Key, Value], shardCount)
+	for i := range shards {
+		shards[i] = New[Key, Value](opts...)
+	}
+	return &Sharded[Key, Value]{
+		shards: shards,
+		hash:   hash,
+	}
+}
+
+// shard returns the Cache that holds the key.
+func (s *Sharded[Key, Value]) shard(key Key) *Cache[Key, Value] {
+	return s.shards[s.hash(key)%uint64(len(s.shards))]
+}
+
+// Set stores the value in the shard of the key. See Cache.Set.
+func (s *Sharded[Key, Value]) Set(key Key, value Value, ttl *time.Duration) {
+	s.shard(key).Set(key, value, ttl)
+}
+
+// Get returns the value from the shard of the key. See Cache.Get.
+func (s *Sharded[Key, Value]) Get(key Key) (Value, bool) {
+	return s.shard(key).Get(key)
+}
+
+// GetOrSet gets or sets the value in the shard of the key. See Cache.GetOrSet.
+func (s *Sharded[Key, Value]) GetOrSet(key Key, fn GetOrSetFn[Key, Value]) (Value, error) {
+	return s.shard(key).GetOrSet(key, fn)
+}
+
+// Touch resets the expiry time of the key. See Cache.Touch.
+func (s *Sharded[Key, Value]) Touch(key Key) bool {
+	return s.shard(key).Touch(key)
+}
+
+// Extend adds the duration to the expiry time of the key. See Cache.Extend.
+func (s *Sharded[Key, Value]) Extend(key Key, duration time.Duration) bool {
+	return s.shard(key).Extend(key, duration)
+}
+
+// Remove removes the key from its shard.
+func (s *Sharded[Key, Value]) Remove(key Key) {
+	s.shard(key).Remove(key)
+}
+
+// Reset removes all the keys from all the shards.
+func (s *Sharded[Key, Value]) Reset() {
+	for _, shard := range s.shards {
+		shard.Reset()
+	}
+}
+
+// Len returns the number of entries in all the shards.
+func (s *Sharded[Key, Value]) Len() int {
+	length := 0
+	for _, shard := range s.shards {
+		length += shard.Len()
+	}
+	return length
+}
+
+// Stats returns the sum of the stats of all the shards.
+func (s *Sharded[Key, Value]) Stats() Stats {
+	total := Stats{}
+	for _, shard := range s.shards {
+		stats := shard.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Evictions += stats.Evictions
+		total.Expirations += stats.Expirations
+		total.Loads += stats.Loads
+		total.LoadErrors += stats.LoadErrors
+		total.LoadDuration += stats.LoadDuration
+	}
+	return total
+}
+
+// Close stops the background routines of all the shards. See Cache.Close.
+func (s *Sharded[Key, Value]) Close() {
+	for _, shard := range s.shards {
+		shard.Close()
+	}
+}
+
+// Export writes the entries of all the shards to the writer. See Cache.Export.
+func (s *Sharded[Key, Value]) Export(w io.Writer) error {
+	for _, shard := range s.shards {
+		if err := shard.Export(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import reads entries written by Export and sets them in their shard. See Cache.Import.
+func (s *Sharded[Key, Value]) Import(r io.Reader) error {
+	return importEntries(r, func(key Key, value Value, ttl *time.Duration) {
+		s.shard(key).set(key, value, ttl)
+	})
+}
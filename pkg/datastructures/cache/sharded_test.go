@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"errors"
+	"hash/maphash"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func intHash(key int) uint64 {
+	return uint64(key)
+}
+
+func TestSharded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the shard count is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			NewSharded[int, int](0, intHash)
+		}, "The shard count must be greater than zero.")
+	})
+
+	t.Run("when the hash function is nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			NewSharded[int, int](1, nil)
+		}, "The hash function cannot be nil.")
+	})
+
+	t.Run("when keys are set it should spread them over the shards", func(t *testing.T) {
+		t.Parallel()
+		testCache := NewSharded[int, int](4, intHash)
+		for i := 0; i < 8; i++ {
+			testCache.Set(i, i*10, nil)
+		}
+		for _, shard := range testCache.shards {
+			assert.Equals(t, shard.Len(), 2)
+		}
+		assert.Equals(t, testCache.Len(), 8)
+		for i := 0; i < 8; i++ {
+			value, gotten := testCache.Get(i)
+			assert.True(t, gotten)
+			assert.Equals(t, value, i*10)
+		}
+	})
+
+	t.Run("when the cache functions are called it should delegate them to the shard of the key", func(t *testing.T) {
+		t.Parallel()
+		testCache := NewSharded[int, string](3, intHash, WithDefaultTTL[int, string](time.Minute))
+		defer testCache.Close()
+
+		value, err := testCache.GetOrSet(1, func(key int) (string, *time.Duration, error) {
+			return "value", nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equals(t, value, "value")
+		_, err = testCache.GetOrSet(2, func(key int) (string, *time.Duration, error) {
+			return "", nil, errors.New("load error")
+		})
+		assert.ErrorExact(t, err, "load error")
+
+		assert.True(t, testCache.Touch(1))
+		assert.True(t, testCache.Extend(1, time.Minute))
+		assert.False(t, testCache.Touch(2))
+
+		testCache.Set(4, "other", ptr.Of(time.Hour))
+		testCache.Remove(1)
+		_, gotten := testCache.Get(1)
+		assert.False(t, gotten)
+		assert.Equals(t, testCache.Len(), 1)
+
+		stats := testCache.Stats()
+		assert.Equals(t, stats.Loads, uint64(2))
+		assert.Equals(t, stats.LoadErrors, uint64(1))
+		assert.Equals(t, stats.Misses, uint64(3))
+
+		testCache.Reset()
+		assert.Equals(t, testCache.Len(), 0)
+	})
+
+	t.Run("when the sharded cache is accessed concurrently it should have no issues", func(t *testing.T) {
+		t.Parallel()
+		testCache := NewSharded[int, int](8, intHash)
+		const threadCount = 4
+		const loopCount = 2000
+		loads := atomic.Int32{}
+		wg := sync.WaitGroup{}
+		startChan := make(chan struct{})
+		for i := 0; i < threadCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-startChan
+				for k := 0; k < loopCount; k++ {
+					value, err := testCache.GetOrSet(k, func(key int) (int, *time.Duration, error) {
+						loads.Add(1)
+						return key, nil, nil
+					})
+					assert.NoError(t, err, assert.Continue())
+					assert.Equals(t, value, k, assert.Continue())
+				}
+			}()
+		}
+		close(startChan)
+		wg.Wait()
+		assert.Equals(t, testCache.Len(), loopCount)
+		assert.Equals(t, int(loads.Load()), loopCount)
+	})
+}
+
+func BenchmarkCacheSetGet(b *testing.B) {
+	seed := maphash.MakeSeed()
+	hash := func(key string) uint64 {
+		return maphash.String(seed, key)
+	}
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	b.Run("single", func(b *testing.B) {
+		testCache := New[string, int]()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				key := keys[i%len(keys)]
+				testCache.Set(key, i, nil)
+				testCache.Get(key)
+				i++
+			}
+		})
+	})
+
+	b.Run("sharded", func(b *testing.B) {
+		testCache := NewSharded[string, int](32, hash)
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				key := keys[i%len(keys)]
+				testCache.Set(key, i, nil)
+				testCache.Get(key)
+				i++
+			}
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// snapshotEntry is the serialized form of a Cache entry.
+// It is written as one JSON object per line.
+type snapshotEntry[Key comparable, Value any] struct {
+	Key          Key            `json:"key"`
+	Value        Value          `json:"value"`
+	RemainingTTL *time.Duration `json:"remaining_ttl,omitempty"`
+}
+
+// Export writes the entries of the Cache to the writer as JSON lines, with the remaining TTL of each entry.
+// Expired entries are skipped. The keys and values must be serializable with encoding/json.
+// The entries are copied before being written, so a slow writer does not block the Cache.
+func (c *Cache[Key, Value]) Export(w io.Writer) error {
+	now := c.clock.Now()
+	c.rwMutex.RLock()
+	entries := make([]snapshotEntry[Key, Value], 0, len(c.keyToItem))
+	for key, itemValue := range c.keyToItem {
+		if itemValue.expired(now) {
+			continue
+		}
+		entry := snapshotEntry[Key, Value]{
+			Key:          key,
+			Value:        itemValue.value,
+			RemainingTTL: nil,
+		}
+		if itemValue.expiry != nil {
+			remainingTTL := itemValue.expiry.Sub(now)
+			entry.RemainingTTL = &remainingTTL
+		}
+		entries = append(entries, entry)
+	}
+	c.rwMutex.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode the cache entry (%w)", err)
+		}
+	}
+	return nil
+}
+
+// Import reads entries written by Export and sets them in the Cache with their remaining TTL.
+// Entries that did not expire when exported do not expire when imported, even if there is a default TTL.
+func (c *Cache[Key, Value]) Import(r io.Reader) error {
+	return importEntries(r, c.set)
+}
+
+// importEntries decodes the entries written by Export and calls set for each of them.
+func importEntries[Key comparable, Value any](r io.Reader, set func(key Key, value Value, ttl *time.Duration)) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var entry snapshotEntry[Key, Value]
+		if err := decoder.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode the cache entry (%w)", err)
+		}
+		set(entry.Key, entry.Value, entry.RemainingTTL)
+	}
+}
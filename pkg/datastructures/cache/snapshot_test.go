@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a cache is exported and imported it should restore its entries and remaining TTLs", func(t *testing.T) {
+		t.Parallel()
+		source := New[string, int]()
+		source.Set("forever", 1, nil)
+		source.Set("later", 2, ptr.Of(time.Hour))
+		source.Set("expired", 3, ptr.Of(time.Nanosecond))
+		time.Sleep(time.Nanosecond * 2)
+
+		buffer := &bytes.Buffer{}
+		assert.NoError(t, source.Export(buffer))
+		assert.Equals(t, strings.Count(buffer.String(), "\n"), 2)
+
+		destination := New[string, int](WithDefaultTTL[string, int](time.Nanosecond))
+		assert.NoError(t, destination.Import(buffer))
+		assert.Equals(t, destination.Len(), 2)
+		time.Sleep(time.Nanosecond * 2)
+		cacheMustHaveKeyAndValue(t, destination, "forever", 1)
+		cacheMustHaveKeyAndValue(t, destination, "later", 2)
+		assert.Nil(t, destination.keyToItem["forever"].expiry)
+		remaining := time.Until(*destination.keyToItem["later"].expiry)
+		assert.True(t, remaining > time.Minute*59 && remaining <= time.Hour)
+	})
+
+	t.Run("when an empty cache is exported it should write nothing", func(t *testing.T) {
+		t.Parallel()
+		buffer := &bytes.Buffer{}
+		assert.NoError(t, New[string, int]().Export(buffer))
+		assert.Equals(t, buffer.Len(), 0)
+		destination := New[string, int]()
+		assert.NoError(t, destination.Import(buffer))
+		assert.Equals(t, destination.Len(), 0)
+	})
+
+	t.Run("when the writer fails it should return an error", func(t *testing.T) {
+		t.Parallel()
+		source := New[string, int]()
+		source.Set("key", 1, nil)
+		assert.ErrorExact(t, source.Export(failingWriter{}), "failed to encode the cache entry (write error)")
+	})
+
+	t.Run("when a value cannot be encoded it should return an error", func(t *testing.T) {
+		t.Parallel()
+		source := New[string, func()]()
+		source.Set("key", func() {}, nil)
+		assert.ErrorPart(t, source.Export(&bytes.Buffer{}), "failed to encode the cache entry")
+	})
+
+	t.Run("when the snapshot is malformed it should return an error", func(t *testing.T) {
+		t.Parallel()
+		destination := New[string, int]()
+		err := destination.Import(strings.NewReader("{\"key\":\"a\",\"value\":1}\n{not json"))
+		assert.ErrorPart(t, err, "failed to decode the cache entry")
+		cacheMustHaveKeyAndValue(t, destination, "a", 1)
+	})
+
+	t.Run("when a sharded cache is exported and imported it should restore its entries in their shards", func(t *testing.T) {
+		t.Parallel()
+		source := NewSharded[int, string](4, intHash)
+		for i := 0; i < 8; i++ {
+			source.Set(i, "value", nil)
+		}
+		buffer := &bytes.Buffer{}
+		assert.NoError(t, source.Export(buffer))
+
+		destination := NewSharded[int, string](2, intHash)
+		assert.NoError(t, destination.Import(buffer))
+		assert.Equals(t, destination.Len(), 8)
+		for _, shard := range destination.shards {
+			assert.Equals(t, shard.Len(), 4)
+		}
+	})
+
+	t.Run("when a shard fails to export it should return an error", func(t *testing.T) {
+		t.Parallel()
+		source := NewSharded[int, string](2, intHash)
+		source.Set(1, "value", nil)
+		assert.ErrorPart(t, source.Export(failingWriter{}), "write error")
+	})
+}
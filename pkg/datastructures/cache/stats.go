@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the counters of a Cache.
+type Stats struct {
+	// Hits is the number of times Get found a value.
+	Hits uint64
+
+	// Misses is the number of times Get did not find a value.
+	Misses uint64
+
+	// Evictions is the number of entries removed to keep the Cache within its bounds.
+	Evictions uint64
+
+	// Expirations is the number of expired entries removed from the Cache.
+	Expirations uint64
+
+	// Loads is the number of times a GetOrSet function was called.
+	Loads uint64
+
+	// LoadErrors is the number of times a GetOrSet function returned an error.
+	LoadErrors uint64
+
+	// LoadDuration is the total time spent in GetOrSet functions.
+	LoadDuration time.Duration
+}
+
+// HitRatio returns the ratio of hits over the number of lookups. It returns zero if there were no lookups.
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+// counters are the atomic counters behind Stats.
+type counters struct {
+	hits         atomic.Uint64
+	misses       atomic.Uint64
+	evictions    atomic.Uint64
+	expirations  atomic.Uint64
+	loads        atomic.Uint64
+	loadErrors   atomic.Uint64
+	loadDuration atomic.Int64
+}
+
+// snapshot returns the current values of the counters.
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Evictions:    c.evictions.Load(),
+		Expirations:  c.expirations.Load(),
+		Loads:        c.loads.Load(),
+		LoadErrors:   c.loadErrors.Load(),
+		LoadDuration: time.Duration(c.loadDuration.Load()),
+	}
+}
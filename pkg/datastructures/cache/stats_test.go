@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the cache is new it should have empty stats", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		assert.Equals(t, testCache.Stats(), Stats{})
+		assert.Equals(t, testCache.Stats().HitRatio(), 0.0)
+	})
+
+	t.Run("when values are gotten it should count the hits and misses", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		testCache.Set("key", "value", nil)
+		testCache.Get("key")
+		testCache.Get("key")
+		testCache.Get("key")
+		testCache.Get("missing")
+		stats := testCache.Stats()
+		assert.Equals(t, stats.Hits, uint64(3))
+		assert.Equals(t, stats.Misses, uint64(1))
+		assert.FloatEquals(t, stats.HitRatio(), 0.75, 0.0001)
+	})
+
+	t.Run("when values expire it should count the expirations and misses", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		testCache.Set("first", "value", ptr.Of(time.Nanosecond))
+		testCache.Set("second", "value", ptr.Of(time.Nanosecond))
+		time.Sleep(time.Nanosecond * 2)
+		testCache.Get("first")
+		testCache.removeExpired()
+		stats := testCache.Stats()
+		assert.Equals(t, stats.Expirations, uint64(2))
+		assert.Equals(t, stats.Misses, uint64(1))
+	})
+
+	t.Run("when values are evicted it should count the evictions", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[int, int](WithMaxEntries[int, int](1))
+		testCache.Set(1, 1, nil)
+		testCache.Set(2, 2, nil)
+		testCache.Set(3, 3, nil)
+		assert.Equals(t, testCache.Stats().Evictions, uint64(2))
+	})
+
+	t.Run("when get or set calls its function it should count the loads, errors, and duration", func(t *testing.T) {
+		t.Parallel()
+		testCache := New[string, string]()
+		_, err := testCache.GetOrSet("key", func(key string) (string, *time.Duration, error) {
+			time.Sleep(time.Millisecond)
+			return "value", nil, nil
+		})
+		assert.NoError(t, err)
+		_, err = testCache.GetOrSet("key", func(key string) (string, *time.Duration, error) {
+			return "", nil, errors.New("should not be called")
+		})
+		assert.NoError(t, err)
+		_, err = testCache.GetOrSet("other", func(key string) (string, *time.Duration, error) {
+			return "", nil, errors.New("load error")
+		})
+		assert.ErrorExact(t, err, "load error")
+		stats := testCache.Stats()
+		assert.Equals(t, stats.Loads, uint64(2))
+		assert.Equals(t, stats.LoadErrors, uint64(1))
+		assert.True(t, stats.LoadDuration >= time.Millisecond)
+		assert.Equals(t, stats.Hits, uint64(1))
+		assert.Equals(t, stats.Misses, uint64(2))
+	})
+}
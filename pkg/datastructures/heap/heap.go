@@ -1,6 +1,7 @@
 package heap
 
 import (
+	"context"
 	"sync"
 )
 
@@ -75,12 +76,34 @@ import (
 //    /              /
 //   8              15
 
+// Handle refers to a value that was pushed on a Heap with PushHandle.
+// It is used to update the priority of the value or remove it from the heap.
+type Handle[T any] struct {
+	heap  *Heap[T]
+	value T
+	index int
+}
+
+// node is an element of the tree. The handle is nil if the value was pushed without one.
+type node[T any] struct {
+	value  T
+	handle *Handle[T]
+}
+
+// Value returns the value referred to by the handle.
+func (handle *Handle[T]) Value() T {
+	handle.heap.lock.RLock()
+	defer handle.heap.lock.RUnlock()
+	return handle.value
+}
+
 // Heap is a tree-based data structure optimized for quickly accessing the minimum or maximum element,
 // depending on the comparator. It supports O(log n) insertion and deletion operations. It is commonly
-// used in priority queues, heap sort, and graph algorithms.
+// used in priority queues, heap sort, and graph algorithms. It is safe for concurrent use.
 type Heap[T any] struct {
 	hasPriority func(a T, b T) bool
-	tree        []T
+	tree        []node[T]
+	pushed      chan struct{}
 	lock        sync.RWMutex
 }
 
@@ -88,7 +111,8 @@ type Heap[T any] struct {
 func New[T any](hasPriority func(a T, b T) bool) *Heap[T] {
 	return &Heap[T]{
 		hasPriority: hasPriority,
-		tree:        make([]T, 0, 1),
+		tree:        make([]node[T], 0, 1),
+		pushed:      nil,
 		lock:        sync.RWMutex{},
 	}
 }
@@ -104,16 +128,31 @@ func (h *Heap[T]) Size() int {
 func (h *Heap[T]) Push(value T) {
 	h.lock.Lock()
 	defer h.lock.Unlock()
+	h.push(node[T]{value: value, handle: nil})
+}
 
-	h.tree = append(h.tree, value)
+// PushHandle adds a new value to the heap and returns a handle to it.
+// The handle can be used to update or remove the value.
+func (h *Heap[T]) PushHandle(value T) *Handle[T] {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	handle := &Handle[T]{
+		heap:  h,
+		value: value,
+		index: len(h.tree),
+	}
+	h.push(node[T]{value: value, handle: handle})
+	return handle
+}
 
-	index := len(h.tree) - 1
-	for index > 0 {
-		parentIndex := (index - 1) / 2
-		if h.hasPriority(h.tree[index], h.tree[parentIndex]) {
-			h.tree[index], h.tree[parentIndex] = h.tree[parentIndex], h.tree[index]
-		}
-		index = parentIndex
+// push appends the node to the tree, restores the heap property, and wakes up the routines waiting in PopWait.
+func (h *Heap[T]) push(pushed node[T]) {
+	h.tree = append(h.tree, pushed)
+	h.bubbleUp(len(h.tree) - 1)
+
+	if h.pushed != nil {
+		close(h.pushed)
+		h.pushed = nil
 	}
 }
 
@@ -122,17 +161,119 @@ func (h *Heap[T]) Push(value T) {
 func (h *Heap[T]) Pop() T {
 	h.lock.Lock()
 	defer h.lock.Unlock()
+	return h.removeAt(0).value
+}
+
+// PopWait removes the largest or smallest element (depending on the comparator) from the heap.
+// If the heap is empty, it waits until a value is pushed or the context is done.
+func (h *Heap[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		h.lock.Lock()
+		if len(h.tree) > 0 {
+			value := h.removeAt(0).value
+			h.lock.Unlock()
+			return value, nil
+		}
+		if h.pushed == nil {
+			h.pushed = make(chan struct{})
+		}
+		pushed := h.pushed
+		h.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-pushed:
+		}
+	}
+}
+
+// Peek returns the min or max value on this heap. The access is O(1).
+// It panics if there is no values in the heap.
+func (h *Heap[T]) Peek() T {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.tree[0].value
+}
+
+// Fix replaces the value referred to by the handle and restores the heap property in O(log n).
+// It panics if the handle is not in this heap.
+func (h *Heap[T]) Fix(handle *Handle[T], value T) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.mustContain(handle)
+	handle.value = value
+	h.tree[handle.index].value = value
+	h.bubbleDown(h.bubbleUp(handle.index))
+}
+
+// Remove removes the value referred to by the handle from the heap in O(log n).
+// It panics if the handle is not in this heap.
+func (h *Heap[T]) Remove(handle *Handle[T]) T {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.mustContain(handle)
+	return h.removeAt(handle.index).value
+}
+
+// mustContain panics if the handle is not in this heap.
+func (h *Heap[T]) mustContain(handle *Handle[T]) {
+	if handle.heap != h || handle.index < 0 {
+		panic("The handle is not in the heap.")
+	}
+}
+
+// removeAt removes the node at the index and restores the heap property.
+func (h *Heap[T]) removeAt(index int) node[T] {
+	removed := h.tree[index]
+	lastIndex := len(h.tree) - 1
+	h.swap(index, lastIndex)
+	h.tree[lastIndex] = node[T]{}
+	h.tree = h.tree[:lastIndex]
+	if removed.handle != nil {
+		removed.handle.index = -1
+	}
+
+	if index < len(h.tree) {
+		h.bubbleDown(h.bubbleUp(index))
+	}
+
+	return removed
+}
+
+// swap swaps two nodes of the tree and keeps their handle indexes up to date.
+func (h *Heap[T]) swap(a int, b int) {
+	h.tree[a], h.tree[b] = h.tree[b], h.tree[a]
+	if h.tree[a].handle != nil {
+		h.tree[a].handle.index = a
+	}
+	if h.tree[b].handle != nil {
+		h.tree[b].handle.index = b
+	}
+}
 
-	retValue := h.tree[0]
-	h.tree[0] = h.tree[len(h.tree)-1]
-	h.tree = h.tree[:len(h.tree)-1]
+// bubbleUp swaps the node with its parent until its parent has priority over it.
+// It returns the final index of the node.
+func (h *Heap[T]) bubbleUp(index int) int {
+	for index > 0 {
+		parentIndex := (index - 1) / 2
+		if !h.hasPriority(h.tree[index].value, h.tree[parentIndex].value) {
+			break
+		}
+		h.swap(index, parentIndex)
+		index = parentIndex
+	}
+	return index
+}
 
-	index := 0
+// bubbleDown swaps the node with its child with the most priority until it has priority over its children.
+func (h *Heap[T]) bubbleDown(index int) {
 	for {
 		leftIndex := (index * 2) + 1
 		var swapLeft bool
 		if leftIndex < len(h.tree) {
-			swapLeft = h.hasPriority(h.tree[leftIndex], h.tree[index])
+			swapLeft = h.hasPriority(h.tree[leftIndex].value, h.tree[index].value)
 		} else {
 			break
 		}
@@ -140,11 +281,11 @@ func (h *Heap[T]) Pop() T {
 		rightIndex := (index * 2) + 2
 		var swapRight bool
 		if rightIndex < len(h.tree) {
-			swapRight = h.hasPriority(h.tree[rightIndex], h.tree[index])
+			swapRight = h.hasPriority(h.tree[rightIndex].value, h.tree[index].value)
 		}
 
 		if swapLeft && swapRight {
-			if h.hasPriority(h.tree[leftIndex], h.tree[rightIndex]) {
+			if h.hasPriority(h.tree[leftIndex].value, h.tree[rightIndex].value) {
 				swapRight = false
 			} else {
 				swapLeft = false
@@ -152,27 +293,17 @@ func (h *Heap[T]) Pop() T {
 		}
 
 		if swapLeft {
-			h.tree[index], h.tree[leftIndex] = h.tree[leftIndex], h.tree[index]
+			h.swap(index, leftIndex)
 			index = leftIndex
 			continue
 		}
 
 		if swapRight {
-			h.tree[index], h.tree[rightIndex] = h.tree[rightIndex], h.tree[index]
+			h.swap(index, rightIndex)
 			index = rightIndex
 			continue
 		}
 
 		break
 	}
-
-	return retValue
-}
-
-// Peek returns the min or max value on this heap. The access is O(1).
-// It panics if there is no values in the heap.
-func (h *Heap[T]) Peek() T {
-	h.lock.RLock()
-	defer h.lock.RUnlock()
-	return h.tree[0]
 }
@@ -1,10 +1,13 @@
 package heap_test
 
 import (
+	"context"
 	"math"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/TriangleSide/GoTools/pkg/datastructures/heap"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
@@ -192,6 +195,90 @@ func TestHeap(t *testing.T) {
 		assert.Equals(t, 0, len(valueToCount))
 	})
 
+	t.Run("when a value is fixed it should move to its new position in the heap", func(t *testing.T) {
+		t.Parallel()
+
+		minHeap := heap.New(func(a, b int) bool { return a < b })
+		minHeap.Push(10)
+		handle := minHeap.PushHandle(20)
+		minHeap.Push(30)
+		assert.Equals(t, handle.Value(), 20)
+
+		minHeap.Fix(handle, 5)
+		assert.Equals(t, handle.Value(), 5)
+		assert.Equals(t, minHeap.Peek(), 5)
+
+		minHeap.Fix(handle, 40)
+		assert.Equals(t, minHeap.Pop(), 10)
+		assert.Equals(t, minHeap.Pop(), 30)
+		assert.Equals(t, minHeap.Pop(), 40)
+	})
+
+	t.Run("when a value is removed by its handle it should no longer be in the heap", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		maxHeap.Push(10)
+		handle := maxHeap.PushHandle(20)
+		maxHeap.Push(5)
+		maxHeap.Push(15)
+
+		assert.Equals(t, maxHeap.Remove(handle), 20)
+		assert.Equals(t, maxHeap.Size(), 3)
+		assert.Equals(t, maxHeap.Pop(), 15)
+		assert.Equals(t, maxHeap.Pop(), 10)
+		assert.Equals(t, maxHeap.Pop(), 5)
+	})
+
+	t.Run("when the last value is removed by its handle it should leave an empty heap", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		handle := maxHeap.PushHandle(10)
+		assert.Equals(t, maxHeap.Remove(handle), 10)
+		assert.Equals(t, maxHeap.Size(), 0)
+	})
+
+	t.Run("when a handle is no longer in the heap it should panic", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		poppedHandle := maxHeap.PushHandle(10)
+		maxHeap.Pop()
+		assert.PanicExact(t, func() { maxHeap.Remove(poppedHandle) }, "The handle is not in the heap.")
+		assert.PanicExact(t, func() { maxHeap.Fix(poppedHandle, 1) }, "The handle is not in the heap.")
+
+		otherHeap := heap.New(func(a, b int) bool { return a > b })
+		otherHandle := otherHeap.PushHandle(10)
+		assert.PanicExact(t, func() { maxHeap.Remove(otherHandle) }, "The handle is not in the heap.")
+	})
+
+	t.Run("when random values are fixed and removed it should retain its heap properties", func(t *testing.T) {
+		t.Parallel()
+
+		const count = 1000
+		minHeap := heap.New(func(a, b int) bool { return a < b })
+		handles := make([]*heap.Handle[int], 0, count)
+		for i := 0; i < count; i++ {
+			handles = append(handles, minHeap.PushHandle(rand.IntN(count)))
+		}
+
+		for i := 0; i < count/2; i++ {
+			minHeap.Fix(handles[i], rand.IntN(count))
+		}
+		for i := count / 2; i < count*3/4; i++ {
+			minHeap.Remove(handles[i])
+		}
+
+		assert.Equals(t, minHeap.Size(), count-(count/4))
+		lastValue := -1
+		for minHeap.Size() > 0 {
+			value := minHeap.Pop()
+			assert.True(t, value >= lastValue)
+			lastValue = value
+		}
+	})
+
 	t.Run("when the heap is accessed concurrently it should have no issues", func(t *testing.T) {
 		t.Parallel()
 
@@ -229,4 +316,92 @@ func TestHeap(t *testing.T) {
 
 		assert.Equals(t, 0, maxHeap.Size())
 	})
+
+	t.Run("when PopWait is called on a heap with values it should return the top value", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		maxHeap.Push(10)
+		maxHeap.Push(20)
+		value, err := maxHeap.PopWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, value, 20)
+		assert.Equals(t, maxHeap.Size(), 1)
+	})
+
+	t.Run("when PopWait is called on an empty heap it should wait for a value to be pushed", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		popped := make(chan int)
+		go func() {
+			value, err := maxHeap.PopWait(context.Background())
+			assert.NoError(t, err)
+			popped <- value
+		}()
+
+		select {
+		case <-popped:
+			t.Fatal("PopWait returned before a value was pushed.")
+		case <-time.After(time.Millisecond * 10):
+		}
+
+		maxHeap.Push(10)
+		assert.Equals(t, <-popped, 10)
+		assert.Equals(t, maxHeap.Size(), 0)
+	})
+
+	t.Run("when the context of PopWait is canceled it should return the context error", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		value, err := maxHeap.PopWait(ctx)
+		assert.ErrorExact(t, err, context.DeadlineExceeded.Error())
+		assert.Equals(t, value, 0)
+	})
+
+	t.Run("when many routines wait on PopWait it should give each pushed value to one routine", func(t *testing.T) {
+		t.Parallel()
+
+		maxHeap := heap.New(func(a, b int) bool { return a > b })
+		const routineCount = 8
+		const countPerRoutine = 500
+
+		wg := sync.WaitGroup{}
+		poppedCount := atomic.Int32{}
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < countPerRoutine; k++ {
+					_, err := maxHeap.PopWait(context.Background())
+					assert.NoError(t, err)
+					poppedCount.Add(1)
+				}
+			}()
+		}
+
+		for i := 0; i < routineCount*countPerRoutine; i++ {
+			maxHeap.Push(rand.IntN(countPerRoutine))
+		}
+
+		wg.Wait()
+		assert.Equals(t, poppedCount.Load(), int32(routineCount*countPerRoutine))
+		assert.Equals(t, maxHeap.Size(), 0)
+	})
+}
+
+func TestHeapAllocations(t *testing.T) {
+	t.Run("when a value is pushed without a handle it should not allocate", func(t *testing.T) {
+		minHeap := heap.New(func(a, b int) bool { return a < b })
+		minHeap.Push(1)
+		minHeap.Pop()
+		allocations := testing.AllocsPerRun(100, func() {
+			minHeap.Push(1)
+			minHeap.Pop()
+		})
+		assert.Equals(t, allocations, 0.0)
+	})
 }
@@ -0,0 +1,127 @@
+package priorityqueue
+
+import (
+	"sync/atomic"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/heap"
+)
+
+// config is configured by the Option type.
+type config struct {
+	stable bool
+}
+
+// Option configures a PriorityQueue.
+type Option func(*config)
+
+// WithStableOrder makes values with equal priorities pop in the order they were pushed.
+func WithStableOrder() Option {
+	return func(cfg *config) {
+		cfg.stable = true
+	}
+}
+
+// entry is a value and its priority stored in the heap.
+type entry[T any, Priority any] struct {
+	value    T
+	priority Priority
+	sequence uint64
+}
+
+// Item refers to a value pushed on a PriorityQueue.
+// It is used to update the priority of the value or remove it from the queue.
+type Item[T any, Priority any] struct {
+	handle *heap.Handle[entry[T, Priority]]
+}
+
+// Value returns the value of the item.
+func (i *Item[T, Priority]) Value() T {
+	return i.handle.Value().value
+}
+
+// Priority returns the current priority of the item.
+func (i *Item[T, Priority]) Priority() Priority {
+	return i.handle.Value().priority
+}
+
+// PriorityQueue pops its values by priority. The priority of a value can be updated after it is pushed,
+// which is needed for schedulers and algorithms like Dijkstra's. It is safe for concurrent use.
+type PriorityQueue[T any, Priority any] struct {
+	heap     *heap.Heap[entry[T, Priority]]
+	sequence atomic.Uint64
+}
+
+// New instantiates a PriorityQueue. The hasPriority function returns true if a should pop before b.
+// For example, (a < b) pops the smallest priority first.
+func New[T any, Priority any](hasPriority func(a Priority, b Priority) bool, opts ...Option) *PriorityQueue[T, Priority] {
+	cfg := &config{
+		stable: false,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	compare := func(a entry[T, Priority], b entry[T, Priority]) bool {
+		return hasPriority(a.priority, b.priority)
+	}
+	if cfg.stable {
+		compare = func(a entry[T, Priority], b entry[T, Priority]) bool {
+			if hasPriority(a.priority, b.priority) {
+				return true
+			}
+			if hasPriority(b.priority, a.priority) {
+				return false
+			}
+			return a.sequence < b.sequence
+		}
+	}
+
+	return &PriorityQueue[T, Priority]{
+		heap:     heap.New(compare),
+		sequence: atomic.Uint64{},
+	}
+}
+
+// Size returns the number of values in the queue.
+func (q *PriorityQueue[T, Priority]) Size() int {
+	return q.heap.Size()
+}
+
+// Push adds the value with its priority to the queue.
+func (q *PriorityQueue[T, Priority]) Push(value T, priority Priority) *Item[T, Priority] {
+	return &Item[T, Priority]{
+		handle: q.heap.PushHandle(entry[T, Priority]{
+			value:    value,
+			priority: priority,
+			sequence: q.sequence.Add(1),
+		}),
+	}
+}
+
+// Pop removes the value with the most priority from the queue.
+// It panics if the queue is empty.
+func (q *PriorityQueue[T, Priority]) Pop() (T, Priority) {
+	popped := q.heap.Pop()
+	return popped.value, popped.priority
+}
+
+// Peek returns the value with the most priority without removing it.
+// It panics if the queue is empty.
+func (q *PriorityQueue[T, Priority]) Peek() (T, Priority) {
+	peeked := q.heap.Peek()
+	return peeked.value, peeked.priority
+}
+
+// Update changes the priority of the item in O(log n).
+// The item keeps its push order for WithStableOrder. It panics if the item is no longer in the queue.
+func (q *PriorityQueue[T, Priority]) Update(item *Item[T, Priority], priority Priority) {
+	updated := item.handle.Value()
+	updated.priority = priority
+	q.heap.Fix(item.handle, updated)
+}
+
+// Remove removes the item from the queue in O(log n) and returns its value.
+// It panics if the item is no longer in the queue.
+func (q *PriorityQueue[T, Priority]) Remove(item *Item[T, Priority]) T {
+	return q.heap.Remove(item.handle).value
+}
@@ -0,0 +1,119 @@
+package priorityqueue_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/priorityqueue"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func lessThan(a, b int) bool {
+	return a < b
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when values are pushed it should pop them by priority", func(t *testing.T) {
+		t.Parallel()
+		q := priorityqueue.New[string](lessThan)
+		q.Push("low", 10)
+		q.Push("high", 1)
+		q.Push("mid", 5)
+		assert.Equals(t, q.Size(), 3)
+		value, priority := q.Peek()
+		assert.Equals(t, value, "high")
+		assert.Equals(t, priority, 1)
+		for _, expected := range []string{"high", "mid", "low"} {
+			value, _ = q.Pop()
+			assert.Equals(t, value, expected)
+		}
+		assert.Equals(t, q.Size(), 0)
+	})
+
+	t.Run("when the queue is empty it should panic on pop and peek", func(t *testing.T) {
+		t.Parallel()
+		q := priorityqueue.New[string](lessThan)
+		assert.Panic(t, func() { q.Pop() })
+		assert.Panic(t, func() { q.Peek() })
+	})
+
+	t.Run("when the priority of an item is updated it should move in the queue", func(t *testing.T) {
+		t.Parallel()
+		q := priorityqueue.New[string](lessThan)
+		q.Push("a", 1)
+		b := q.Push("b", 2)
+		c := q.Push("c", 3)
+		q.Update(c, 0)
+		assert.Equals(t, c.Priority(), 0)
+		assert.Equals(t, c.Value(), "c")
+		q.Update(b, 10)
+		for _, expected := range []string{"c", "a", "b"} {
+			value, _ := q.Pop()
+			assert.Equals(t, value, expected)
+		}
+	})
+
+	t.Run("when an item is removed it should no longer be in the queue", func(t *testing.T) {
+		t.Parallel()
+		q := priorityqueue.New[string](lessThan)
+		a := q.Push("a", 1)
+		q.Push("b", 2)
+		assert.Equals(t, q.Remove(a), "a")
+		assert.Equals(t, q.Size(), 1)
+		assert.PanicExact(t, func() { q.Remove(a) }, "The handle is not in the heap.")
+		assert.PanicExact(t, func() { q.Update(a, 0) }, "The handle is not in the heap.")
+	})
+
+	t.Run("when the order is stable it should pop equal priorities in push order", func(t *testing.T) {
+		t.Parallel()
+		q := priorityqueue.New[int](lessThan, priorityqueue.WithStableOrder())
+		const count = 100
+		for i := 0; i < count; i++ {
+			q.Push(i, i%2)
+		}
+		for i := 0; i < count; i += 2 {
+			value, priority := q.Pop()
+			assert.Equals(t, priority, 0)
+			assert.Equals(t, value, i)
+		}
+		for i := 1; i < count; i += 2 {
+			value, priority := q.Pop()
+			assert.Equals(t, priority, 1)
+			assert.Equals(t, value, i)
+		}
+	})
+
+	t.Run("when used for shortest paths it should find the shortest distances", func(t *testing.T) {
+		t.Parallel()
+		type edge struct {
+			to     int
+			weight int
+		}
+		graph := map[int][]edge{
+			0: {{to: 1, weight: 4}, {to: 2, weight: 1}},
+			2: {{to: 1, weight: 2}, {to: 3, weight: 5}},
+			1: {{to: 3, weight: 1}},
+		}
+		distances := []int{0, math.MaxInt, math.MaxInt, math.MaxInt}
+		q := priorityqueue.New[int](lessThan)
+		items := map[int]*priorityqueue.Item[int, int]{0: q.Push(0, 0)}
+		for q.Size() > 0 {
+			node, distance := q.Pop()
+			delete(items, node)
+			for _, e := range graph[node] {
+				if distance+e.weight >= distances[e.to] {
+					continue
+				}
+				distances[e.to] = distance + e.weight
+				if item, queued := items[e.to]; queued {
+					q.Update(item, distances[e.to])
+				} else {
+					items[e.to] = q.Push(e.to, distances[e.to])
+				}
+			}
+		}
+		assert.Equals(t, distances, []int{0, 3, 1, 4})
+	})
+}
@@ -0,0 +1,189 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrClosed is returned when pushing to a closed Queue, or popping from a closed Queue that is empty.
+	ErrClosed = errors.New("the queue is closed")
+
+	// ErrFull is returned when TryPush is called on a Queue that is at capacity.
+	ErrFull = errors.New("the queue is full")
+)
+
+// Queue is a thread-safe FIFO with a bounded capacity. It is backed by a ring buffer.
+// Once closed, values can no longer be pushed but the remaining values can still be popped.
+type Queue[T any] struct {
+	buffer []T
+	head   int
+	size   int
+	closed bool
+	pushed chan struct{}
+	popped chan struct{}
+	lock   sync.Mutex
+}
+
+// New instantiates a Queue that holds up to capacity values.
+func New[T any](capacity int) *Queue[T] {
+	if capacity <= 0 {
+		panic("The capacity of the queue must be greater than zero.")
+	}
+	return &Queue[T]{
+		buffer: make([]T, capacity),
+		head:   0,
+		size:   0,
+		closed: false,
+		pushed: nil,
+		popped: nil,
+		lock:   sync.Mutex{},
+	}
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue[T]) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.size
+}
+
+// Cap returns the maximum number of values the queue can hold.
+func (q *Queue[T]) Cap() int {
+	return len(q.buffer)
+}
+
+// Push adds the value to the back of the queue. If the queue is full, it waits until there is
+// space or the context is done. It returns ErrClosed if the queue is closed.
+func (q *Queue[T]) Push(ctx context.Context, value T) error {
+	for {
+		q.lock.Lock()
+		if q.closed {
+			q.lock.Unlock()
+			return ErrClosed
+		}
+		if q.size < len(q.buffer) {
+			q.push(value)
+			q.lock.Unlock()
+			return nil
+		}
+		if q.popped == nil {
+			q.popped = make(chan struct{})
+		}
+		popped := q.popped
+		q.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-popped:
+		}
+	}
+}
+
+// TryPush adds the value to the back of the queue without waiting.
+// It returns ErrFull if the queue is full, and ErrClosed if the queue is closed.
+func (q *Queue[T]) TryPush(value T) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if q.size == len(q.buffer) {
+		return ErrFull
+	}
+	q.push(value)
+	return nil
+}
+
+// Pop removes the value at the front of the queue. If the queue is empty, it waits until a value
+// is pushed or the context is done. It returns ErrClosed if the queue is closed and empty.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.lock.Lock()
+		if q.size > 0 {
+			value := q.pop()
+			q.lock.Unlock()
+			return value, nil
+		}
+		if q.closed {
+			q.lock.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		if q.pushed == nil {
+			q.pushed = make(chan struct{})
+		}
+		pushed := q.pushed
+		q.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-pushed:
+		}
+	}
+}
+
+// TryPop removes the value at the front of the queue without waiting.
+// It returns false if the queue is empty.
+func (q *Queue[T]) TryPop() (T, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// Close prevents values from being pushed and wakes up the routines waiting on the queue.
+// It is safe to call Close multiple times.
+func (q *Queue[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.wakePushed()
+	q.wakePopped()
+}
+
+// push writes the value at the back of the ring buffer and wakes up the routines waiting to pop.
+// The lock must be held.
+func (q *Queue[T]) push(value T) {
+	q.buffer[(q.head+q.size)%len(q.buffer)] = value
+	q.size++
+	q.wakePushed()
+}
+
+// pop reads the value at the front of the ring buffer and wakes up the routines waiting to push.
+// The lock must be held.
+func (q *Queue[T]) pop() T {
+	var zero T
+	value := q.buffer[q.head]
+	q.buffer[q.head] = zero
+	q.head = (q.head + 1) % len(q.buffer)
+	q.size--
+	q.wakePopped()
+	return value
+}
+
+// wakePushed wakes up the routines waiting for a value to be pushed. The lock must be held.
+func (q *Queue[T]) wakePushed() {
+	if q.pushed != nil {
+		close(q.pushed)
+		q.pushed = nil
+	}
+}
+
+// wakePopped wakes up the routines waiting for a value to be popped. The lock must be held.
+func (q *Queue[T]) wakePopped() {
+	if q.popped != nil {
+		close(q.popped)
+		q.popped = nil
+	}
+}
@@ -0,0 +1,185 @@
+package queue_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/queue"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the capacity is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			queue.New[int](0)
+		}, "The capacity of the queue must be greater than zero.")
+	})
+
+	t.Run("when values are pushed and popped it should be first in first out", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](3)
+		assert.Equals(t, q.Cap(), 3)
+		for round := 0; round < 3; round++ {
+			assert.NoError(t, q.Push(context.Background(), 1))
+			assert.NoError(t, q.TryPush(2))
+			assert.NoError(t, q.Push(context.Background(), 3))
+			assert.Equals(t, q.Len(), 3)
+			value, err := q.Pop(context.Background())
+			assert.NoError(t, err)
+			assert.Equals(t, value, 1)
+			value, popped := q.TryPop()
+			assert.True(t, popped)
+			assert.Equals(t, value, 2)
+			value, err = q.Pop(context.Background())
+			assert.NoError(t, err)
+			assert.Equals(t, value, 3)
+			assert.Equals(t, q.Len(), 0)
+		}
+	})
+
+	t.Run("when the queue is full it should fail to try push", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](1)
+		assert.NoError(t, q.TryPush(1))
+		assert.ErrorExact(t, q.TryPush(2), queue.ErrFull.Error())
+	})
+
+	t.Run("when the queue is empty it should fail to try pop", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](1)
+		value, popped := q.TryPop()
+		assert.False(t, popped)
+		assert.Equals(t, value, 0)
+	})
+
+	t.Run("when the queue is full it should block push until a value is popped", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](1)
+		assert.NoError(t, q.TryPush(1))
+		pushed := make(chan error)
+		go func() {
+			pushed <- q.Push(context.Background(), 2)
+		}()
+		select {
+		case <-pushed:
+			t.Fatal("Push returned before a value was popped.")
+		case <-time.After(time.Millisecond * 10):
+		}
+		value, popped := q.TryPop()
+		assert.True(t, popped)
+		assert.Equals(t, value, 1)
+		assert.NoError(t, <-pushed)
+		value, popped = q.TryPop()
+		assert.True(t, popped)
+		assert.Equals(t, value, 2)
+	})
+
+	t.Run("when the queue is empty it should block pop until a value is pushed", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](1)
+		popped := make(chan int)
+		go func() {
+			value, err := q.Pop(context.Background())
+			assert.NoError(t, err)
+			popped <- value
+		}()
+		select {
+		case <-popped:
+			t.Fatal("Pop returned before a value was pushed.")
+		case <-time.After(time.Millisecond * 10):
+		}
+		assert.NoError(t, q.TryPush(1))
+		assert.Equals(t, <-popped, 1)
+	})
+
+	t.Run("when the context is done it should stop waiting", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](1)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		_, err := q.Pop(ctx)
+		assert.ErrorExact(t, err, context.DeadlineExceeded.Error())
+		assert.NoError(t, q.TryPush(1))
+		assert.ErrorExact(t, q.Push(ctx, 2), context.DeadlineExceeded.Error())
+	})
+
+	t.Run("when the queue is closed it should reject pushes and drain the remaining values", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](2)
+		assert.NoError(t, q.TryPush(1))
+		q.Close()
+		q.Close()
+		assert.ErrorExact(t, q.TryPush(2), queue.ErrClosed.Error())
+		assert.ErrorExact(t, q.Push(context.Background(), 2), queue.ErrClosed.Error())
+		value, err := q.Pop(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, value, 1)
+		_, err = q.Pop(context.Background())
+		assert.ErrorExact(t, err, queue.ErrClosed.Error())
+	})
+
+	t.Run("when the queue is closed it should wake up the waiting routines", func(t *testing.T) {
+		t.Parallel()
+		emptyQueue := queue.New[int](1)
+		fullQueue := queue.New[int](1)
+		assert.NoError(t, fullQueue.TryPush(1))
+		errs := make(chan error, 2)
+		go func() {
+			_, err := emptyQueue.Pop(context.Background())
+			errs <- err
+		}()
+		go func() {
+			errs <- fullQueue.Push(context.Background(), 2)
+		}()
+		time.Sleep(time.Millisecond * 10)
+		emptyQueue.Close()
+		fullQueue.Close()
+		assert.ErrorExact(t, <-errs, queue.ErrClosed.Error())
+		assert.ErrorExact(t, <-errs, queue.ErrClosed.Error())
+	})
+
+	t.Run("when many producers and consumers use the queue it should deliver every value once", func(t *testing.T) {
+		t.Parallel()
+		q := queue.New[int](4)
+		const routineCount = 4
+		const countPerRoutine = 1000
+
+		producers := sync.WaitGroup{}
+		consumers := sync.WaitGroup{}
+		sum := atomic.Int64{}
+		count := atomic.Int64{}
+		for i := 0; i < routineCount; i++ {
+			producers.Add(1)
+			go func() {
+				defer producers.Done()
+				for k := 1; k <= countPerRoutine; k++ {
+					assert.NoError(t, q.Push(context.Background(), k), assert.Continue())
+				}
+			}()
+			consumers.Add(1)
+			go func() {
+				defer consumers.Done()
+				for {
+					value, err := q.Pop(context.Background())
+					if err != nil {
+						return
+					}
+					sum.Add(int64(value))
+					count.Add(1)
+				}
+			}()
+		}
+
+		producers.Wait()
+		q.Close()
+		consumers.Wait()
+		assert.Equals(t, count.Load(), int64(routineCount*countPerRoutine))
+		assert.Equals(t, sum.Load(), int64(routineCount*countPerRoutine*(countPerRoutine+1)/2))
+	})
+}
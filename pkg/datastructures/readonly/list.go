@@ -0,0 +1,96 @@
+package readonly
+
+import (
+	"iter"
+)
+
+// List is an immutable singly linked list.
+// Lists share structure, so prepending to a List creates a new List without copying or modifying the original.
+// A nil *List is a valid empty list.
+type List[T any] struct {
+	head T
+	tail *List[T]
+	size int
+}
+
+// NewList returns a List containing the values in order.
+func NewList[T any](values ...T) *List[T] {
+	var list *List[T]
+	for i := len(values) - 1; i >= 0; i-- {
+		list = list.Cons(values[i])
+	}
+	return list
+}
+
+// Cons returns a new List with the value in front of the elements of this List.
+// The returned List shares the elements of this List.
+func (l *List[T]) Cons(value T) *List[T] {
+	return &List[T]{
+		head: value,
+		tail: l,
+		size: l.Size() + 1,
+	}
+}
+
+// Head returns the first element of the List, and false if the List is empty.
+func (l *List[T]) Head() (T, bool) {
+	if l == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head, true
+}
+
+// Tail returns the List without its first element. The tail of an empty List is empty.
+func (l *List[T]) Tail() *List[T] {
+	if l == nil {
+		return nil
+	}
+	return l.tail
+}
+
+// Size returns the number of elements in the List.
+func (l *List[T]) Size() int {
+	if l == nil {
+		return 0
+	}
+	return l.size
+}
+
+// IsEmpty returns true if the List has no elements.
+func (l *List[T]) IsEmpty() bool {
+	return l == nil
+}
+
+// Reverse returns a new List with the elements in the reverse order.
+func (l *List[T]) Reverse() *List[T] {
+	var reversed *List[T]
+	for node := l; node != nil; node = node.tail {
+		reversed = reversed.Cons(node.head)
+	}
+	return reversed
+}
+
+// All iterates over the elements of the List.
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for node := l; node != nil; node = node.tail {
+			if !yield(index, node.head) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// Values iterates over the elements of the List without their index.
+func (l *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l; node != nil; node = node.tail {
+			if !yield(node.head) {
+				return
+			}
+		}
+	}
+}
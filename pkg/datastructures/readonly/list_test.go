@@ -0,0 +1,97 @@
+package readonly_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/readonly"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestReadOnlyList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the list is nil it should be empty", func(t *testing.T) {
+		t.Parallel()
+		var list *readonly.List[int]
+		assert.True(t, list.IsEmpty())
+		assert.Equals(t, list.Size(), 0)
+		head, ok := list.Head()
+		assert.False(t, ok)
+		assert.Equals(t, head, 0)
+		assert.True(t, list.Tail().IsEmpty())
+		assert.True(t, list.Reverse().IsEmpty())
+		assert.Equals(t, len(slices.Collect(list.Values())), 0)
+	})
+
+	t.Run("when a list is created from values it should keep their order", func(t *testing.T) {
+		t.Parallel()
+		list := readonly.NewList(1, 2, 3)
+		assert.Equals(t, list.Size(), 3)
+		head, ok := list.Head()
+		assert.True(t, ok)
+		assert.Equals(t, head, 1)
+		assert.Equals(t, slices.Collect(list.Values()), []int{1, 2, 3})
+		assert.Equals(t, slices.Collect(list.Tail().Values()), []int{2, 3})
+		assert.Equals(t, slices.Collect(list.Reverse().Values()), []int{3, 2, 1})
+	})
+
+	t.Run("when a value is prepended it should not change the original list", func(t *testing.T) {
+		t.Parallel()
+		original := readonly.NewList("b", "c")
+		first := original.Cons("a")
+		second := original.Cons("z")
+		assert.Equals(t, slices.Collect(original.Values()), []string{"b", "c"})
+		assert.Equals(t, slices.Collect(first.Values()), []string{"a", "b", "c"})
+		assert.Equals(t, slices.Collect(second.Values()), []string{"z", "b", "c"})
+		assert.True(t, first.Tail() == original)
+		assert.True(t, second.Tail() == original)
+	})
+
+	t.Run("when iterating with indexes it should yield each element with its position", func(t *testing.T) {
+		t.Parallel()
+		list := readonly.NewList("a", "b", "c")
+		indexes := make([]int, 0)
+		values := make([]string, 0)
+		for i, value := range list.All() {
+			indexes = append(indexes, i)
+			values = append(values, value)
+		}
+		assert.Equals(t, indexes, []int{0, 1, 2})
+		assert.Equals(t, values, []string{"a", "b", "c"})
+	})
+
+	t.Run("when iteration is stopped early it should stop yielding", func(t *testing.T) {
+		t.Parallel()
+		list := readonly.NewList(1, 2, 3)
+		count := 0
+		for range list.All() {
+			count++
+			break
+		}
+		for range list.Values() {
+			count++
+			break
+		}
+		assert.Equals(t, count, 2)
+	})
+
+	t.Run("when a list is shared between goroutines it should be safe to extend", func(t *testing.T) {
+		t.Parallel()
+		shared := readonly.NewList(0)
+		const goroutines = 8
+		var wg sync.WaitGroup
+		for i := 1; i <= goroutines; i++ {
+			wg.Add(1)
+			go func(value int) {
+				defer wg.Done()
+				list := shared.Cons(value)
+				assert.Equals(t, list.Size(), 2, assert.Continue())
+				assert.Equals(t, slices.Collect(list.Values()), []int{value, 0}, assert.Continue())
+			}(i)
+		}
+		wg.Wait()
+		assert.Equals(t, shared.Size(), 1)
+	})
+}
@@ -1,6 +1,9 @@
 package readonly
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"iter"
 	"sync/atomic"
 )
@@ -58,6 +61,29 @@ func (r *Map[Key, Value]) Size() int {
 	return len(r.internalMap)
 }
 
+// MarshalJSON encodes the map as a JSON object.
+func (r Map[Key, Value]) MarshalJSON() ([]byte, error) {
+	if r.internalMap == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(r.internalMap)
+}
+
+// UnmarshalJSON decodes a JSON object into the zero value of a Map. This allows a Map to be used
+// in structs that are decoded, like configs and request bodies. It fails on a Map that was built,
+// since a Map must not change once it is shared.
+func (r *Map[Key, Value]) UnmarshalJSON(data []byte) error {
+	if r.internalMap != nil {
+		return errors.New("cannot unmarshal into a Map that was already built")
+	}
+	builder := NewMapBuilder[Key, Value]()
+	if err := json.Unmarshal(data, builder); err != nil {
+		return err
+	}
+	r.internalMap = builder.Build().internalMap
+	return nil
+}
+
 // MapEntry is a key-value pair for the MapBuilder.
 type MapEntry[Key comparable, Value any] struct {
 	Key   Key
@@ -102,6 +128,16 @@ func (b *MapBuilder[Key, Value]) SetMap(otherMap map[Key]Value) *MapBuilder[Key,
 	return b
 }
 
+// UnmarshalJSON adds the entries of a JSON object to the MapBuilder.
+func (b *MapBuilder[Key, Value]) UnmarshalJSON(data []byte) error {
+	var decoded map[Key]Value
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("failed to decode the map (%w)", err)
+	}
+	b.SetMap(decoded)
+	return nil
+}
+
 // Build creates a Map from the MapBuilder's entries.
 func (b *MapBuilder[Key, Value]) Build() *Map[Key, Value] {
 	if b.built.Swap(true) {
@@ -1,6 +1,7 @@
 package readonly_test
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
@@ -234,6 +235,70 @@ func TestReadOnlyMap(t *testing.T) {
 		assert.Equals(t, count, 0)
 	})
 
+	t.Run("when a map is marshaled to JSON it should be a JSON object", func(t *testing.T) {
+		t.Parallel()
+		roMap := readonly.NewMapBuilder[string, int]().SetMap(map[string]int{"a": 1, "b": 2}).Build()
+		encoded, err := json.Marshal(roMap)
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{"a":1,"b":2}`)
+
+		encoded, err = json.Marshal(&readonly.Map[string, int]{})
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{}`)
+	})
+
+	t.Run("when a map is in a struct it should round trip through JSON", func(t *testing.T) {
+		t.Parallel()
+		type response struct {
+			Labels *readonly.Map[string, string] `json:"labels"`
+			Counts readonly.Map[string, int]     `json:"counts"`
+		}
+		var decoded response
+		assert.NoError(t, json.Unmarshal([]byte(`{"labels":{"env":"prod"},"counts":{"a":1}}`), &decoded))
+		verifyMapKeyAndValue(t, decoded.Labels, "env", "prod")
+		verifyMapKeyAndValue(t, &decoded.Counts, "a", 1)
+
+		encoded, err := json.Marshal(&decoded)
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{"labels":{"env":"prod"},"counts":{"a":1}}`)
+	})
+
+	t.Run("when JSON is unmarshaled into a built map it should return an error", func(t *testing.T) {
+		t.Parallel()
+		roMap := readonly.NewMapBuilder[string, int]().Build()
+		err := json.Unmarshal([]byte(`{"a":1}`), roMap)
+		assert.ErrorExact(t, err, "cannot unmarshal into a Map that was already built")
+		assert.Equals(t, roMap.Size(), 0)
+	})
+
+	t.Run("when the JSON is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		roMap := &readonly.Map[string, int]{}
+		assert.ErrorPart(t, json.Unmarshal([]byte(`{"a":"not a number"}`), roMap), "failed to decode the map")
+		assert.Equals(t, roMap.Size(), 0)
+	})
+
+	t.Run("when JSON is unmarshaled into a builder it should add the entries", func(t *testing.T) {
+		t.Parallel()
+		builder := readonly.NewMapBuilder[string, int]().Set(readonly.MapEntry[string, int]{Key: "a", Value: 1})
+		assert.NoError(t, json.Unmarshal([]byte(`{"b":2}`), builder))
+		roMap := builder.Build()
+		verifyMapKeyAndValue(t, roMap, "a", 1)
+		verifyMapKeyAndValue(t, roMap, "b", 2)
+	})
+
+	t.Run("when a map is a non-pointer field of a struct marshaled by value it should be a JSON object", func(t *testing.T) {
+		t.Parallel()
+		type response struct {
+			Counts readonly.Map[string, int] `json:"counts"`
+			Empty  readonly.Map[string, int] `json:"empty"`
+		}
+		roMap := readonly.NewMapBuilder[string, int]().SetMap(map[string]int{"a": 1}).Build()
+		encoded, err := json.Marshal(response{Counts: *roMap})
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{"counts":{"a":1},"empty":{}}`)
+	})
+
 	t.Run("when many threads use the map it should have no issues", func(t *testing.T) {
 		t.Parallel()
 
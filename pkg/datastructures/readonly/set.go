@@ -0,0 +1,81 @@
+package readonly
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// Set provides a read-only wrapper around a set of values.
+type Set[T comparable] struct {
+	internalSet map[T]struct{}
+}
+
+// Has checks if the value is in the set.
+func (s *Set[T]) Has(value T) bool {
+	_, ok := s.internalSet[value]
+	return ok
+}
+
+// Values returns a slice of all the values in the set.
+func (s *Set[T]) Values() []T {
+	values := make([]T, 0, len(s.internalSet))
+	for value := range s.internalSet {
+		values = append(values, value)
+	}
+	return values
+}
+
+// All iterates over the values of the set.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range s.internalSet {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of values in the set.
+func (s *Set[T]) Size() int {
+	return len(s.internalSet)
+}
+
+// SetBuilder builds a Set.
+type SetBuilder[T comparable] struct {
+	built       atomic.Bool
+	internalSet map[T]struct{}
+}
+
+// NewSetBuilder returns a new SetBuilder.
+func NewSetBuilder[T comparable]() *SetBuilder[T] {
+	builder := &SetBuilder[T]{
+		built:       atomic.Bool{},
+		internalSet: make(map[T]struct{}),
+	}
+	builder.built.Store(false)
+	return builder
+}
+
+// Add adds values to the SetBuilder. Duplicate values are only stored once.
+func (b *SetBuilder[T]) Add(values ...T) *SetBuilder[T] {
+	if b.built.Load() {
+		panic("Build has already been called on this SetBuilder.")
+	}
+	for _, value := range values {
+		b.internalSet[value] = struct{}{}
+	}
+	return b
+}
+
+// Build creates a Set from the SetBuilder's values.
+func (b *SetBuilder[T]) Build() *Set[T] {
+	if b.built.Swap(true) {
+		panic("Build has already been called on this SetBuilder.")
+	}
+	internalSet := b.internalSet
+	b.internalSet = nil // This ensures the SetBuilder no longer has access to the internal set passed to Set.
+	return &Set[T]{
+		internalSet: internalSet,
+	}
+}
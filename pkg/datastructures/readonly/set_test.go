@@ -0,0 +1,99 @@
+package readonly_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/readonly"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestReadOnlySet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the SetBuilder doesn't have values it should create an empty Set", func(t *testing.T) {
+		t.Parallel()
+		roSet := readonly.NewSetBuilder[string]().Build()
+		assert.Equals(t, roSet.Size(), 0)
+		assert.Equals(t, len(roSet.Values()), 0)
+		assert.False(t, roSet.Has("value"))
+	})
+
+	t.Run("when build gets called on a SetBuilder twice it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			builder := readonly.NewSetBuilder[string]()
+			builder.Build()
+			builder.Build()
+		}, "Build has already been called on this SetBuilder.")
+	})
+
+	t.Run("when add gets called after build it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			builder := readonly.NewSetBuilder[string]()
+			builder.Build()
+			builder.Add("value")
+		}, "Build has already been called on this SetBuilder.")
+	})
+
+	t.Run("when duplicate values are added it should store them once", func(t *testing.T) {
+		t.Parallel()
+		roSet := readonly.NewSetBuilder[string]().Add("a", "b").Add("a").Build()
+		assert.Equals(t, roSet.Size(), 2)
+		assert.True(t, roSet.Has("a"))
+		assert.True(t, roSet.Has("b"))
+		assert.False(t, roSet.Has("c"))
+		values := roSet.Values()
+		slices.Sort(values)
+		assert.Equals(t, values, []string{"a", "b"})
+	})
+
+	t.Run("when iterating over the set it should yield every value until stopped", func(t *testing.T) {
+		t.Parallel()
+		roSet := readonly.NewSetBuilder[int]().Add(1, 2, 3).Build()
+		values := make([]int, 0)
+		for value := range roSet.All() {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+		assert.Equals(t, values, []int{1, 2, 3})
+
+		count := 0
+		for range roSet.All() {
+			count++
+			break
+		}
+		assert.Equals(t, count, 1)
+	})
+
+	t.Run("when many threads use the set it should have no issues", func(t *testing.T) {
+		t.Parallel()
+
+		const valueCount = 1000
+		const goRoutineCount = 4
+		wg := sync.WaitGroup{}
+		waitToStart := make(chan struct{})
+
+		builder := readonly.NewSetBuilder[int]()
+		for i := 0; i < valueCount; i++ {
+			builder.Add(i)
+		}
+		roSet := builder.Build()
+
+		for i := 0; i < goRoutineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-waitToStart
+				for k := 0; k < valueCount; k++ {
+					assert.True(t, roSet.Has(k), assert.Continue())
+				}
+			}()
+		}
+
+		close(waitToStart)
+		wg.Wait()
+	})
+}
@@ -2,6 +2,7 @@ package readonly
 
 import (
 	"iter"
+	"slices"
 	"sync/atomic"
 )
 
@@ -31,6 +32,23 @@ func (s *Slice[T]) All() iter.Seq2[int, T] {
 	}
 }
 
+// Values iterates over the elements of the slice without their index.
+func (s *Slice[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range s.internalSlice {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Contains checks if the slice has an element equal to the value.
+// It is a function because Slice allows elements that are not comparable.
+func Contains[T comparable](s *Slice[T], value T) bool {
+	return slices.Contains(s.internalSlice, value)
+}
+
 // SliceBuilder builds a Slice.
 type SliceBuilder[T any] struct {
 	built         atomic.Bool
@@ -124,6 +124,28 @@ func TestReadOnlySlice(t *testing.T) {
 		assert.Equals(t, count, 0)
 	})
 
+	t.Run("when iterating over the values it should yield them in order", func(t *testing.T) {
+		t.Parallel()
+		roSlice := readonly.NewSliceBuilder[string]().Append("a", "b", "c").Build()
+		values := make([]string, 0)
+		for value := range roSlice.Values() {
+			values = append(values, value)
+			if value == "b" {
+				break
+			}
+		}
+		assert.Equals(t, values, []string{"a", "b"})
+	})
+
+	t.Run("when checking if a slice contains a value it should compare the elements", func(t *testing.T) {
+		t.Parallel()
+		roSlice := readonly.NewSliceBuilder[string]().Append("a", "b").Build()
+		assert.True(t, readonly.Contains(roSlice, "a"))
+		assert.True(t, readonly.Contains(roSlice, "b"))
+		assert.False(t, readonly.Contains(roSlice, "c"))
+		assert.False(t, readonly.Contains(readonly.NewSliceBuilder[string]().Build(), "a"))
+	})
+
 	t.Run("when many threads use the slice it should have no issues", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,72 @@
+package set
+
+import (
+	"iter"
+	"sync"
+)
+
+// Concurrent is a Set that is safe for concurrent use.
+// Use Snapshot to get a Set on which to do set algebra.
+type Concurrent[T comparable] struct {
+	set  *Set[T]
+	lock sync.RWMutex
+}
+
+// NewConcurrent instantiates a Concurrent set with the values.
+func NewConcurrent[T comparable](values ...T) *Concurrent[T] {
+	return &Concurrent[T]{
+		set:  New(values...),
+		lock: sync.RWMutex{},
+	}
+}
+
+// Add adds the values to the set.
+func (c *Concurrent[T]) Add(values ...T) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set.Add(values...)
+}
+
+// AddIfAbsent adds the value to the set. It returns false if the value was already in the set.
+func (c *Concurrent[T]) AddIfAbsent(value T) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.set.Has(value) {
+		return false
+	}
+	c.set.Add(value)
+	return true
+}
+
+// Remove removes the values from the set.
+func (c *Concurrent[T]) Remove(values ...T) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set.Remove(values...)
+}
+
+// Has checks if the value is in the set.
+func (c *Concurrent[T]) Has(value T) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.set.Has(value)
+}
+
+// Size returns the number of values in the set.
+func (c *Concurrent[T]) Size() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.set.Size()
+}
+
+// All iterates over a snapshot of the values, so the set can be modified while iterating.
+func (c *Concurrent[T]) All() iter.Seq[T] {
+	return c.Snapshot().All()
+}
+
+// Snapshot returns a copy of the values as a Set.
+func (c *Concurrent[T]) Snapshot() *Set[T] {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.set.Clone()
+}
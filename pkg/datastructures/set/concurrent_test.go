@@ -0,0 +1,71 @@
+package set_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/set"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConcurrent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when values are added and removed it should update the set", func(t *testing.T) {
+		t.Parallel()
+		c := set.NewConcurrent(1, 2)
+		c.Add(3)
+		c.Remove(1)
+		assert.Equals(t, c.Size(), 2)
+		assert.False(t, c.Has(1))
+		assert.True(t, c.Has(3))
+		assert.True(t, c.AddIfAbsent(4))
+		assert.False(t, c.AddIfAbsent(4))
+		assert.Equals(t, sortedValues(c.Snapshot()), []int{2, 3, 4})
+	})
+
+	t.Run("when iterating it should be able to modify the set", func(t *testing.T) {
+		t.Parallel()
+		c := set.NewConcurrent(1, 2, 3)
+		for value := range c.All() {
+			c.Remove(value)
+		}
+		assert.Equals(t, c.Size(), 0)
+	})
+
+	t.Run("when a snapshot is modified it should not change the set", func(t *testing.T) {
+		t.Parallel()
+		c := set.NewConcurrent(1)
+		snapshot := c.Snapshot()
+		snapshot.Add(2)
+		assert.Equals(t, c.Size(), 1)
+	})
+
+	t.Run("when many routines add the same values it should add each value once", func(t *testing.T) {
+		t.Parallel()
+		c := set.NewConcurrent[int]()
+		const routineCount = 4
+		const valueCount = 1000
+		added := atomic.Int32{}
+		wg := sync.WaitGroup{}
+		waitToStart := make(chan struct{})
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-waitToStart
+				for k := 0; k < valueCount; k++ {
+					if c.AddIfAbsent(k) {
+						added.Add(1)
+					}
+					c.Has(k)
+				}
+			}()
+		}
+		close(waitToStart)
+		wg.Wait()
+		assert.Equals(t, int(added.Load()), valueCount)
+		assert.Equals(t, c.Size(), valueCount)
+	})
+}
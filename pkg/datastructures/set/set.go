@@ -0,0 +1,136 @@
+package set
+
+import (
+	"iter"
+)
+
+// Set is an unordered collection of unique values. It is not safe for concurrent use, see Concurrent.
+type Set[T comparable] struct {
+	values map[T]struct{}
+}
+
+// New instantiates a Set with the values.
+func New[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{
+		values: make(map[T]struct{}, len(values)),
+	}
+	s.Add(values...)
+	return s
+}
+
+// Add adds the values to the set.
+func (s *Set[T]) Add(values ...T) {
+	for _, value := range values {
+		s.values[value] = struct{}{}
+	}
+}
+
+// Remove removes the values from the set.
+func (s *Set[T]) Remove(values ...T) {
+	for _, value := range values {
+		delete(s.values, value)
+	}
+}
+
+// Has checks if the value is in the set.
+func (s *Set[T]) Has(value T) bool {
+	_, found := s.values[value]
+	return found
+}
+
+// Size returns the number of values in the set.
+func (s *Set[T]) Size() int {
+	return len(s.values)
+}
+
+// Values returns a slice of all the values in the set.
+func (s *Set[T]) Values() []T {
+	values := make([]T, 0, len(s.values))
+	for value := range s.values {
+		values = append(values, value)
+	}
+	return values
+}
+
+// All iterates over the values of the set.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range s.values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set.
+func (s *Set[T]) Clone() *Set[T] {
+	clone := &Set[T]{
+		values: make(map[T]struct{}, len(s.values)),
+	}
+	for value := range s.values {
+		clone.values[value] = struct{}{}
+	}
+	return clone
+}
+
+// Equal checks if both sets have the same values.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	if len(s.values) != len(other.values) {
+		return false
+	}
+	for value := range s.values {
+		if !other.Has(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubsetOf checks if all the values of the set are in the other set.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	if len(s.values) > len(other.values) {
+		return false
+	}
+	for value := range s.values {
+		if !other.Has(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set with the values that are in either set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	union := s.Clone()
+	for value := range other.values {
+		union.values[value] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new set with the values that are in both sets.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	smaller, larger := s, other
+	if len(smaller.values) > len(larger.values) {
+		smaller, larger = larger, smaller
+	}
+	intersection := New[T]()
+	for value := range smaller.values {
+		if larger.Has(value) {
+			intersection.values[value] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set with the values that are in this set but not in the other set.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	difference := New[T]()
+	for value := range s.values {
+		if !other.Has(value) {
+			difference.values[value] = struct{}{}
+		}
+	}
+	return difference
+}
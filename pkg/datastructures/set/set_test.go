@@ -0,0 +1,87 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/set"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func sortedValues(s *set.Set[int]) []int {
+	values := s.Values()
+	slices.Sort(values)
+	return values
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a set is created with values it should hold each value once", func(t *testing.T) {
+		t.Parallel()
+		s := set.New(1, 2, 2, 3)
+		assert.Equals(t, s.Size(), 3)
+		assert.True(t, s.Has(1))
+		assert.False(t, s.Has(4))
+		assert.Equals(t, sortedValues(s), []int{1, 2, 3})
+		assert.Equals(t, set.New[int]().Size(), 0)
+	})
+
+	t.Run("when values are added and removed it should update the set", func(t *testing.T) {
+		t.Parallel()
+		s := set.New[int]()
+		s.Add(1, 2, 3)
+		s.Remove(2, 4)
+		assert.Equals(t, sortedValues(s), []int{1, 3})
+	})
+
+	t.Run("when iterating over the set it should yield every value until stopped", func(t *testing.T) {
+		t.Parallel()
+		s := set.New(1, 2, 3)
+		values := make([]int, 0)
+		for value := range s.All() {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+		assert.Equals(t, values, []int{1, 2, 3})
+		count := 0
+		for range s.All() {
+			count++
+			break
+		}
+		assert.Equals(t, count, 1)
+	})
+
+	t.Run("when a set is cloned it should not share its values", func(t *testing.T) {
+		t.Parallel()
+		s := set.New(1, 2)
+		clone := s.Clone()
+		clone.Add(3)
+		assert.Equals(t, sortedValues(s), []int{1, 2})
+		assert.Equals(t, sortedValues(clone), []int{1, 2, 3})
+	})
+
+	t.Run("when comparing sets it should check their values", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, set.New(1, 2).Equal(set.New(2, 1)))
+		assert.False(t, set.New(1, 2).Equal(set.New(1, 3)))
+		assert.False(t, set.New(1, 2).Equal(set.New(1)))
+		assert.True(t, set.New(1).IsSubsetOf(set.New(1, 2)))
+		assert.True(t, set.New[int]().IsSubsetOf(set.New(1)))
+		assert.False(t, set.New(1, 3).IsSubsetOf(set.New(1, 2)))
+		assert.False(t, set.New(1, 2, 3).IsSubsetOf(set.New(1, 2)))
+	})
+
+	t.Run("when doing set algebra it should return new sets", func(t *testing.T) {
+		t.Parallel()
+		a := set.New(1, 2, 3)
+		b := set.New(2, 3, 4, 5)
+		assert.Equals(t, sortedValues(a.Union(b)), []int{1, 2, 3, 4, 5})
+		assert.Equals(t, sortedValues(a.Intersection(b)), []int{2, 3})
+		assert.Equals(t, sortedValues(b.Intersection(a)), []int{2, 3})
+		assert.Equals(t, sortedValues(a.Difference(b)), []int{1})
+		assert.Equals(t, sortedValues(b.Difference(a)), []int{4, 5})
+		assert.Equals(t, sortedValues(a), []int{1, 2, 3})
+		assert.Equals(t, sortedValues(b), []int{2, 3, 4, 5})
+	})
+}
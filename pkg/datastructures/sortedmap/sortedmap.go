@@ -0,0 +1,281 @@
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+const (
+	// maxLevel is the maximum number of levels of the skip list. It supports 4^32 entries efficiently.
+	maxLevel = 32
+
+	// levelProbability is the probability of a node being promoted to the next level.
+	levelProbability = 0.25
+)
+
+// node is an entry of the skip list. It has a forward pointer for each of its levels.
+type node[Key any, Value any] struct {
+	key     Key
+	value   Value
+	forward []*node[Key, Value]
+}
+
+// Map keeps its entries ordered by key. It is backed by a skip list, so lookups, insertions,
+// and deletions are O(log n) on average. It is safe for concurrent use.
+type Map[Key any, Value any] struct {
+	compare func(a Key, b Key) int
+	head    *node[Key, Value]
+	level   int
+	size    int
+	lock    sync.RWMutex
+}
+
+// New instantiates a Map ordered by the natural order of the keys.
+func New[Key cmp.Ordered, Value any]() *Map[Key, Value] {
+	return NewFunc[Key, Value](cmp.Compare[Key])
+}
+
+// NewFunc instantiates a Map ordered by the compare function.
+// The function must return a negative number if a < b, zero if a == b, and a positive number if a > b.
+func NewFunc[Key any, Value any](compare func(a Key, b Key) int) *Map[Key, Value] {
+	if compare == nil {
+		panic("The compare function cannot be nil.")
+	}
+	return &Map[Key, Value]{
+		compare: compare,
+		head: &node[Key, Value]{
+			forward: make([]*node[Key, Value], maxLevel),
+		},
+		level: 1,
+		size:  0,
+		lock:  sync.RWMutex{},
+	}
+}
+
+// Len returns the number of entries in the map.
+func (m *Map[Key, Value]) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.size
+}
+
+// Set sets the value of the key, replacing the existing value if there is one.
+func (m *Map[Key, Value]) Set(key Key, value Value) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	update := make([]*node[Key, Value], maxLevel)
+	current := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for current.forward[level] != nil && m.compare(current.forward[level].key, key) < 0 {
+			current = current.forward[level]
+		}
+		update[level] = current
+	}
+
+	if next := current.forward[0]; next != nil && m.compare(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+
+	newLevel := randomLevel()
+	if newLevel > m.level {
+		for level := m.level; level < newLevel; level++ {
+			update[level] = m.head
+		}
+		m.level = newLevel
+	}
+
+	newNode := &node[Key, Value]{
+		key:     key,
+		value:   value,
+		forward: make([]*node[Key, Value], newLevel),
+	}
+	for level := 0; level < newLevel; level++ {
+		newNode.forward[level] = update[level].forward[level]
+		update[level].forward[level] = newNode
+	}
+	m.size++
+}
+
+// Get returns the value of the key if it is in the map.
+func (m *Map[Key, Value]) Get(key Key) (Value, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if found := m.ceiling(key); found != nil && m.compare(found.key, key) == 0 {
+		return found.value, true
+	}
+	var zero Value
+	return zero, false
+}
+
+// Delete removes the key from the map. It returns false if the key was not in the map.
+func (m *Map[Key, Value]) Delete(key Key) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	update := make([]*node[Key, Value], maxLevel)
+	current := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for current.forward[level] != nil && m.compare(current.forward[level].key, key) < 0 {
+			current = current.forward[level]
+		}
+		update[level] = current
+	}
+
+	target := current.forward[0]
+	if target == nil || m.compare(target.key, key) != 0 {
+		return false
+	}
+	for level := 0; level < len(target.forward); level++ {
+		update[level].forward[level] = target.forward[level]
+	}
+	for m.level > 1 && m.head.forward[m.level-1] == nil {
+		m.level--
+	}
+	m.size--
+	return true
+}
+
+// Min returns the entry with the smallest key.
+func (m *Map[Key, Value]) Min() (Key, Value, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return entryOf(m.head.forward[0])
+}
+
+// Max returns the entry with the largest key.
+func (m *Map[Key, Value]) Max() (Key, Value, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	current := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for current.forward[level] != nil {
+			current = current.forward[level]
+		}
+	}
+	if current == m.head {
+		return entryOf[Key, Value](nil)
+	}
+	return entryOf(current)
+}
+
+// Floor returns the entry with the largest key that is less than or equal to the key.
+func (m *Map[Key, Value]) Floor(key Key) (Key, Value, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	current := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for current.forward[level] != nil && m.compare(current.forward[level].key, key) <= 0 {
+			current = current.forward[level]
+		}
+	}
+	if current == m.head {
+		return entryOf[Key, Value](nil)
+	}
+	return entryOf(current)
+}
+
+// Ceiling returns the entry with the smallest key that is greater than or equal to the key.
+func (m *Map[Key, Value]) Ceiling(key Key) (Key, Value, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return entryOf(m.ceiling(key))
+}
+
+// All iterates over all the entries in ascending order of the keys.
+// The entries are collected before iterating, so the map can be modified while iterating.
+func (m *Map[Key, Value]) All() iter.Seq2[Key, Value] {
+	m.lock.RLock()
+	keys, values := m.collect(m.head.forward[0], nil)
+	m.lock.RUnlock()
+	return iterate(keys, values)
+}
+
+// Ascend iterates in ascending order over the entries with keys in [lower, upper).
+// The entries are collected before iterating, so the map can be modified while iterating.
+func (m *Map[Key, Value]) Ascend(lower Key, upper Key) iter.Seq2[Key, Value] {
+	m.lock.RLock()
+	keys, values := m.collect(m.ceiling(lower), func(key Key) bool {
+		return m.compare(key, upper) < 0
+	})
+	m.lock.RUnlock()
+	return iterate(keys, values)
+}
+
+// Descend iterates in descending order over the entries with keys in (lower, upper].
+// The entries are collected before iterating, so the map can be modified while iterating.
+func (m *Map[Key, Value]) Descend(upper Key, lower Key) iter.Seq2[Key, Value] {
+	m.lock.RLock()
+	start := m.ceiling(lower)
+	if start != nil && m.compare(start.key, lower) == 0 {
+		start = start.forward[0]
+	}
+	keys, values := m.collect(start, func(key Key) bool {
+		return m.compare(key, upper) <= 0
+	})
+	m.lock.RUnlock()
+	slices.Reverse(keys)
+	slices.Reverse(values)
+	return iterate(keys, values)
+}
+
+// ceiling returns the first node with a key that is greater than or equal to the key, or nil if there is none.
+// The lock must be held.
+func (m *Map[Key, Value]) ceiling(key Key) *node[Key, Value] {
+	current := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for current.forward[level] != nil && m.compare(current.forward[level].key, key) < 0 {
+			current = current.forward[level]
+		}
+	}
+	return current.forward[0]
+}
+
+// collect returns the keys and values from the start node while the keys are within the bound.
+// A nil bound collects until the end of the list. The lock must be held.
+func (m *Map[Key, Value]) collect(start *node[Key, Value], withinBound func(Key) bool) ([]Key, []Value) {
+	keys := make([]Key, 0)
+	values := make([]Value, 0)
+	for current := start; current != nil; current = current.forward[0] {
+		if withinBound != nil && !withinBound(current.key) {
+			break
+		}
+		keys = append(keys, current.key)
+		values = append(values, current.value)
+	}
+	return keys, values
+}
+
+// iterate yields the keys and values in order.
+func iterate[Key any, Value any](keys []Key, values []Value) iter.Seq2[Key, Value] {
+	return func(yield func(Key, Value) bool) {
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// entryOf returns the key and value of the node, or false if the node is nil.
+func entryOf[Key any, Value any](found *node[Key, Value]) (Key, Value, bool) {
+	if found == nil {
+		var zeroKey Key
+		var zeroValue Value
+		return zeroKey, zeroValue, false
+	}
+	return found.key, found.value, true
+}
+
+// randomLevel returns the level of a new node. Each level is reached with a probability of levelProbability.
+func randomLevel() int {
+	level := 1
+	for level < maxLevel && rand.Float64() < levelProbability {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,205 @@
+package sortedmap_test
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/sortedmap"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func keysOf[Key any, Value any](seq iter.Seq2[Key, Value]) []Key {
+	keys := make([]Key, 0)
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestSortedMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the compare function is nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			sortedmap.NewFunc[string, int](nil)
+		}, "The compare function cannot be nil.")
+	})
+
+	t.Run("when the map is empty it should not find any entries", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, string]()
+		assert.Equals(t, m.Len(), 0)
+		_, found := m.Get(1)
+		assert.False(t, found)
+		_, _, found = m.Min()
+		assert.False(t, found)
+		_, _, found = m.Max()
+		assert.False(t, found)
+		_, _, found = m.Floor(1)
+		assert.False(t, found)
+		_, _, found = m.Ceiling(1)
+		assert.False(t, found)
+		assert.False(t, m.Delete(1))
+		assert.Equals(t, keysOf(m.All()), []int{})
+	})
+
+	t.Run("when entries are set it should keep them ordered by key", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, string]()
+		m.Set(30, "thirty")
+		m.Set(10, "ten")
+		m.Set(20, "twenty")
+		m.Set(10, "TEN")
+		assert.Equals(t, m.Len(), 3)
+		assert.Equals(t, keysOf(m.All()), []int{10, 20, 30})
+		value, found := m.Get(10)
+		assert.True(t, found)
+		assert.Equals(t, value, "TEN")
+
+		key, value, found := m.Min()
+		assert.True(t, found)
+		assert.Equals(t, key, 10)
+		assert.Equals(t, value, "TEN")
+		key, value, found = m.Max()
+		assert.True(t, found)
+		assert.Equals(t, key, 30)
+		assert.Equals(t, value, "thirty")
+	})
+
+	t.Run("when looking up the floor and ceiling it should find the closest keys", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, int]()
+		for _, key := range []int{10, 20, 30} {
+			m.Set(key, key)
+		}
+		key, _, found := m.Floor(25)
+		assert.True(t, found)
+		assert.Equals(t, key, 20)
+		key, _, found = m.Floor(20)
+		assert.True(t, found)
+		assert.Equals(t, key, 20)
+		_, _, found = m.Floor(5)
+		assert.False(t, found)
+
+		key, _, found = m.Ceiling(25)
+		assert.True(t, found)
+		assert.Equals(t, key, 30)
+		key, _, found = m.Ceiling(20)
+		assert.True(t, found)
+		assert.Equals(t, key, 20)
+		_, _, found = m.Ceiling(35)
+		assert.False(t, found)
+	})
+
+	t.Run("when iterating over a range it should respect the bounds", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, int]()
+		for key := 0; key < 10; key++ {
+			m.Set(key*10, key)
+		}
+		assert.Equals(t, keysOf(m.Ascend(20, 50)), []int{20, 30, 40})
+		assert.Equals(t, keysOf(m.Ascend(15, 45)), []int{20, 30, 40})
+		assert.Equals(t, keysOf(m.Ascend(50, 20)), []int{})
+		assert.Equals(t, keysOf(m.Descend(50, 20)), []int{50, 40, 30})
+		assert.Equals(t, keysOf(m.Descend(45, 15)), []int{40, 30, 20})
+		assert.Equals(t, keysOf(m.Descend(20, 50)), []int{})
+
+		count := 0
+		for range m.Ascend(0, 100) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equals(t, count, 2)
+	})
+
+	t.Run("when a custom compare function is used it should order by it", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.NewFunc[string, int](func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		m.Set("b", 1)
+		m.Set("A", 2)
+		m.Set("B", 3)
+		assert.Equals(t, keysOf(m.All()), []string{"A", "b"})
+		value, found := m.Get("a")
+		assert.True(t, found)
+		assert.Equals(t, value, 2)
+		value, _ = m.Get("b")
+		assert.Equals(t, value, 3)
+	})
+
+	t.Run("when random operations are performed it should match a sorted reference", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, int]()
+		reference := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			key := rand.IntN(500)
+			if rand.IntN(3) == 0 {
+				_, inReference := reference[key]
+				assert.Equals(t, m.Delete(key), inReference)
+				delete(reference, key)
+			} else {
+				m.Set(key, i)
+				reference[key] = i
+			}
+		}
+		expectedKeys := make([]int, 0, len(reference))
+		for key := range reference {
+			expectedKeys = append(expectedKeys, key)
+		}
+		slices.Sort(expectedKeys)
+		assert.Equals(t, m.Len(), len(reference))
+		assert.Equals(t, keysOf(m.All()), expectedKeys)
+		for key, value := range reference {
+			gotten, found := m.Get(key)
+			assert.True(t, found)
+			assert.Equals(t, gotten, value)
+		}
+	})
+
+	t.Run("when iterating it should be able to modify the map", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, int]()
+		m.Set(1, 1)
+		m.Set(2, 2)
+		for key := range m.All() {
+			assert.True(t, m.Delete(key))
+		}
+		assert.Equals(t, m.Len(), 0)
+	})
+
+	t.Run("when many routines use the map it should have no issues", func(t *testing.T) {
+		t.Parallel()
+		m := sortedmap.New[int, int]()
+		const routineCount = 4
+		const countPerRoutine = 1000
+		wg := sync.WaitGroup{}
+		waitToStart := make(chan struct{})
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-waitToStart
+				for k := 0; k < countPerRoutine; k++ {
+					key := i*countPerRoutine + k
+					m.Set(key, k)
+					m.Floor(key)
+					m.Ceiling(key)
+					for range m.Ascend(key-5, key+5) {
+					}
+				}
+			}()
+		}
+		close(waitToStart)
+		wg.Wait()
+		assert.Equals(t, m.Len(), routineCount*countPerRoutine)
+		assert.True(t, slices.IsSorted(keysOf(m.All())))
+	})
+}
@@ -0,0 +1,190 @@
+package trie
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// node is a vertex of the trie. Each edge is a byte of the key.
+type node[Value any] struct {
+	children map[byte]*node[Value]
+	value    Value
+	hasValue bool
+}
+
+// newNode allocates an empty node.
+func newNode[Value any]() *node[Value] {
+	return &node[Value]{
+		children: make(map[byte]*node[Value]),
+		hasValue: false,
+	}
+}
+
+// Trie is a prefix tree that maps string keys to values. Keys that share a prefix share the nodes
+// of that prefix, which makes prefix lookups proportional to the length of the key instead of the
+// number of keys. It is safe for concurrent use.
+type Trie[Value any] struct {
+	root *node[Value]
+	size int
+	lock sync.RWMutex
+}
+
+// New instantiates an empty Trie.
+func New[Value any]() *Trie[Value] {
+	return &Trie[Value]{
+		root: newNode[Value](),
+		size: 0,
+		lock: sync.RWMutex{},
+	}
+}
+
+// Size returns the number of keys in the trie.
+func (t *Trie[Value]) Size() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.size
+}
+
+// Insert sets the value of the key, replacing the existing value if there is one.
+func (t *Trie[Value]) Insert(key string, value Value) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	current := t.root
+	for i := 0; i < len(key); i++ {
+		child, found := current.children[key[i]]
+		if !found {
+			child = newNode[Value]()
+			current.children[key[i]] = child
+		}
+		current = child
+	}
+	if !current.hasValue {
+		t.size++
+	}
+	current.value = value
+	current.hasValue = true
+}
+
+// Get returns the value of the key if it is in the trie.
+func (t *Trie[Value]) Get(key string) (Value, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	found := t.find(key)
+	if found == nil || !found.hasValue {
+		var zero Value
+		return zero, false
+	}
+	return found.value, true
+}
+
+// Delete removes the key from the trie and prunes the nodes that are no longer needed.
+// It returns false if the key was not in the trie.
+func (t *Trie[Value]) Delete(key string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	path := make([]*node[Value], 0, len(key)+1)
+	current := t.root
+	path = append(path, current)
+	for i := 0; i < len(key); i++ {
+		child, found := current.children[key[i]]
+		if !found {
+			return false
+		}
+		current = child
+		path = append(path, current)
+	}
+	if !current.hasValue {
+		return false
+	}
+	var zero Value
+	current.value = zero
+	current.hasValue = false
+	t.size--
+	for i := len(key); i > 0; i-- {
+		if path[i].hasValue || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key in the trie that is a prefix of the provided key, and its value.
+// For example, with the keys "/api" and "/api/users", the longest prefix of "/api/users/1" is "/api/users".
+func (t *Trie[Value]) LongestPrefix(key string) (string, Value, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var longestValue Value
+	longestLength := -1
+	current := t.root
+	if current.hasValue {
+		longestValue = current.value
+		longestLength = 0
+	}
+	for i := 0; i < len(key); i++ {
+		child, found := current.children[key[i]]
+		if !found {
+			break
+		}
+		current = child
+		if current.hasValue {
+			longestValue = current.value
+			longestLength = i + 1
+		}
+	}
+	if longestLength < 0 {
+		var zero Value
+		return "", zero, false
+	}
+	return key[:longestLength], longestValue, true
+}
+
+// WithPrefix iterates over the keys that start with the prefix, and their values, in lexicographical order.
+// The matches are collected before iterating, so the trie can be modified while iterating.
+func (t *Trie[Value]) WithPrefix(prefix string) iter.Seq2[string, Value] {
+	t.lock.RLock()
+	keys := make([]string, 0)
+	values := make([]Value, 0)
+	if start := t.find(prefix); start != nil {
+		collect(start, []byte(prefix), &keys, &values)
+	}
+	t.lock.RUnlock()
+
+	return func(yield func(string, Value) bool) {
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// find returns the node at the end of the key, or nil if there is none.
+func (t *Trie[Value]) find(key string) *node[Value] {
+	current := t.root
+	for i := 0; i < len(key); i++ {
+		child, found := current.children[key[i]]
+		if !found {
+			return nil
+		}
+		current = child
+	}
+	return current
+}
+
+// collect appends the keys and values under the node in lexicographical order.
+func collect[Value any](current *node[Value], key []byte, keys *[]string, values *[]Value) {
+	if current.hasValue {
+		*keys = append(*keys, string(key))
+		*values = append(*values, current.value)
+	}
+	edges := make([]byte, 0, len(current.children))
+	for edge := range current.children {
+		edges = append(edges, edge)
+	}
+	slices.Sort(edges)
+	for _, edge := range edges {
+		collect(current.children[edge], append(key, edge), keys, values)
+	}
+}
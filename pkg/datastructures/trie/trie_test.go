@@ -0,0 +1,175 @@
+package trie_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/trie"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func collectPrefix[Value any](tr *trie.Trie[Value], prefix string) []string {
+	keys := make([]string, 0)
+	for key := range tr.WithPrefix(prefix) {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestTrie(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the trie is empty it should not find any keys", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		assert.Equals(t, tr.Size(), 0)
+		_, found := tr.Get("")
+		assert.False(t, found)
+		_, _, found = tr.LongestPrefix("key")
+		assert.False(t, found)
+		assert.Equals(t, collectPrefix(tr, ""), []string{})
+		assert.False(t, tr.Delete("key"))
+	})
+
+	t.Run("when keys are inserted it should get them by exact match", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		tr.Insert("tea", 1)
+		tr.Insert("team", 2)
+		tr.Insert("", 3)
+		assert.Equals(t, tr.Size(), 3)
+		value, found := tr.Get("tea")
+		assert.True(t, found)
+		assert.Equals(t, value, 1)
+		value, found = tr.Get("team")
+		assert.True(t, found)
+		assert.Equals(t, value, 2)
+		value, found = tr.Get("")
+		assert.True(t, found)
+		assert.Equals(t, value, 3)
+		_, found = tr.Get("te")
+		assert.False(t, found)
+		_, found = tr.Get("teams")
+		assert.False(t, found)
+	})
+
+	t.Run("when a key is inserted twice it should replace the value", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[string]()
+		tr.Insert("key", "first")
+		tr.Insert("key", "second")
+		assert.Equals(t, tr.Size(), 1)
+		value, found := tr.Get("key")
+		assert.True(t, found)
+		assert.Equals(t, value, "second")
+	})
+
+	t.Run("when looking up the longest prefix it should return the longest key that prefixes the input", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[string]()
+		tr.Insert("/api", "api")
+		tr.Insert("/api/users", "users")
+		tr.Insert("/api/users/admin", "admin")
+
+		prefix, value, found := tr.LongestPrefix("/api/users/1")
+		assert.True(t, found)
+		assert.Equals(t, prefix, "/api/users")
+		assert.Equals(t, value, "users")
+
+		prefix, value, found = tr.LongestPrefix("/api/other")
+		assert.True(t, found)
+		assert.Equals(t, prefix, "/api")
+		assert.Equals(t, value, "api")
+
+		_, _, found = tr.LongestPrefix("/ap")
+		assert.False(t, found)
+
+		tr.Insert("", "root")
+		prefix, value, found = tr.LongestPrefix("/other")
+		assert.True(t, found)
+		assert.Equals(t, prefix, "")
+		assert.Equals(t, value, "root")
+	})
+
+	t.Run("when iterating by prefix it should yield the matching keys in order", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		for i, key := range []string{"car", "cat", "cart", "dog", "ca", "c"} {
+			tr.Insert(key, i)
+		}
+		assert.Equals(t, collectPrefix(tr, "ca"), []string{"ca", "car", "cart", "cat"})
+		assert.Equals(t, collectPrefix(tr, ""), []string{"c", "ca", "car", "cart", "cat", "dog"})
+		assert.Equals(t, collectPrefix(tr, "cart"), []string{"cart"})
+		assert.Equals(t, collectPrefix(tr, "x"), []string{})
+
+		values := make([]int, 0)
+		for _, value := range tr.WithPrefix("car") {
+			values = append(values, value)
+			break
+		}
+		assert.Equals(t, values, []int{0})
+	})
+
+	t.Run("when iterating it should be able to modify the trie", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		tr.Insert("a", 1)
+		tr.Insert("ab", 2)
+		for key := range tr.WithPrefix("a") {
+			assert.True(t, tr.Delete(key))
+		}
+		assert.Equals(t, tr.Size(), 0)
+	})
+
+	t.Run("when keys are deleted it should remove only those keys", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		tr.Insert("tea", 1)
+		tr.Insert("team", 2)
+		tr.Insert("ten", 3)
+
+		assert.False(t, tr.Delete("te"))
+		assert.True(t, tr.Delete("team"))
+		assert.False(t, tr.Delete("team"))
+		assert.Equals(t, tr.Size(), 2)
+		_, found := tr.Get("team")
+		assert.False(t, found)
+		value, found := tr.Get("tea")
+		assert.True(t, found)
+		assert.Equals(t, value, 1)
+
+		assert.True(t, tr.Delete("tea"))
+		assert.Equals(t, collectPrefix(tr, "te"), []string{"ten"})
+		_, _, found = tr.LongestPrefix("teapot")
+		assert.False(t, found)
+	})
+
+	t.Run("when many routines use the trie it should have no issues", func(t *testing.T) {
+		t.Parallel()
+		tr := trie.New[int]()
+		const routineCount = 4
+		const keyCount = 500
+		wg := sync.WaitGroup{}
+		waitToStart := make(chan struct{})
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-waitToStart
+				for k := 0; k < keyCount; k++ {
+					key := string(rune('a'+i)) + string(rune('a'+k%26)) + string(rune('a'+k/26))
+					tr.Insert(key, k)
+					value, found := tr.Get(key)
+					assert.True(t, found, assert.Continue())
+					assert.Equals(t, value, k, assert.Continue())
+					tr.LongestPrefix(key + "suffix")
+					for range tr.WithPrefix(key[:1]) {
+					}
+				}
+			}()
+		}
+		close(waitToStart)
+		wg.Wait()
+		assert.Equals(t, tr.Size(), routineCount*keyCount)
+	})
+}
@@ -0,0 +1,50 @@
+package errors
+
+// Code is a stable identifier of a kind of error. Unlike the messages, the codes can be relied on by callers.
+// A Code is also an error, so it can be used as the target of Is.
+//
+//	if errors.Is(err, errors.CodeNotFound) { ... }
+type Code string
+
+const (
+	// CodeUnknown is returned by CodeOf when the error has no code.
+	CodeUnknown Code = "UNKNOWN"
+
+	// CodeInvalidArgument means the input of the caller is not valid.
+	CodeInvalidArgument Code = "INVALID_ARGUMENT"
+
+	// CodeNotFound means a requested entity does not exist.
+	CodeNotFound Code = "NOT_FOUND"
+
+	// CodeAlreadyExists means an entity that the caller tried to create already exists.
+	CodeAlreadyExists Code = "ALREADY_EXISTS"
+
+	// CodeFailedPrecondition means the system is not in the state required by the operation.
+	CodeFailedPrecondition Code = "FAILED_PRECONDITION"
+
+	// CodeUnauthenticated means the caller could not be identified.
+	CodeUnauthenticated Code = "UNAUTHENTICATED"
+
+	// CodePermissionDenied means the caller is not allowed to perform the operation.
+	CodePermissionDenied Code = "PERMISSION_DENIED"
+
+	// CodeResourceExhausted means a quota or rate limit was reached.
+	CodeResourceExhausted Code = "RESOURCE_EXHAUSTED"
+
+	// CodeDeadlineExceeded means the operation did not finish in time.
+	CodeDeadlineExceeded Code = "DEADLINE_EXCEEDED"
+
+	// CodeUnavailable means a dependency is temporarily unavailable and the operation can be retried.
+	CodeUnavailable Code = "UNAVAILABLE"
+
+	// CodeUnimplemented means the operation is not supported.
+	CodeUnimplemented Code = "UNIMPLEMENTED"
+
+	// CodeInternal means an unexpected failure that should not be shown to the caller.
+	CodeInternal Code = "INTERNAL"
+)
+
+// Error returns the code as a string.
+func (c Code) Error() string {
+	return string(c)
+}
@@ -0,0 +1,25 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/errors"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a code is used as an error it should return the code as its message", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorExact(t, errors.CodeNotFound, "NOT_FOUND")
+	})
+
+	t.Run("when a code is the target of Is it should only match errors with the same code", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New(errors.CodeNotFound, "the user was not found")
+		assert.True(t, errors.Is(err, errors.CodeNotFound))
+		assert.False(t, errors.Is(err, errors.CodeInternal))
+		assert.False(t, errors.Is(errors.Join(), errors.CodeNotFound))
+	})
+}
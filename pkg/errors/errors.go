@@ -0,0 +1,196 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"runtime"
+)
+
+const (
+	// maxStackDepth is the maximum number of frames captured by WithStack.
+	maxStackDepth = 64
+)
+
+// Error is an error with a Code, metadata, and optionally the stack where it was created.
+// It works with the standard Is, As, and Unwrap functions.
+type Error struct {
+	code     Code
+	message  string
+	cause    error
+	metadata map[string]any
+	stack    []uintptr
+}
+
+// config holds the configuration of an Error.
+type config struct {
+	metadata     map[string]any
+	captureStack bool
+}
+
+// Option configures an Error.
+type Option func(*config)
+
+// WithMetadata adds a key and value to the metadata of the Error.
+func WithMetadata(key string, value any) Option {
+	return func(cfg *config) {
+		cfg.metadata[key] = value
+	}
+}
+
+// WithStack captures the stack of the caller of New or Wrap.
+func WithStack() Option {
+	return func(cfg *config) {
+		cfg.captureStack = true
+	}
+}
+
+// newError creates an Error. It must be called directly by New or Wrap for the stack to start at their caller.
+func newError(code Code, message string, cause error, opts ...Option) *Error {
+	cfg := &config{
+		metadata:     make(map[string]any),
+		captureStack: false,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	err := &Error{
+		code:     code,
+		message:  message,
+		cause:    cause,
+		metadata: cfg.metadata,
+	}
+	if cfg.captureStack {
+		pcs := make([]uintptr, maxStackDepth)
+		err.stack = pcs[:runtime.Callers(3, pcs)]
+	}
+	return err
+}
+
+// New creates an Error with a code and a message.
+func New(code Code, message string, opts ...Option) error {
+	return newError(code, message, nil, opts...)
+}
+
+// Wrap creates an Error with a code and a message that wraps the cause. It returns nil if the cause is nil.
+func Wrap(cause error, code Code, message string, opts ...Option) error {
+	if cause == nil {
+		return nil
+	}
+	return newError(code, message, cause, opts...)
+}
+
+// Error returns the message followed by the cause in parentheses.
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.message
+	case e.message == "":
+		return e.cause.Error()
+	default:
+		return fmt.Sprintf("%s (%s)", e.message, e.cause.Error())
+	}
+}
+
+// Unwrap returns the cause of the Error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is returns true if the target is the Code of the Error.
+func (e *Error) Is(target error) bool {
+	code, isCode := target.(Code)
+	return isCode && code == e.code
+}
+
+// Code returns the code of the Error.
+func (e *Error) Code() Code {
+	return e.code
+}
+
+// Message returns the message of the Error without its cause.
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Metadata returns a copy of the metadata of the Error.
+func (e *Error) Metadata() map[string]any {
+	return maps.Clone(e.metadata)
+}
+
+// Stack returns the frames captured with WithStack, starting at the caller of New or Wrap.
+func (e *Error) Stack() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.stack)
+	stack := make([]runtime.Frame, 0, len(e.stack))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return stack
+		}
+	}
+}
+
+// CodeOf returns the code of the first Error in the chain, or CodeUnknown if there is none.
+func CodeOf(err error) Code {
+	var codedErr *Error
+	if stderrors.As(err, &codedErr) {
+		return codedErr.code
+	}
+	return CodeUnknown
+}
+
+// MetadataOf returns the metadata of all the Error values in the chain.
+// When a key is set more than once, the value closest to the top of the chain is used.
+func MetadataOf(err error) map[string]any {
+	metadata := make(map[string]any)
+	walk(err, func(codedErr *Error) {
+		for key, value := range codedErr.metadata {
+			if _, found := metadata[key]; !found {
+				metadata[key] = value
+			}
+		}
+	})
+	return metadata
+}
+
+// walk calls the function on each Error in the chain, including the errors combined with Join.
+func walk(err error, fn func(*Error)) {
+	if err == nil {
+		return
+	}
+	if codedErr, isCoded := err.(*Error); isCoded {
+		fn(codedErr)
+	}
+	switch unwrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		walk(unwrapper.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, joined := range unwrapper.Unwrap() {
+			walk(joined, fn)
+		}
+	}
+}
+
+// Is calls the standard errors.Is so this package can be used in its place.
+func Is(err error, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As calls the standard errors.As so this package can be used in its place.
+func As(err error, target any) bool {
+	return stderrors.As(err, target)
+}
+
+// Unwrap calls the standard errors.Unwrap so this package can be used in its place.
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
+
+// Join calls the standard errors.Join so this package can be used in its place.
+func Join(errs ...error) error {
+	return stderrors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package errors_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/errors"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when an error is created it should have the code, message, and metadata", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New(errors.CodeInvalidArgument, "the name is too long", errors.WithMetadata("field", "name"))
+		assert.ErrorExact(t, err, "the name is too long")
+		var codedErr *errors.Error
+		assert.True(t, errors.As(err, &codedErr))
+		assert.Equals(t, codedErr.Code(), errors.CodeInvalidArgument)
+		assert.Equals(t, codedErr.Message(), "the name is too long")
+		assert.Equals(t, codedErr.Metadata(), map[string]any{"field": "name"})
+		assert.Nil(t, codedErr.Stack())
+		assert.Nil(t, errors.Unwrap(err))
+	})
+
+	t.Run("when an error is wrapped it should keep the cause in the chain", func(t *testing.T) {
+		t.Parallel()
+		err := errors.Wrap(context.DeadlineExceeded, errors.CodeUnavailable, "failed to query the database")
+		assert.ErrorExact(t, err, "failed to query the database (context deadline exceeded)")
+		assert.True(t, stderrors.Is(err, context.DeadlineExceeded))
+		assert.True(t, stderrors.Is(err, errors.CodeUnavailable))
+		assert.Equals(t, errors.Unwrap(err), context.DeadlineExceeded)
+	})
+
+	t.Run("when an error is wrapped without a message it should use the message of the cause", func(t *testing.T) {
+		t.Parallel()
+		err := errors.Wrap(stderrors.New("the cause"), errors.CodeInternal, "")
+		assert.ErrorExact(t, err, "the cause")
+	})
+
+	t.Run("when a nil error is wrapped it should return nil", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, errors.Wrap(nil, errors.CodeInternal, "message"))
+	})
+
+	t.Run("when the stack is captured it should start at the caller", func(t *testing.T) {
+		t.Parallel()
+		var codedErr *errors.Error
+		assert.True(t, errors.As(errors.New(errors.CodeInternal, "message", errors.WithStack()), &codedErr))
+		stack := codedErr.Stack()
+		assert.True(t, len(stack) > 0)
+		assert.True(t, strings.HasSuffix(stack[0].Function, "TestErrors.func5"))
+	})
+
+	t.Run("when the code is requested it should return the code of the outermost error", func(t *testing.T) {
+		t.Parallel()
+		inner := errors.New(errors.CodeNotFound, "the row was not found")
+		outer := fmt.Errorf("failed to load the user (%w)", errors.Wrap(inner, errors.CodePermissionDenied, "access denied"))
+		assert.Equals(t, errors.CodeOf(outer), errors.CodePermissionDenied)
+		assert.Equals(t, errors.CodeOf(inner), errors.CodeNotFound)
+		assert.Equals(t, errors.CodeOf(stderrors.New("plain")), errors.CodeUnknown)
+		assert.Equals(t, errors.CodeOf(nil), errors.CodeUnknown)
+		assert.True(t, errors.Is(outer, errors.CodeNotFound))
+	})
+
+	t.Run("when the metadata is requested it should merge the chain with the outer values first", func(t *testing.T) {
+		t.Parallel()
+		inner := errors.New(errors.CodeNotFound, "not found", errors.WithMetadata("id", 1), errors.WithMetadata("table", "users"))
+		outer := errors.Wrap(inner, errors.CodeInternal, "failed", errors.WithMetadata("id", 2))
+		joined := errors.Join(outer, errors.New(errors.CodeInternal, "other", errors.WithMetadata("other", true)))
+		assert.Equals(t, errors.MetadataOf(joined), map[string]any{"id": 2, "table": "users", "other": true})
+		assert.Equals(t, errors.MetadataOf(stderrors.New("plain")), map[string]any{})
+	})
+
+	t.Run("when the metadata of an error is modified it should not change the error", func(t *testing.T) {
+		t.Parallel()
+		var codedErr *errors.Error
+		assert.True(t, errors.As(errors.New(errors.CodeInternal, "message", errors.WithMetadata("key", "value")), &codedErr))
+		codedErr.Metadata()["key"] = "changed"
+		assert.Equals(t, codedErr.Metadata(), map[string]any{"key": "value"})
+	})
+}
@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"time"
+)
+
+const (
+	// defaultBufferSize is the default number of events a subscriber can hold before Publish waits.
+	defaultBufferSize = 64
+
+	// defaultMaxAttempts is the default number of times an event is given to a handler that returns an error.
+	defaultMaxAttempts = 3
+
+	// defaultRetryDelay is the default time between the delivery attempts of an event.
+	defaultRetryDelay = time.Millisecond * 100
+)
+
+// config holds the configuration of a Subscription.
+type config struct {
+	bufferSize   int
+	maxAttempts  int
+	retryDelay   time.Duration
+	errorHandler func(error)
+}
+
+// Option configures a Subscription.
+type Option func(*config)
+
+// WithBufferSize sets the number of events the subscriber can hold before Publish waits for it.
+func WithBufferSize(size int) Option {
+	return func(cfg *config) {
+		cfg.bufferSize = size
+	}
+}
+
+// WithMaxAttempts sets the number of times an event is given to the handler until it succeeds.
+func WithMaxAttempts(attempts int) Option {
+	return func(cfg *config) {
+		cfg.maxAttempts = attempts
+	}
+}
+
+// WithRetryDelay sets the time to wait before giving a failed event to the handler again.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(cfg *config) {
+		cfg.retryDelay = delay
+	}
+}
+
+// WithErrorHandler sets a function that is called when the handler failed all the attempts of an event.
+func WithErrorHandler(handler func(error)) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = handler
+	}
+}
+
+// configure creates a config out of the provided options and validates it.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		bufferSize:   defaultBufferSize,
+		maxAttempts:  defaultMaxAttempts,
+		retryDelay:   defaultRetryDelay,
+		errorHandler: func(error) {},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.bufferSize < 0 {
+		panic("The buffer size cannot be negative.")
+	}
+	if cfg.maxAttempts <= 0 {
+		panic("The max attempts must be greater than zero.")
+	}
+	if cfg.retryDelay < 0 {
+		panic("The retry delay cannot be negative.")
+	}
+	if cfg.errorHandler == nil {
+		panic("The error handler cannot be nil.")
+	}
+	return cfg
+}
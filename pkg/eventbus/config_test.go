@@ -0,0 +1,34 @@
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/eventbus"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[string]("test")
+		handler := func(context.Context, string) error { return nil }
+		testCases := []struct {
+			option   eventbus.Option
+			panicMsg string
+		}{
+			{eventbus.WithBufferSize(-1), "The buffer size cannot be negative."},
+			{eventbus.WithMaxAttempts(0), "The max attempts must be greater than zero."},
+			{eventbus.WithRetryDelay(-1), "The retry delay cannot be negative."},
+			{eventbus.WithErrorHandler(nil), "The error handler cannot be nil."},
+		}
+		for _, testCase := range testCases {
+			assert.PanicExact(t, func() {
+				_, _ = topic.Subscribe(handler, testCase.option)
+			}, testCase.panicMsg)
+		}
+		assert.Equals(t, topic.Subscribers(), 0)
+	})
+}
@@ -0,0 +1,126 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed is returned when a Topic is used after it is closed.
+var ErrClosed = errors.New("the topic is closed")
+
+// Handler processes an event. If it returns an error, the event is given to it again.
+// The context carries the values of the context given to Publish, but it is not canceled with it.
+type Handler[T any] func(ctx context.Context, event T) error
+
+// delivery is an event queued for a subscriber.
+type delivery[T any] struct {
+	ctx   context.Context
+	event T
+}
+
+// Topic delivers the events of type T to its subscribers. Each subscriber has its own buffer and goroutine,
+// so a slow subscriber only delays the publishers once its buffer is full.
+//
+//	reloads := eventbus.NewTopic[ConfigReloaded]("config.reloaded")
+//	subscription, err := reloads.Subscribe(func(ctx context.Context, event ConfigReloaded) error {
+//		return apply(event)
+//	})
+//	err = reloads.Publish(ctx, ConfigReloaded{})
+type Topic[T any] struct {
+	name        string
+	lock        sync.RWMutex
+	subscribers map[*Subscription[T]]struct{}
+	closed      bool
+}
+
+// NewTopic creates a Topic. The name is used in the errors.
+func NewTopic[T any](name string) *Topic[T] {
+	return &Topic[T]{
+		name:        name,
+		subscribers: make(map[*Subscription[T]]struct{}),
+		closed:      false,
+	}
+}
+
+// Name returns the name of the Topic.
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribers returns the number of active subscriptions.
+func (t *Topic[T]) Subscribers() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return len(t.subscribers)
+}
+
+// Subscribe starts delivering the events published after this call to the handler.
+func (t *Topic[T]) Subscribe(handler Handler[T], opts ...Option) (*Subscription[T], error) {
+	if handler == nil {
+		panic("The handler cannot be nil.")
+	}
+	cfg := configure(opts...)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return nil, ErrClosed
+	}
+	subscription := &Subscription[T]{
+		topic:        t,
+		handler:      handler,
+		cfg:          cfg,
+		events:       make(chan delivery[T], cfg.bufferSize),
+		unsubscribed: make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	t.subscribers[subscription] = struct{}{}
+	go subscription.run()
+	return subscription, nil
+}
+
+// Publish queues the event for every subscriber. It waits for subscribers with a full buffer,
+// and returns an error if the context is done before the event is queued for all of them.
+// The topic is not locked while waiting, so a subscriber can unsubscribe in the meantime,
+// in which case the event is not queued for it. An event that is queued is always delivered.
+func (t *Topic[T]) Publish(ctx context.Context, event T) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to publish to the topic '%s' (%w)", t.name, err)
+	}
+	t.lock.RLock()
+	if t.closed {
+		t.lock.RUnlock()
+		return ErrClosed
+	}
+	subscribers := make([]*Subscription[T], 0, len(t.subscribers))
+	for subscription := range t.subscribers {
+		subscribers = append(subscribers, subscription)
+	}
+	t.lock.RUnlock()
+	queued := delivery[T]{
+		ctx:   context.WithoutCancel(ctx),
+		event: event,
+	}
+	for _, subscription := range subscribers {
+		if err := subscription.queue(ctx, queued); err != nil {
+			return fmt.Errorf("failed to publish to the topic '%s' (%w)", t.name, err)
+		}
+	}
+	return nil
+}
+
+// Close unsubscribes all the subscribers and waits for them to process their queued events.
+// The Topic cannot be used once it is closed. It must not be called from a handler of the Topic.
+func (t *Topic[T]) Close() {
+	t.lock.Lock()
+	t.closed = true
+	subscribers := make([]*Subscription[T], 0, len(t.subscribers))
+	for subscription := range t.subscribers {
+		subscribers = append(subscribers, subscription)
+	}
+	t.lock.Unlock()
+	for _, subscription := range subscribers {
+		subscription.UnsubscribeAndWait()
+	}
+}
@@ -0,0 +1,264 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/eventbus"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type contextKey struct{}
+
+type event struct {
+	ID int
+}
+
+func TestTopic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when an event is published it should be delivered to every subscriber", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		assert.Equals(t, topic.Name(), "events")
+		lock := sync.Mutex{}
+		received := map[string][]int{}
+		for _, name := range []string{"first", "second"} {
+			_, err := topic.Subscribe(func(_ context.Context, e event) error {
+				lock.Lock()
+				defer lock.Unlock()
+				received[name] = append(received[name], e.ID)
+				return nil
+			})
+			assert.NoError(t, err)
+		}
+		assert.Equals(t, topic.Subscribers(), 2)
+		for id := range 3 {
+			assert.NoError(t, topic.Publish(context.Background(), event{ID: id}))
+		}
+		topic.Close()
+		assert.Equals(t, received, map[string][]int{"first": {0, 1, 2}, "second": {0, 1, 2}})
+	})
+
+	t.Run("when an event is published it should pass the context values but not the cancellation", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		results := make(chan error, 1)
+		values := make(chan any, 1)
+		_, err := topic.Subscribe(func(ctx context.Context, _ event) error {
+			values <- ctx.Value(contextKey{})
+			results <- ctx.Err()
+			return nil
+		})
+		assert.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "value"))
+		assert.NoError(t, topic.Publish(ctx, event{}))
+		cancel()
+		topic.Close()
+		assert.Equals(t, <-values, any("value"))
+		assert.NoError(t, <-results)
+	})
+
+	t.Run("when the handler fails it should retry until it succeeds", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		var attempts atomic.Int64
+		_, err := topic.Subscribe(func(context.Context, event) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("handler error")
+			}
+			return nil
+		}, eventbus.WithMaxAttempts(5), eventbus.WithRetryDelay(0), eventbus.WithErrorHandler(func(err error) {
+			t.Errorf("Unexpected delivery error (%s).", err.Error())
+		}))
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{}))
+		topic.Close()
+		assert.Equals(t, attempts.Load(), int64(3))
+	})
+
+	t.Run("when the handler fails all the attempts it should call the error handler", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		var attempts atomic.Int64
+		var deliveryErr error
+		_, err := topic.Subscribe(func(context.Context, event) error {
+			if attempts.Add(1) == 1 {
+				panic("handler failure")
+			}
+			return errors.New("handler error")
+		}, eventbus.WithMaxAttempts(2), eventbus.WithRetryDelay(time.Millisecond), eventbus.WithErrorHandler(func(err error) {
+			deliveryErr = err
+		}))
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{}))
+		topic.Close()
+		assert.Equals(t, attempts.Load(), int64(2))
+		assert.ErrorExact(t, deliveryErr, "failed to deliver the event on the topic 'events' after 2 attempt(s) (handler error)")
+	})
+
+	t.Run("when the subscriber unsubscribes while the handler waits to retry it should retry without the delay", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		var attempts atomic.Int64
+		firstAttempt := make(chan struct{})
+		var deliveryErr error
+		subscription, err := topic.Subscribe(func(context.Context, event) error {
+			if attempts.Add(1) == 1 {
+				close(firstAttempt)
+			}
+			return errors.New("handler error")
+		}, eventbus.WithMaxAttempts(3), eventbus.WithRetryDelay(time.Hour), eventbus.WithErrorHandler(func(err error) {
+			deliveryErr = err
+		}))
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{}))
+		<-firstAttempt
+		subscription.UnsubscribeAndWait()
+		assert.Equals(t, attempts.Load(), int64(3))
+		assert.ErrorExact(t, deliveryErr, "failed to deliver the event on the topic 'events' after 3 attempt(s) (handler error)")
+	})
+
+	t.Run("when a subscriber buffer is full it should wait until the context is done", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		release := make(chan struct{})
+		_, err := topic.Subscribe(func(context.Context, event) error {
+			<-release
+			return nil
+		}, eventbus.WithBufferSize(0))
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{ID: 1}))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		err = topic.Publish(ctx, event{ID: 2})
+		assert.ErrorExact(t, err, "failed to publish to the topic 'events' (context deadline exceeded)")
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		close(release)
+		topic.Close()
+	})
+
+	t.Run("when a subscriber unsubscribes it should process its queued events and stop receiving", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		release := make(chan struct{})
+		var received atomic.Int64
+		subscription, err := topic.Subscribe(func(context.Context, event) error {
+			<-release
+			received.Add(1)
+			return nil
+		})
+		assert.NoError(t, err)
+		for range 3 {
+			assert.NoError(t, topic.Publish(context.Background(), event{}))
+		}
+		close(release)
+		subscription.UnsubscribeAndWait()
+		subscription.UnsubscribeAndWait()
+		assert.Equals(t, received.Load(), int64(3))
+		assert.Equals(t, topic.Subscribers(), 0)
+		assert.NoError(t, topic.Publish(context.Background(), event{}))
+		assert.Equals(t, received.Load(), int64(3))
+	})
+
+	t.Run("when a handler unsubscribes its own subscription it should not wait for itself", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		var received atomic.Int64
+		unsubscribed := make(chan struct{})
+		var subscription *eventbus.Subscription[event]
+		subscription, err := topic.Subscribe(func(context.Context, event) error {
+			received.Add(1)
+			subscription.Unsubscribe()
+			close(unsubscribed)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{}))
+		select {
+		case <-unsubscribed:
+		case <-time.After(time.Second * 5):
+			t.Fatal("The handler did not return from Unsubscribe.")
+		}
+		subscription.UnsubscribeAndWait()
+		assert.Equals(t, topic.Subscribers(), 0)
+		assert.Equals(t, received.Load(), int64(1))
+		topic.Close()
+	})
+
+	t.Run("when a publisher waits on a full buffer it should not block the other operations on the topic", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		release := make(chan struct{})
+		subscription, err := topic.Subscribe(func(context.Context, event) error {
+			<-release
+			return nil
+		}, eventbus.WithBufferSize(0))
+		assert.NoError(t, err)
+		assert.NoError(t, topic.Publish(context.Background(), event{ID: 1}))
+		published := make(chan error, 1)
+		go func() {
+			published <- topic.Publish(context.Background(), event{ID: 2})
+		}()
+		time.Sleep(time.Millisecond * 10)
+		other, err := topic.Subscribe(func(context.Context, event) error { return nil })
+		assert.NoError(t, err)
+		assert.Equals(t, topic.Subscribers(), 2)
+		unsubscribed := make(chan struct{})
+		go func() {
+			subscription.UnsubscribeAndWait()
+			close(unsubscribed)
+		}()
+		assert.NoError(t, <-published)
+		close(release)
+		<-unsubscribed
+		other.UnsubscribeAndWait()
+		topic.Close()
+		assert.Equals(t, topic.Subscribers(), 0)
+	})
+
+	t.Run("when the topic is closed it should return an error", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		topic.Close()
+		subscription, err := topic.Subscribe(func(context.Context, event) error { return nil })
+		assert.ErrorExact(t, err, eventbus.ErrClosed.Error())
+		assert.Nil(t, subscription)
+		assert.ErrorExact(t, topic.Publish(context.Background(), event{}), eventbus.ErrClosed.Error())
+	})
+
+	t.Run("when the handler is nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			_, _ = eventbus.NewTopic[event]("events").Subscribe(nil)
+		}, "The handler cannot be nil.")
+	})
+
+	t.Run("when events are published concurrently it should deliver all of them", func(t *testing.T) {
+		t.Parallel()
+		topic := eventbus.NewTopic[event]("events")
+		var received atomic.Int64
+		_, err := topic.Subscribe(func(context.Context, event) error {
+			received.Add(1)
+			return nil
+		}, eventbus.WithBufferSize(1))
+		assert.NoError(t, err)
+		wg := sync.WaitGroup{}
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10 {
+					assert.NoError(t, topic.Publish(context.Background(), event{}), assert.Continue())
+				}
+			}()
+		}
+		wg.Wait()
+		topic.Close()
+		assert.Equals(t, received.Load(), int64(100))
+	})
+}
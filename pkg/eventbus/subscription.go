@@ -0,0 +1,120 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Subscription receives the events of a Topic on its own goroutine.
+type Subscription[T any] struct {
+	topic        *Topic[T]
+	handler      Handler[T]
+	cfg          *config
+	events       chan delivery[T]
+	unsubscribed chan struct{}
+	done         chan struct{}
+	once         sync.Once
+
+	// queueLock is held for reading by the publishers while they queue an event, and for writing
+	// when the Subscription stops accepting events, so no event is queued after the buffer is drained.
+	queueLock sync.RWMutex
+	stopped   bool
+}
+
+// Unsubscribe stops the Subscription from receiving new events. The queued events are still processed,
+// but it does not wait for them, so it can be called from the handler of the Subscription.
+func (s *Subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		s.topic.lock.Lock()
+		delete(s.topic.subscribers, s)
+		s.topic.lock.Unlock()
+		close(s.unsubscribed)
+	})
+}
+
+// UnsubscribeAndWait stops the Subscription from receiving new events, and waits for the queued events
+// to be processed. It must not be called from the handler of the Subscription.
+func (s *Subscription[T]) UnsubscribeAndWait() {
+	s.Unsubscribe()
+	<-s.done
+}
+
+// queue adds the event to the buffer, unless the Subscription is unsubscribed first.
+func (s *Subscription[T]) queue(ctx context.Context, queued delivery[T]) error {
+	s.queueLock.RLock()
+	defer s.queueLock.RUnlock()
+	if s.stopped {
+		return nil
+	}
+	select {
+	case s.events <- queued:
+		return nil
+	case <-s.unsubscribed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run delivers the queued events until the Subscription is unsubscribed. It then waits for the publishers
+// that are queuing an event, and delivers the events left in the buffer.
+func (s *Subscription[T]) run() {
+	defer close(s.done)
+	for {
+		select {
+		case queued := <-s.events:
+			s.deliver(queued)
+		case <-s.unsubscribed:
+			s.queueLock.Lock()
+			s.stopped = true
+			s.queueLock.Unlock()
+			for {
+				select {
+				case queued := <-s.events:
+					s.deliver(queued)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver gives the event to the handler until it succeeds or the attempts are used up.
+// Once the Subscription is unsubscribed, a failed event is retried without waiting for the retry delay
+// so that unsubscribing is not held up by the delays.
+func (s *Subscription[T]) deliver(queued delivery[T]) {
+	for attempt := 1; ; attempt++ {
+		err := s.call(queued)
+		if err == nil {
+			return
+		}
+		if attempt >= s.cfg.maxAttempts {
+			s.cfg.errorHandler(fmt.Errorf("failed to deliver the event on the topic '%s' after %d attempt(s) (%w)", s.topic.name, attempt, err))
+			return
+		}
+		s.waitToRetry()
+	}
+}
+
+// waitToRetry waits for the retry delay, or until the Subscription is unsubscribed.
+func (s *Subscription[T]) waitToRetry() {
+	timer := time.NewTimer(s.cfg.retryDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-s.unsubscribed:
+	}
+}
+
+// call calls the handler and converts a panic into an error.
+func (s *Subscription[T]) call(queued delivery[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the handler panicked (%v)", r)
+		}
+	}()
+	return s.handler(queued.ctx, queued.event)
+}
@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestSubscriptionQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when events are published while unsubscribing it should deliver every queued event", func(t *testing.T) {
+		t.Parallel()
+		for range 100 {
+			topic := NewTopic[int]("events")
+			var received atomic.Int64
+			subscription, err := topic.Subscribe(func(context.Context, int) error {
+				received.Add(1)
+				return nil
+			}, WithBufferSize(4))
+			assert.NoError(t, err)
+			wg := sync.WaitGroup{}
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for event := range 10 {
+						assert.NoError(t, topic.Publish(context.Background(), event), assert.Continue())
+					}
+				}()
+			}
+			subscription.UnsubscribeAndWait()
+			wg.Wait()
+			assert.Equals(t, len(subscription.events), 0)
+			assert.True(t, received.Load() <= 40)
+		}
+	})
+}
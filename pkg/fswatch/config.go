@@ -0,0 +1,94 @@
+package fswatch
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+const (
+	// defaultInterval is the default time between the scans of the watched paths.
+	defaultInterval = time.Millisecond * 500
+
+	// defaultDebounce is the default time without changes before the callback is called.
+	defaultDebounce = time.Millisecond * 100
+)
+
+// config holds the configuration of a Watcher.
+type config struct {
+	interval  time.Duration
+	debounce  time.Duration
+	patterns  []string
+	recursive bool
+	clock     timestamp.Clock
+}
+
+// Option configures a Watcher.
+type Option func(*config)
+
+// WithInterval sets the time between the scans of the watched paths.
+func WithInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.interval = interval
+	}
+}
+
+// WithDebounce sets how long the watched paths must be unchanged before the events are given to the callback.
+// Editors and tools often write a file many times in a row, and this groups the writes into one call.
+// A debounce of zero calls the callback after each scan that found changes.
+func WithDebounce(debounce time.Duration) Option {
+	return func(cfg *config) {
+		cfg.debounce = debounce
+	}
+}
+
+// WithPatterns only reports the files whose base name matches one of the glob patterns, like "*.yaml".
+// The patterns use the syntax of filepath.Match.
+func WithPatterns(patterns ...string) Option {
+	return func(cfg *config) {
+		cfg.patterns = patterns
+	}
+}
+
+// WithRecursive watches the files in the subdirectories of the watched directories.
+func WithRecursive() Option {
+	return func(cfg *config) {
+		cfg.recursive = true
+	}
+}
+
+// WithClock sets the Clock used to wait between the scans and to measure the debounce.
+// It is meant for tests that use a timestamp.FakeClock.
+func WithClock(clock timestamp.Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = clock
+	}
+}
+
+// configure creates a config out of the provided options and validates it.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		interval:  defaultInterval,
+		debounce:  defaultDebounce,
+		patterns:  nil,
+		recursive: false,
+		clock:     timestamp.SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.interval <= 0 {
+		panic("The interval must be greater than zero.")
+	}
+	if cfg.debounce < 0 {
+		panic("The debounce cannot be negative.")
+	}
+	for _, pattern := range cfg.patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			panic(fmt.Sprintf("The pattern '%s' is not valid.", pattern))
+		}
+	}
+	return cfg
+}
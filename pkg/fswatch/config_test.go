@@ -0,0 +1,30 @@
+package fswatch_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/fswatch"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		callback := func([]fswatch.Event) {}
+		testCases := []struct {
+			option   fswatch.Option
+			panicMsg string
+		}{
+			{fswatch.WithInterval(0), "The interval must be greater than zero."},
+			{fswatch.WithDebounce(-1), "The debounce cannot be negative."},
+			{fswatch.WithPatterns("[invalid"), "The pattern '[invalid' is not valid."},
+		}
+		for _, testCase := range testCases {
+			assert.PanicExact(t, func() {
+				fswatch.New([]string{t.TempDir()}, callback, testCase.option)
+			}, testCase.panicMsg)
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package fswatch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Op is the kind of change of a file. The values can be combined when a file changed more than once
+// during the debounce.
+type Op uint8
+
+const (
+	// Create means the file was created.
+	Create Op = 1 << iota
+
+	// Write means the size or modification time of the file changed.
+	Write
+
+	// Remove means the file was removed.
+	Remove
+)
+
+// Has returns true if the Op contains the other Op.
+func (op Op) Has(other Op) bool {
+	return op&other == other
+}
+
+// String returns the names of the operations separated by a pipe, like "CREATE|WRITE".
+func (op Op) String() string {
+	names := make([]string, 0, 3)
+	for _, candidate := range []struct {
+		op   Op
+		name string
+	}{{Create, "CREATE"}, {Write, "WRITE"}, {Remove, "REMOVE"}} {
+		if op.Has(candidate.op) {
+			names = append(names, candidate.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Event is a change of a file.
+type Event struct {
+	Path string
+	Op   Op
+}
+
+// fileState is what the Watcher compares between scans to find changes.
+// For a symlink, it is the state of the file it points to, along with the path of that file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	mode    fs.FileMode
+	target  string
+}
+
+// Watcher scans files and directories on an interval and calls a callback with the changes.
+// It polls instead of using the notification APIs of the operating system, so it works the same
+// on every platform and with files that do not exist yet, like a certificate that is created later.
+// Symlinks are followed, so a file is reported as written when its symlink, or a symlink on the way
+// to it, points to another file. This is how mounted Kubernetes ConfigMaps and Secrets are updated.
+//
+//	watcher := fswatch.New([]string{"/etc/app"}, func(events []fswatch.Event) {
+//		reloadConfig()
+//	}, fswatch.WithPatterns("*.yaml"))
+//	defer watcher.Close()
+type Watcher struct {
+	paths    []string
+	callback func([]Event)
+	cfg      *config
+	files    map[string]fileState
+	pending  map[string]Op
+	changed  time.Time
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// New scans the paths and starts watching them for changes. The paths can be files or directories.
+// The callback is called on the goroutine of the Watcher with the events sorted by path.
+// Close must be called to stop the Watcher.
+func New(paths []string, callback func(events []Event), opts ...Option) *Watcher {
+	if len(paths) == 0 {
+		panic("At least one path must be watched.")
+	}
+	if callback == nil {
+		panic("The callback cannot be nil.")
+	}
+	w := &Watcher{
+		paths:    paths,
+		callback: callback,
+		cfg:      configure(opts...),
+		pending:  make(map[string]Op),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	w.files = w.scan()
+	go w.run()
+	return w
+}
+
+// Close stops the Watcher and waits for its goroutine to finish. Pending events are discarded.
+func (w *Watcher) Close() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+// run scans the paths on the interval until the Watcher is closed.
+// The interval is measured from the end of each scan, so slow scans do not pile up.
+func (w *Watcher) run() {
+	defer close(w.done)
+	timer := w.cfg.clock.NewTimer(w.cfg.interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C():
+			w.poll(w.cfg.clock.Now())
+			timer.Reset(w.cfg.interval)
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// poll scans the paths, records the changes, and calls the callback once the debounce has passed.
+func (w *Watcher) poll(now time.Time) {
+	files := w.scan()
+	for path, state := range files {
+		previous, existed := w.files[path]
+		switch {
+		case !existed:
+			w.record(path, Create, now)
+		case previous != state:
+			w.record(path, Write, now)
+		}
+	}
+	for path := range w.files {
+		if _, exists := files[path]; !exists {
+			w.record(path, Remove, now)
+		}
+	}
+	w.files = files
+
+	if len(w.pending) == 0 || now.Sub(w.changed) < w.cfg.debounce {
+		return
+	}
+	events := make([]Event, 0, len(w.pending))
+	for path, op := range w.pending {
+		events = append(events, Event{Path: path, Op: op})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	w.pending = make(map[string]Op)
+	w.callback(events)
+}
+
+// record adds a change to the pending events.
+func (w *Watcher) record(path string, op Op, now time.Time) {
+	w.pending[path] |= op
+	w.changed = now
+}
+
+// scan returns the state of the files of the watched paths that match the patterns.
+// Paths that cannot be read, like files that do not exist yet, are skipped. A watched directory
+// that is a symlink is walked where it points to, but its files are reported under the watched path.
+func (w *Watcher) scan() map[string]fileState {
+	files := make(map[string]fileState)
+	for _, root := range w.paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			w.add(files, root)
+			continue
+		}
+		resolvedRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			continue
+		}
+		_ = filepath.WalkDir(resolvedRoot, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if entry.IsDir() {
+				if path != resolvedRoot && !w.cfg.recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if relative, relErr := filepath.Rel(resolvedRoot, path); relErr == nil {
+				w.add(files, filepath.Join(root, relative))
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// add records the state of the file if it matches the patterns. Symlinks are followed,
+// and the ones that point to directories or to nothing are skipped.
+func (w *Watcher) add(files map[string]fileState, path string) {
+	if !w.matches(filepath.Base(path)) {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return
+	}
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return
+	}
+	files[path] = fileState{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		mode:    info.Mode(),
+		target:  target,
+	}
+}
+
+// matches returns true if there are no patterns or if the name matches one of them.
+func (w *Watcher) matches(name string) bool {
+	if len(w.cfg.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range w.cfg.patterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
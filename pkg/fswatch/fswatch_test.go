@@ -0,0 +1,182 @@
+package fswatch_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/fswatch"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/fakeclock"
+)
+
+const (
+	testInterval = time.Millisecond * 5
+)
+
+// testWatcher is a Watcher that scans when its fake clock is advanced.
+type testWatcher struct {
+	clock  *fakeclock.Clock
+	events chan []fswatch.Event
+}
+
+func startWatcher(t *testing.T, paths []string, opts ...fswatch.Option) *testWatcher {
+	t.Helper()
+	watcher := &testWatcher{
+		clock:  fakeclock.New(),
+		events: make(chan []fswatch.Event, 100),
+	}
+	opts = append([]fswatch.Option{
+		fswatch.WithInterval(testInterval),
+		fswatch.WithDebounce(0),
+		fswatch.WithClock(watcher.clock),
+	}, opts...)
+	closeWatcher := fswatch.New(paths, func(batch []fswatch.Event) {
+		watcher.events <- batch
+	}, opts...).Close
+	t.Cleanup(closeWatcher)
+	return watcher
+}
+
+// poll advances the clock by the elapsed time, waits for the scan to finish, and returns the events
+// given to the callback, if any. The clock must be advanced by at least the interval for a scan to run.
+func (w *testWatcher) poll(elapsed time.Duration) []fswatch.Event {
+	w.clock.BlockUntil(1)
+	w.clock.Advance(elapsed)
+	w.clock.BlockUntil(1)
+	select {
+	case batch := <-w.events:
+		return batch
+	default:
+		return nil
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestOp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when an op is formatted it should list its operations", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, fswatch.Create.String(), "CREATE")
+		assert.Equals(t, (fswatch.Create | fswatch.Write | fswatch.Remove).String(), "CREATE|WRITE|REMOVE")
+		assert.Equals(t, fswatch.Op(0).String(), "")
+		assert.True(t, (fswatch.Create | fswatch.Write).Has(fswatch.Write))
+		assert.False(t, fswatch.Create.Has(fswatch.Remove))
+	})
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the arguments are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			fswatch.New(nil, func([]fswatch.Event) {})
+		}, "At least one path must be watched.")
+		assert.PanicExact(t, func() {
+			fswatch.New([]string{t.TempDir()}, nil)
+		}, "The callback cannot be nil.")
+	})
+
+	t.Run("when files in a directory change it should report the changes", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.yaml")
+		watcher := startWatcher(t, []string{dir})
+
+		writeFile(t, path, "a")
+		assert.Equals(t, watcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Create}})
+		writeFile(t, path, "ab")
+		assert.Equals(t, watcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Write}})
+		assert.NoError(t, os.Remove(path))
+		assert.Equals(t, watcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Remove}})
+		assert.Nil(t, watcher.poll(testInterval))
+	})
+
+	t.Run("when a watched file does not exist yet it should report its creation", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "server.crt")
+		watcher := startWatcher(t, []string{path})
+		assert.Nil(t, watcher.poll(testInterval))
+		writeFile(t, path, "certificate")
+		assert.Equals(t, watcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Create}})
+	})
+
+	t.Run("when there are patterns it should only report the matching files", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		watcher := startWatcher(t, []string{dir}, fswatch.WithPatterns("*.yaml", "*.json"))
+		writeFile(t, filepath.Join(dir, "ignored.txt"), "ignored")
+		writeFile(t, filepath.Join(dir, "config.json"), "{}")
+		assert.Equals(t, watcher.poll(testInterval), []fswatch.Event{{Path: filepath.Join(dir, "config.json"), Op: fswatch.Create}})
+	})
+
+	t.Run("when a subdirectory changes it should only report it if the watcher is recursive", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		subDir := filepath.Join(dir, "nested")
+		assert.NoError(t, os.Mkdir(subDir, 0700))
+		shallow := startWatcher(t, []string{dir})
+		recursive := startWatcher(t, []string{dir}, fswatch.WithRecursive())
+		path := filepath.Join(subDir, "file")
+		writeFile(t, path, "data")
+		assert.Equals(t, recursive.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Create}})
+		assert.Nil(t, shallow.poll(testInterval))
+	})
+
+	t.Run("when there is a debounce it should group the changes into one call", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		first := filepath.Join(dir, "first")
+		second := filepath.Join(dir, "second")
+		watcher := startWatcher(t, []string{dir}, fswatch.WithDebounce(time.Millisecond*200))
+		writeFile(t, first, "1")
+		assert.Nil(t, watcher.poll(testInterval))
+		writeFile(t, first, "12")
+		writeFile(t, second, "2")
+		assert.Nil(t, watcher.poll(testInterval))
+		assert.Nil(t, watcher.poll(time.Millisecond*100))
+		batch := watcher.poll(time.Millisecond * 100)
+		assert.Equals(t, batch, []fswatch.Event{
+			{Path: first, Op: fswatch.Create | fswatch.Write},
+			{Path: second, Op: fswatch.Create},
+		})
+		assert.Nil(t, watcher.poll(time.Millisecond*200))
+	})
+
+	t.Run("when a symlink is swapped to another file it should report a write", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		firstVersion := filepath.Join(dir, "..2024_01")
+		secondVersion := filepath.Join(dir, "..2024_02")
+		assert.NoError(t, os.Mkdir(firstVersion, 0700))
+		assert.NoError(t, os.Mkdir(secondVersion, 0700))
+		writeFile(t, filepath.Join(firstVersion, "tls.crt"), "first")
+		writeFile(t, filepath.Join(secondVersion, "tls.crt"), "other")
+		modTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for _, version := range []string{firstVersion, secondVersion} {
+			assert.NoError(t, os.Chtimes(filepath.Join(version, "tls.crt"), modTime, modTime))
+		}
+		assert.NoError(t, os.Symlink(filepath.Base(firstVersion), filepath.Join(dir, "..data")))
+		path := filepath.Join(dir, "tls.crt")
+		assert.NoError(t, os.Symlink(filepath.Join("..data", "tls.crt"), path))
+
+		dirWatcher := startWatcher(t, []string{dir}, fswatch.WithPatterns("*.crt"))
+		fileWatcher := startWatcher(t, []string{path})
+		linkedDirWatcher := startWatcher(t, []string{filepath.Join(dir, "..data")})
+		assert.Nil(t, dirWatcher.poll(testInterval))
+
+		swap := filepath.Join(dir, "..data_tmp")
+		assert.NoError(t, os.Symlink(filepath.Base(secondVersion), swap))
+		assert.NoError(t, os.Rename(swap, filepath.Join(dir, "..data")))
+		assert.Equals(t, dirWatcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Write}})
+		assert.Equals(t, fileWatcher.poll(testInterval), []fswatch.Event{{Path: path, Op: fswatch.Write}})
+		assert.Equals(t, linkedDirWatcher.poll(testInterval), []fswatch.Event{{Path: filepath.Join(dir, "..data", "tls.crt"), Op: fswatch.Write}})
+	})
+}
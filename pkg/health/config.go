@@ -0,0 +1,84 @@
+package health
+
+import (
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+const (
+	// defaultTimeout is the default time a check has to complete.
+	defaultTimeout = time.Second * 5
+)
+
+// registryConfig holds the configuration of a Registry.
+type registryConfig struct {
+	clock timestamp.Clock
+}
+
+// RegistryOption configures a Registry.
+type RegistryOption func(*registryConfig)
+
+// WithClock sets the Clock used to time the checks and schedule the periodic checks.
+func WithClock(clock timestamp.Clock) RegistryOption {
+	return func(cfg *registryConfig) {
+		cfg.clock = clock
+	}
+}
+
+// checkConfig holds the configuration of a registered check.
+type checkConfig struct {
+	timeout  time.Duration
+	interval time.Duration
+	cacheTTL time.Duration
+}
+
+// CheckOption configures a registered check.
+type CheckOption func(*checkConfig)
+
+// WithTimeout sets the time the check has to complete before it is reported as down.
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithInterval runs the check in the background on the interval. Reports use the latest result
+// instead of running the check.
+func WithInterval(interval time.Duration) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.interval = interval
+	}
+}
+
+// WithCacheTTL reuses the result of the check in the reports for the duration.
+func WithCacheTTL(ttl time.Duration) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.cacheTTL = ttl
+	}
+}
+
+// configureCheck creates a checkConfig out of the provided options and validates it.
+func configureCheck(opts ...CheckOption) *checkConfig {
+	cfg := &checkConfig{
+		timeout:  defaultTimeout,
+		interval: 0,
+		cacheTTL: 0,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.timeout <= 0 {
+		panic("The timeout must be greater than zero.")
+	}
+	if cfg.interval < 0 {
+		panic("The interval cannot be negative.")
+	}
+	if cfg.cacheTTL < 0 {
+		panic("The cache TTL cannot be negative.")
+	}
+	if cfg.interval > 0 && cfg.cacheTTL > 0 {
+		panic("A check cannot have both an interval and a cache TTL.")
+	}
+	return cfg
+}
@@ -0,0 +1,36 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/health"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the check options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		checker := health.CheckerFunc(func(context.Context) error { return nil })
+		testCases := []struct {
+			opts     []health.CheckOption
+			panicMsg string
+		}{
+			{[]health.CheckOption{health.WithTimeout(0)}, "The timeout must be greater than zero."},
+			{[]health.CheckOption{health.WithInterval(-1)}, "The interval cannot be negative."},
+			{[]health.CheckOption{health.WithCacheTTL(-1)}, "The cache TTL cannot be negative."},
+			{[]health.CheckOption{health.WithInterval(time.Second), health.WithCacheTTL(time.Second)}, "A check cannot have both an interval and a cache TTL."},
+		}
+		for _, testCase := range testCases {
+			assert.PanicExact(t, func() {
+				registry.MustRegister("check", checker, testCase.opts...)
+			}, testCase.panicMsg)
+		}
+		assert.Equals(t, len(registry.Names()), 0)
+	})
+}
@@ -0,0 +1,270 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/concurrency"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// Checker verifies that a dependency or component is healthy. It returns an error if it is not.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is a function that implements Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls the function.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Status is the outcome of a check or a report.
+type Status string
+
+const (
+	// StatusUp means the check succeeded.
+	StatusUp Status = "UP"
+
+	// StatusDown means the check failed, timed out, or has not run yet.
+	StatusDown Status = "DOWN"
+)
+
+// Result is the outcome of a single check. The Duration is encoded as a string like "5ms".
+type Result struct {
+	Status    Status             `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Duration  timestamp.Duration `json:"duration"`
+	CheckedAt time.Time          `json:"checkedAt"`
+}
+
+// Report is the aggregate of the results of all the checks. It is up only if all the checks are up.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Healthy returns true if all the checks are up.
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// registeredCheck is a Checker with its configuration and its latest result.
+type registeredCheck struct {
+	name    string
+	checker Checker
+	cfg     *checkConfig
+	lock    sync.Mutex
+	latest  *Result
+}
+
+// Registry holds the named checks and runs them to create reports.
+type Registry struct {
+	clock   timestamp.Clock
+	lock    sync.RWMutex
+	checks  map[string]*registeredCheck
+	closed  bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+// NewRegistry creates a Registry. Close must be called to stop the periodic checks.
+func NewRegistry(opts ...RegistryOption) *Registry {
+	cfg := &registryConfig{
+		clock: timestamp.SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Registry{
+		clock:  cfg.clock,
+		checks: make(map[string]*registeredCheck),
+		closed: false,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// MustRegister adds a named check to the Registry. It panics if the name is already registered.
+func (r *Registry) MustRegister(name string, checker Checker, opts ...CheckOption) {
+	if name == "" {
+		panic("The name of the check cannot be empty.")
+	}
+	if checker == nil {
+		panic("The checker cannot be nil.")
+	}
+	cfg := configureCheck(opts...)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		panic("The registry is closed.")
+	}
+	if _, alreadyRegistered := r.checks[name]; alreadyRegistered {
+		panic(fmt.Sprintf("The check %s is already registered.", name))
+	}
+	check := &registeredCheck{
+		name:    name,
+		checker: checker,
+		cfg:     cfg,
+	}
+	r.checks[name] = check
+	if cfg.interval > 0 {
+		r.workers.Add(1)
+		go r.runPeriodically(check)
+	}
+}
+
+// Names returns the sorted names of the registered checks.
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check creates a report of all the registered checks. The checks without an interval or a fresh cached result
+// are run concurrently.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.lock.RLock()
+	checks := make([]*registeredCheck, 0, len(r.checks))
+	for _, check := range r.checks {
+		checks = append(checks, check)
+	}
+	r.lock.RUnlock()
+
+	results, _ := concurrency.Map(ctx, checks, func(ctx context.Context, check *registeredCheck) (Result, error) {
+		return r.result(ctx, check), nil
+	}, concurrency.WithWorkers(max(len(checks), 1)))
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(checks)),
+	}
+	for i, check := range checks {
+		result := results[i]
+		if result.Status == "" {
+			result = down(ctx.Err(), r.clock.Now(), 0)
+		}
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+		report.Checks[check.name] = result
+	}
+	return report
+}
+
+// Close stops the periodic checks and waits for them to finish.
+func (r *Registry) Close() {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return
+	}
+	r.closed = true
+	r.cancel()
+	r.lock.Unlock()
+	r.workers.Wait()
+}
+
+// result returns the latest result of a periodic or cached check, or runs the check.
+// A result is not cached when the context of the caller is done, since it says more about the caller than the check.
+func (r *Registry) result(ctx context.Context, check *registeredCheck) Result {
+	check.lock.Lock()
+	latest := check.latest
+	check.lock.Unlock()
+
+	if check.cfg.interval > 0 {
+		if latest == nil {
+			return down(errors.New("the check has not run yet"), r.clock.Now(), 0)
+		}
+		return *latest
+	}
+	if check.cfg.cacheTTL > 0 && latest != nil && r.clock.Since(latest.CheckedAt) < check.cfg.cacheTTL {
+		return *latest
+	}
+	result := r.run(ctx, check)
+	if ctx.Err() != nil {
+		return result
+	}
+	check.lock.Lock()
+	check.latest = &result
+	check.lock.Unlock()
+	return result
+}
+
+// runPeriodically runs the check right away and then on its interval until the Registry is closed.
+func (r *Registry) runPeriodically(check *registeredCheck) {
+	defer r.workers.Done()
+	ticker := r.clock.NewTicker(check.cfg.interval)
+	defer ticker.Stop()
+	for {
+		result := r.run(r.ctx, check)
+		check.lock.Lock()
+		check.latest = &result
+		check.lock.Unlock()
+		select {
+		case <-ticker.C():
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// run calls the checker with the timeout of the check. The check is reported as down once the timeout
+// expires even if the checker does not return.
+func (r *Registry) run(ctx context.Context, check *registeredCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, check.cfg.timeout)
+	defer cancel()
+	start := r.clock.Now()
+	checkErr := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				checkErr <- fmt.Errorf("the check panicked (%v)", recovered)
+			}
+		}()
+		checkErr <- check.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-checkErr:
+	case <-ctx.Done():
+		err = fmt.Errorf("the check did not complete in time (%w)", ctx.Err())
+	}
+	if err != nil {
+		return down(err, start, r.clock.Since(start))
+	}
+	return Result{
+		Status:    StatusUp,
+		Duration:  timestamp.Duration(r.clock.Since(start)),
+		CheckedAt: start,
+	}
+}
+
+// down creates a down Result out of an error.
+func down(err error, checkedAt time.Time, duration time.Duration) Result {
+	message := "the check did not run"
+	if err != nil {
+		message = err.Error()
+	}
+	return Result{
+		Status:    StatusDown,
+		Error:     message,
+		Duration:  timestamp.Duration(duration),
+		CheckedAt: checkedAt,
+	}
+}
@@ -0,0 +1,208 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/health"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func newFakeClock() *timestamp.FakeClock {
+	return timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	up := health.CheckerFunc(func(context.Context) error { return nil })
+
+	t.Run("when all the checks succeed it should report up", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		registry := health.NewRegistry(health.WithClock(clock))
+		defer registry.Close()
+		registry.MustRegister("database", up)
+		registry.MustRegister("cache", up)
+		assert.Equals(t, registry.Names(), []string{"cache", "database"})
+		report := registry.Check(context.Background())
+		assert.True(t, report.Healthy())
+		assert.Equals(t, report.Checks["database"], health.Result{Status: health.StatusUp, CheckedAt: clock.Now()})
+		assert.Equals(t, len(report.Checks), 2)
+	})
+
+	t.Run("when there are no checks it should report up", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		report := registry.Check(context.Background())
+		assert.Equals(t, report.Status, health.StatusUp)
+		assert.Equals(t, len(report.Checks), 0)
+	})
+
+	t.Run("when a check fails it should report down with its error", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		registry.MustRegister("database", up)
+		registry.MustRegister("queue", health.CheckerFunc(func(context.Context) error {
+			return errors.New("connection refused")
+		}))
+		report := registry.Check(context.Background())
+		assert.False(t, report.Healthy())
+		assert.Equals(t, report.Checks["database"].Status, health.StatusUp)
+		assert.Equals(t, report.Checks["queue"].Status, health.StatusDown)
+		assert.Equals(t, report.Checks["queue"].Error, "connection refused")
+	})
+
+	t.Run("when a check panics it should report down", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		registry.MustRegister("broken", health.CheckerFunc(func(context.Context) error {
+			panic("check failure")
+		}))
+		report := registry.Check(context.Background())
+		assert.Equals(t, report.Checks["broken"].Error, "the check panicked (check failure)")
+	})
+
+	t.Run("when a check does not complete in time it should report down", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		release := make(chan struct{})
+		defer close(release)
+		registry.MustRegister("slow", health.CheckerFunc(func(context.Context) error {
+			<-release
+			return nil
+		}), health.WithTimeout(time.Millisecond*10))
+		report := registry.Check(context.Background())
+		assert.Equals(t, report.Checks["slow"].Status, health.StatusDown)
+		assert.Equals(t, report.Checks["slow"].Error, "the check did not complete in time (context deadline exceeded)")
+	})
+
+	t.Run("when a check has a cache TTL it should reuse its result until it expires", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		registry := health.NewRegistry(health.WithClock(clock))
+		defer registry.Close()
+		var calls atomic.Int64
+		registry.MustRegister("cached", health.CheckerFunc(func(context.Context) error {
+			calls.Add(1)
+			return nil
+		}), health.WithCacheTTL(time.Minute))
+		registry.Check(context.Background())
+		clock.Advance(time.Second * 59)
+		registry.Check(context.Background())
+		assert.Equals(t, calls.Load(), int64(1))
+		clock.Advance(time.Second)
+		registry.Check(context.Background())
+		assert.Equals(t, calls.Load(), int64(2))
+	})
+
+	t.Run("when the caller is canceled during a cached check it should not cache the result", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		registry := health.NewRegistry(health.WithClock(clock))
+		defer registry.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int64
+		registry.MustRegister("cached", health.CheckerFunc(func(checkCtx context.Context) error {
+			if calls.Add(1) == 1 {
+				cancel()
+				<-checkCtx.Done()
+				return checkCtx.Err()
+			}
+			return nil
+		}), health.WithCacheTTL(time.Minute))
+		assert.Equals(t, registry.Check(ctx).Checks["cached"].Status, health.StatusDown)
+		assert.Equals(t, registry.Check(context.Background()).Checks["cached"].Status, health.StatusUp)
+		assert.Equals(t, calls.Load(), int64(2))
+	})
+
+	t.Run("when a check has an interval it should run in the background and report the latest result", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		registry := health.NewRegistry(health.WithClock(clock))
+		defer registry.Close()
+		var healthy atomic.Bool
+		var calls atomic.Int64
+		registry.MustRegister("periodic", health.CheckerFunc(func(context.Context) error {
+			calls.Add(1)
+			if healthy.Load() {
+				return nil
+			}
+			return errors.New("not ready")
+		}), health.WithInterval(time.Minute))
+		waitForError := func(expected string) {
+			deadline := time.Now().Add(time.Second * 5)
+			for registry.Check(context.Background()).Checks["periodic"].Error != expected {
+				if time.Now().After(deadline) {
+					t.Fatalf("The periodic check did not report '%s' in time.", expected)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}
+		waitForError("not ready")
+		healthy.Store(true)
+		clock.Advance(time.Minute)
+		waitForError("")
+		assert.Equals(t, calls.Load(), int64(2))
+	})
+
+	t.Run("when the context is canceled it should report the checks that did not run as down", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		registry.MustRegister("database", up)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		report := registry.Check(ctx)
+		assert.Equals(t, report.Checks["database"].Status, health.StatusDown)
+		assert.Equals(t, report.Checks["database"].Error, context.Canceled.Error())
+	})
+
+	t.Run("when a check is registered twice it should panic", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		defer registry.Close()
+		registry.MustRegister("database", up)
+		assert.PanicExact(t, func() {
+			registry.MustRegister("database", up)
+		}, "The check database is already registered.")
+		assert.PanicExact(t, func() {
+			registry.MustRegister("", up)
+		}, "The name of the check cannot be empty.")
+		assert.PanicExact(t, func() {
+			registry.MustRegister("nil", nil)
+		}, "The checker cannot be nil.")
+	})
+
+	t.Run("when the registry is closed it should not accept checks", func(t *testing.T) {
+		t.Parallel()
+		registry := health.NewRegistry()
+		registry.Close()
+		registry.Close()
+		assert.PanicExact(t, func() {
+			registry.MustRegister("database", up)
+		}, "The registry is closed.")
+	})
+
+	t.Run("when a report is encoded it should use the JSON field names", func(t *testing.T) {
+		t.Parallel()
+		report := health.Report{
+			Status: health.StatusDown,
+			Checks: map[string]health.Result{
+				"database": {Status: health.StatusDown, Error: "timeout", Duration: timestamp.Duration(time.Millisecond * 5), CheckedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		}
+		encoded, err := json.Marshal(report)
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{"status":"DOWN","checks":{"database":{"status":"DOWN","error":"timeout","duration":"5ms","checkedAt":"2024-01-01T00:00:00Z"}}}`)
+	})
+}
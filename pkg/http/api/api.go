@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/TriangleSide/GoTools/pkg/http/middleware"
@@ -137,6 +138,20 @@ func (builder *HTTPAPIBuilder) Handlers() map[Path]map[Method]*Handler {
 	return builder.handlers
 }
 
+// ServeMux returns an http.ServeMux that routes to the registered handlers. The common middleware
+// runs before the middleware of each handler.
+func (builder *HTTPAPIBuilder) ServeMux(commonMiddleware ...middleware.Middleware) *http.ServeMux {
+	serveMux := http.NewServeMux()
+	for apiPath, methodToEndpointHandlerMap := range builder.handlers {
+		for method, endpointHandler := range methodToEndpointHandlerMap {
+			endpointHandlerMw := slices.Concat(commonMiddleware, endpointHandler.Middleware)
+			handlerChain := middleware.CreateChain(endpointHandlerMw, endpointHandler.Handler)
+			serveMux.HandleFunc(fmt.Sprintf("%s %s", method, apiPath), handlerChain)
+		}
+	}
+	return serveMux
+}
+
 // The HTTPEndpointHandler interface is implemented by structs that handle HTTP calls.
 type HTTPEndpointHandler interface {
 	AcceptHTTPAPIBuilder(builder *HTTPAPIBuilder)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/TriangleSide/GoTools/pkg/http/api"
+	"github.com/TriangleSide/GoTools/pkg/http/middleware"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
 	"github.com/TriangleSide/GoTools/pkg/validation"
 )
@@ -303,4 +304,35 @@ func TestHTTPApi(t *testing.T) {
 		err := validation.Struct(&test)
 		assert.ErrorPart(t, err, "found nil while dereferencing")
 	})
+
+	t.Run("when a serve mux is created it should route to the handlers through the middleware", func(t *testing.T) {
+		t.Parallel()
+		var sequence []string
+		record := func(name string) middleware.Middleware {
+			return func(next http.HandlerFunc) http.HandlerFunc {
+				return func(writer http.ResponseWriter, request *http.Request) {
+					sequence = append(sequence, name)
+					next(writer, request)
+				}
+			}
+		}
+		builder := api.NewHTTPAPIBuilder()
+		builder.MustRegister("/test/{id}", http.MethodGet, &api.Handler{
+			Middleware: []middleware.Middleware{record("handler")},
+			Handler: func(writer http.ResponseWriter, request *http.Request) {
+				sequence = append(sequence, request.PathValue("id"))
+				writer.WriteHeader(http.StatusAccepted)
+			},
+		})
+		serveMux := builder.ServeMux(record("common"))
+
+		recorder := httptest.NewRecorder()
+		serveMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test/123", nil))
+		assert.Equals(t, recorder.Code, http.StatusAccepted)
+		assert.Equals(t, sequence, []string{"common", "handler", "123"})
+
+		recorder = httptest.NewRecorder()
+		serveMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/test/123", nil))
+		assert.Equals(t, recorder.Code, http.StatusMethodNotAllowed)
+	})
 }
@@ -146,3 +146,18 @@ func TestTags(t *testing.T) {
 		})
 	})
 }
+
+func BenchmarkExtractAndValidateFieldTagLookupKeys(b *testing.B) {
+	type testStruct struct {
+		Query  string `urlQuery:"query" json:"-"`
+		Header string `httpHeader:"x-header" json:"-"`
+		Path   string `urlPath:"path" json:"-"`
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := parameters.ExtractAndValidateFieldTagLookupKeys[testStruct](); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -50,8 +50,10 @@ func MustRegisterErrorResponse[T any, R any](status int, callback func(err *T) *
 }
 
 // StandardErrorResponse is the standard JSON response an API endpoint makes when an unknown error occurs in the endpoint handler.
+// The code is set for errors from the errors package.
 type StandardErrorResponse struct {
 	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
 }
 
 // init registers standard error messages for the responder.
@@ -9,9 +9,27 @@ import (
 	"reflect"
 	"strconv"
 
+	codederrors "github.com/TriangleSide/GoTools/pkg/errors"
 	"github.com/TriangleSide/GoTools/pkg/http/headers"
 )
 
+var (
+	// codeToStatus maps the codes of the errors package to HTTP status codes.
+	codeToStatus = map[codederrors.Code]int{
+		codederrors.CodeInvalidArgument:    http.StatusBadRequest,
+		codederrors.CodeNotFound:           http.StatusNotFound,
+		codederrors.CodeAlreadyExists:      http.StatusConflict,
+		codederrors.CodeFailedPrecondition: http.StatusBadRequest,
+		codederrors.CodeUnauthenticated:    http.StatusUnauthorized,
+		codederrors.CodePermissionDenied:   http.StatusForbidden,
+		codederrors.CodeResourceExhausted:  http.StatusTooManyRequests,
+		codederrors.CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+		codederrors.CodeUnavailable:        http.StatusServiceUnavailable,
+		codederrors.CodeUnimplemented:      http.StatusNotImplemented,
+		codederrors.CodeInternal:           http.StatusInternalServerError,
+	}
+)
+
 // errJoinUnwrap unwraps errors joined by errors.Join.
 type errJoinUnwrap interface {
 	Unwrap() []error
@@ -43,8 +61,30 @@ func findRegistryMatch(err error) (error, *registeredErrorResponse) {
 	return nil, nil
 }
 
+// codedErrorResponse maps an error from the errors package to a status and response.
+// The message is only returned for client errors since server errors can contain internal details.
+func codedErrorResponse(err error) (int, *StandardErrorResponse, bool) {
+	var codedErr *codederrors.Error
+	if !errors.As(err, &codedErr) {
+		return 0, nil, false
+	}
+	statusCode, statusFound := codeToStatus[codedErr.Code()]
+	if !statusFound {
+		statusCode = http.StatusInternalServerError
+	}
+	message := codedErr.Message()
+	if statusCode >= http.StatusInternalServerError || message == "" {
+		message = http.StatusText(statusCode)
+	}
+	return statusCode, &StandardErrorResponse{
+		Message: message,
+		Code:    string(codedErr.Code()),
+	}, true
+}
+
 // Error responds to an HTTP requests with an ErrorResponse. It tries to match it to a known error type
-// so it can return its corresponding status and message. It defaults to HTTP 500 internal server error.
+// so it can return its corresponding status and message. Errors from the errors package are mapped by their code.
+// It defaults to HTTP 500 internal server error.
 // An error is returned if there was an error writing the response.
 func Error(writer http.ResponseWriter, err error, opts ...Option) {
 	cfg := configure(opts...)
@@ -54,6 +94,9 @@ func Error(writer http.ResponseWriter, err error, opts ...Option) {
 	if matchErr, match := findRegistryMatch(err); match != nil {
 		statusCode = match.Status
 		errResponse = match.Callback(matchErr)
+	} else if codedStatus, codedResponse, isCoded := codedErrorResponse(err); isCoded {
+		statusCode = codedStatus
+		errResponse = codedResponse
 	} else {
 		statusCode = http.StatusInternalServerError
 		errResponse = StandardErrorResponse{
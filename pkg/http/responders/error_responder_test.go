@@ -3,10 +3,12 @@ package responders_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	codederrors "github.com/TriangleSide/GoTools/pkg/errors"
 	"github.com/TriangleSide/GoTools/pkg/http/responders"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
 )
@@ -103,6 +105,41 @@ func TestErrorResponder(t *testing.T) {
 		assert.NoError(t, writeError)
 	})
 
+	t.Run("when the error has a code it should return the status and message of the code", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			err     error
+			status  int
+			message string
+			code    string
+		}{
+			{codederrors.New(codederrors.CodeNotFound, "the user was not found"), http.StatusNotFound, "the user was not found", "NOT_FOUND"},
+			{codederrors.New(codederrors.CodeInvalidArgument, ""), http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "INVALID_ARGUMENT"},
+			{codederrors.New(codederrors.CodeUnavailable, "the database is down"), http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable), "UNAVAILABLE"},
+			{codederrors.New(codederrors.CodeFailedPrecondition, "the account is locked"), http.StatusBadRequest, "the account is locked", "FAILED_PRECONDITION"},
+			{codederrors.New(codederrors.Code("CUSTOM"), "custom failure"), http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), "CUSTOM"},
+			{fmt.Errorf("failed to load (%w)", codederrors.New(codederrors.CodePermissionDenied, "access denied")), http.StatusForbidden, "access denied", "PERMISSION_DENIED"},
+		}
+		for _, testCase := range testCases {
+			recorder := httptest.NewRecorder()
+			responders.Error(recorder, testCase.err)
+			assert.Equals(t, recorder.Code, testCase.status)
+			httpError := mustDeserializeError(t, recorder)
+			assert.Equals(t, httpError.Message, testCase.message)
+			assert.Equals(t, httpError.Code, testCase.code)
+		}
+	})
+
+	t.Run("when the error has a code and a registered type it should use the registered type", func(t *testing.T) {
+		t.Parallel()
+		recorder := httptest.NewRecorder()
+		responders.Error(recorder, codederrors.Wrap(&testError{}, codederrors.CodeNotFound, "not found"))
+		assert.Equals(t, recorder.Code, http.StatusBadRequest)
+		httpError := mustDeserializeError(t, recorder)
+		assert.Equals(t, httpError.Message, "test error")
+		assert.Equals(t, httpError.Code, "")
+	})
+
 	t.Run("when the writer returns an error it should invoke to the callback", func(t *testing.T) {
 		t.Parallel()
 		recorder := httptest.NewRecorder()
@@ -6,6 +6,7 @@ import (
 	"net/netip"
 
 	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
 )
 
 // TLSMode represents the TLS mode of the HTTP server.
@@ -27,6 +28,10 @@ const (
 )
 
 // Config holds configuration parameters for an HTTP server.
+//
+// The timeouts are durations like "2m" or "500ms". They replace the previous millisecond fields, so
+// HTTP_SERVER_READ_TIMEOUT_MILLISECONDS=120000 becomes HTTP_SERVER_READ_TIMEOUT=2m, and likewise for
+// the write, idle, and header read timeouts.
 type Config struct {
 	// BindIP is the IP address the server listens on.
 	BindIP string `config_format:"snake" config_default:"::1" validate:"required,ip_addr"`
@@ -34,21 +39,21 @@ type Config struct {
 	// BindPort is the port number the server listens on.
 	BindPort uint16 `config_format:"snake" config_default:"0" validate:"gte=0"`
 
-	// ReadTimeoutMilliseconds is the maximum time (in seconds) to read the request.
-	// Zero or negative means no timeout.
-	ReadTimeoutMilliseconds int `config_format:"snake" config_default:"120000" validate:"gte=0"`
+	// ReadTimeout is the maximum time to read the request.
+	// Zero means no timeout.
+	ReadTimeout timestamp.Duration `config_format:"snake" config_default:"2m" validate:"gte=0"`
 
-	// WriteTimeoutMilliseconds is the maximum time (in seconds) to write the response.
-	// Zero or negative means no timeout.
-	WriteTimeoutMilliseconds int `config_format:"snake" config_default:"120000" validate:"gte=0"`
+	// WriteTimeout is the maximum time to write the response.
+	// Zero means no timeout.
+	WriteTimeout timestamp.Duration `config_format:"snake" config_default:"2m" validate:"gte=0"`
 
-	// IdleTimeoutMilliseconds sets the max idle time (in seconds) between requests when keep-alives are enabled.
+	// IdleTimeout sets the max idle time between requests when keep-alives are enabled.
 	// If zero, ReadTimeout is used. If both are zero, it means no timeout.
-	IdleTimeoutMilliseconds int `config_format:"snake" config_default:"0" validate:"gte=0"`
+	IdleTimeout timestamp.Duration `config_format:"snake" config_default:"0s" validate:"gte=0"`
 
-	// HeaderReadTimeoutMilliseconds is the maximum time (in seconds) to read request headers.
+	// HeaderReadTimeout is the maximum time to read request headers.
 	// If zero, ReadTimeout is used. If both are zero, it means no timeout.
-	HeaderReadTimeoutMilliseconds int `config_format:"snake" config_default:"0" validate:"gte=0"`
+	HeaderReadTimeout timestamp.Duration `config_format:"snake" config_default:"0s" validate:"gte=0"`
 
 	// TLSMode specifies the TLS mode of the server: off, tls, or mutual_tls.
 	TLSMode TLSMode `config_format:"snake" config_default:"tls" validate:"oneof=off tls mutual_tls"`
@@ -0,0 +1,37 @@
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/http/server"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("when the defaults are used it should parse the timeouts as durations", func(t *testing.T) {
+		cfg, err := config.Process[server.Config](config.WithPrefix(server.ConfigPrefix))
+		assert.NoError(t, err)
+		assert.Equals(t, cfg.ReadTimeout, timestamp.Duration(time.Minute*2))
+		assert.Equals(t, cfg.WriteTimeout, timestamp.Duration(time.Minute*2))
+		assert.Equals(t, cfg.IdleTimeout, timestamp.Duration(0))
+		assert.Equals(t, cfg.HeaderReadTimeout, timestamp.Duration(0))
+	})
+
+	t.Run("when the timeouts are set in the environment it should parse them as durations", func(t *testing.T) {
+		t.Setenv("HTTP_SERVER_READ_TIMEOUT", "1m30s")
+		t.Setenv("HTTP_SERVER_HEADER_READ_TIMEOUT", "500ms")
+		cfg, err := config.Process[server.Config](config.WithPrefix(server.ConfigPrefix))
+		assert.NoError(t, err)
+		assert.Equals(t, cfg.ReadTimeout, timestamp.Duration(time.Second*90))
+		assert.Equals(t, cfg.HeaderReadTimeout, timestamp.Duration(time.Millisecond*500))
+	})
+
+	t.Run("when a timeout is not a duration it should return an error", func(t *testing.T) {
+		t.Setenv("HTTP_SERVER_WRITE_TIMEOUT", "120000")
+		_, err := config.Process[server.Config](config.WithPrefix(server.ConfigPrefix))
+		assert.ErrorPart(t, err, "failed to parse the duration")
+	})
+}
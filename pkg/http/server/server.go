@@ -11,7 +11,6 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/TriangleSide/GoTools/pkg/http/api"
 	"github.com/TriangleSide/GoTools/pkg/http/middleware"
@@ -91,14 +90,7 @@ func New(opts ...Option) (*Server, error) {
 		endpointHandler.AcceptHTTPAPIBuilder(builder)
 	}
 
-	serveMux := http.NewServeMux()
-	for apiPath, methodToEndpointHandlerMap := range builder.Handlers() {
-		for method, endpointHandler := range methodToEndpointHandlerMap {
-			endpointHandlerMw := append(srvOpts.commonMiddleware, endpointHandler.Middleware...)
-			handlerChain := middleware.CreateChain(endpointHandlerMw, endpointHandler.Handler)
-			serveMux.HandleFunc(fmt.Sprintf("%s %s", method, apiPath), handlerChain)
-		}
-	}
+	serveMux := builder.ServeMux(srvOpts.commonMiddleware...)
 
 	var tlsConfig *tls.Config
 	switch envConfig.TLSMode {
@@ -138,10 +130,10 @@ func New(opts ...Option) (*Server, error) {
 	srv := &Server{
 		srv: http.Server{
 			Handler:           serveMux,
-			ReadTimeout:       time.Millisecond * time.Duration(envConfig.ReadTimeoutMilliseconds),
-			WriteTimeout:      time.Millisecond * time.Duration(envConfig.WriteTimeoutMilliseconds),
-			IdleTimeout:       time.Millisecond * time.Duration(envConfig.IdleTimeoutMilliseconds),
-			ReadHeaderTimeout: time.Millisecond * time.Duration(envConfig.HeaderReadTimeoutMilliseconds),
+			ReadTimeout:       envConfig.ReadTimeout.Std(),
+			WriteTimeout:      envConfig.WriteTimeout.Std(),
+			IdleTimeout:       envConfig.IdleTimeout.Std(),
+			ReadHeaderTimeout: envConfig.HeaderReadTimeout.Std(),
 			MaxHeaderBytes:    envConfig.MaxHeaderBytes,
 			TLSConfig:         tlsConfig,
 		},
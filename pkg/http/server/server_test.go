@@ -3,22 +3,13 @@ package server_test
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
 	"crypto/tls"
-	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/pem"
 	"errors"
 	"io"
-	"math/big"
 	"net"
 	"net/http"
-	"os"
-	"path/filepath"
 	"sync"
 	"testing"
-	"time"
 
 	"github.com/TriangleSide/GoTools/pkg/config"
 	"github.com/TriangleSide/GoTools/pkg/http/api"
@@ -27,6 +18,8 @@ import (
 	"github.com/TriangleSide/GoTools/pkg/http/responders"
 	"github.com/TriangleSide/GoTools/pkg/http/server"
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/certs"
+	"github.com/TriangleSide/GoTools/pkg/test/httpassert"
 )
 
 type testHandler struct {
@@ -105,17 +98,7 @@ func TestServer(t *testing.T) {
 		} else {
 			protocol = "http"
 		}
-		request, err := http.NewRequest(http.MethodGet, protocol+"://"+addr, nil)
-		assert.NoError(t, err)
-		assert.NotNil(t, request)
-		response, err := httpClient.Do(request)
-		assert.NoError(t, err)
-		assert.Equals(t, http.StatusOK, response.StatusCode)
-		assert.NotNil(t, response.Body)
-		bodyContents, err := io.ReadAll(response.Body)
-		assert.NoError(t, err)
-		assert.Equals(t, bodyContents, []byte("PONG"))
-		assert.NoError(t, response.Body.Close())
+		httpassert.NewServerClient(t, protocol+"://"+addr, httpClient).Get("/").Status(http.StatusOK).BodyEquals("PONG")
 	}
 
 	t.Run("when a server is instantiated it should fail if there's an error when parsing the environment variables", func(t *testing.T) {
@@ -286,15 +269,7 @@ func TestServer(t *testing.T) {
 				writer.WriteHeader(http.StatusOK)
 			},
 		}))
-		httpClient := &http.Client{}
-		request, err := http.NewRequest(http.MethodGet, "http://"+serverAddr+"/test", nil)
-		assert.NoError(t, err)
-		response, err := httpClient.Do(request)
-		t.Cleanup(func() {
-			assert.NoError(t, response.Body.Close())
-		})
-		assert.NoError(t, err)
-		assert.NotNil(t, response)
+		httpassert.NewServerClient(t, "http://"+serverAddr, nil).Get("/test").Status(http.StatusOK)
 		assert.Equals(t, seq, []string{"0", "1", "2", "3", "4"})
 	})
 
@@ -314,117 +289,17 @@ func TestServer(t *testing.T) {
 
 	t.Run("when certificates are generated for TLS and mTLS", func(t *testing.T) {
 		t.Parallel()
-		tempDir := t.TempDir()
-
-		caPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-		assert.NoError(t, err)
-
-		caCertTemplate := x509.Certificate{
-			SerialNumber: big.NewInt(1),
-			Subject: pkix.Name{
-				Organization: []string{"Test CA"},
-			},
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(24 * time.Hour),
-			IsCA:                  true,
-			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
-			BasicConstraintsValid: true,
-		}
-
-		caCertBytes, err := x509.CreateCertificate(rand.Reader, &caCertTemplate, &caCertTemplate, &caPrivateKey.PublicKey, caPrivateKey)
-		assert.NoError(t, err)
-		caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCertBytes})
-
-		clientCACertPath := filepath.Join(tempDir, "ca_cert.pem")
-		assert.NoError(t, os.WriteFile(clientCACertPath, caCertPEM, 0644))
-		clientCaCertPaths := []string{clientCACertPath}
-
-		serverPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-		assert.NoError(t, err)
-		serverPrivateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(serverPrivateKey)})
-
-		serverCertTemplate := x509.Certificate{
-			SerialNumber: big.NewInt(2),
-			Subject: pkix.Name{
-				Organization: []string{"Server Tests Inc."},
-			},
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(24 * time.Hour),
-			KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-			BasicConstraintsValid: true,
-			IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
-		}
-		serverCertBytes, err := x509.CreateCertificate(rand.Reader, &serverCertTemplate, &caCertTemplate, &serverPrivateKey.PublicKey, caPrivateKey)
-		assert.NoError(t, err)
-		serverCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCertBytes})
-
-		serverPrivateKeyPath := filepath.Join(tempDir, "server_key.pem")
-		assert.NoError(t, os.WriteFile(serverPrivateKeyPath, serverPrivateKeyPEM, 0600))
-
-		serverCertificatePath := filepath.Join(tempDir, "server_cert.pem")
-		assert.NoError(t, os.WriteFile(serverCertificatePath, serverCertPEM, 0644))
-
-		clientPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-		assert.NoError(t, err)
-		clientPrivateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(clientPrivateKey)})
-
-		clientCertTemplate := x509.Certificate{
-			SerialNumber: big.NewInt(3),
-			Subject: pkix.Name{
-				Organization: []string{"Client Tests Inc."},
-			},
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(24 * time.Hour),
-			KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-			BasicConstraintsValid: true,
-		}
-		clientCertBytes, err := x509.CreateCertificate(rand.Reader, &clientCertTemplate, &caCertTemplate, &clientPrivateKey.PublicKey, caPrivateKey)
-		assert.NoError(t, err)
-		clientCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientCertBytes})
-
-		clientPrivateKeyPath := filepath.Join(tempDir, "client_key.pem")
-		assert.NoError(t, os.WriteFile(clientPrivateKeyPath, clientPrivateKeyPEM, 0600))
-
-		clientCertificatePath := filepath.Join(tempDir, "client_cert.pem")
-		assert.NoError(t, os.WriteFile(clientCertificatePath, clientCertPEM, 0644))
-
-		clientCertificateKeyPair, err := tls.LoadX509KeyPair(clientCertificatePath, clientPrivateKeyPath)
-		assert.NoError(t, err)
-
-		invalidClientPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-		assert.NoError(t, err)
-
-		invalidClientCertTemplate := x509.Certificate{
-			SerialNumber: big.NewInt(4),
-			Subject: pkix.Name{
-				Organization: []string{"Invalid Client"},
-			},
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(24 * time.Hour),
-			KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-			BasicConstraintsValid: true,
-		}
-		invalidClientCertBytes, err := x509.CreateCertificate(rand.Reader, &invalidClientCertTemplate, &invalidClientCertTemplate, &invalidClientPrivateKey.PublicKey, invalidClientPrivateKey)
-		assert.NoError(t, err)
-		invalidClientCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: invalidClientCertBytes})
-		invalidClientKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(invalidClientPrivateKey)})
-
-		invalidClientCert, err := tls.X509KeyPair(invalidClientCertPEM, invalidClientKeyPEM)
-		assert.NoError(t, err)
-
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(clientCertPEM)
-		caCertPool.AppendCertsFromPEM(serverCertPEM)
+		chain := certs.Generate(t)
+		clientCertificateKeyPair := chain.Client.TLSCertificate()
+		invalidClientCert := certs.Generate(t).Client.TLSCertificate()
+		caCertPool := chain.CertPool()
 
 		certPathsConfigProvider := func(t *testing.T) *server.Config {
 			cfg, configErr := config.ProcessAndValidate[server.Config](config.WithPrefix(server.ConfigPrefix))
 			assert.NoError(t, configErr)
-			cfg.Key = serverPrivateKeyPath
-			cfg.Cert = serverCertificatePath
-			cfg.ClientCACerts = clientCaCertPaths
+			cfg.Key = chain.Path(certs.ServerKeyFile)
+			cfg.Cert = chain.Path(certs.ServerCertFile)
+			cfg.ClientCACerts = []string{chain.Path(certs.CACertFile)}
 			return cfg
 		}
 
@@ -482,8 +357,7 @@ func TestServer(t *testing.T) {
 
 		t.Run("when the server certificate is invalid it should fail to be created", func(t *testing.T) {
 			t.Parallel()
-			invalidCertPath := filepath.Join(tempDir, "invalid_cert.pem")
-			assert.NoError(t, os.WriteFile(invalidCertPath, []byte("invalid data"), 0644))
+			invalidCertPath := chain.WriteFile(t, "invalid_cert.pem", []byte("invalid data"))
 			for _, mode := range []server.TLSMode{server.TLSModeTLS, server.TLSModeMutualTLS} {
 				srv, err := server.New(server.WithConfigProvider(func() (*server.Config, error) {
 					cfg := certPathsConfigProvider(t)
@@ -498,8 +372,7 @@ func TestServer(t *testing.T) {
 
 		t.Run("when the server key is invalid it should fail to be created", func(t *testing.T) {
 			t.Parallel()
-			invalidKeyPath := filepath.Join(tempDir, "invalid_key.pem")
-			assert.NoError(t, os.WriteFile(invalidKeyPath, []byte("invalid data"), 0644))
+			invalidKeyPath := chain.WriteFile(t, "invalid_key.pem", []byte("invalid data"))
 			for _, mode := range []server.TLSMode{server.TLSModeTLS, server.TLSModeMutualTLS} {
 				srv, err := server.New(server.WithConfigProvider(func() (*server.Config, error) {
 					cfg := certPathsConfigProvider(t)
@@ -514,8 +387,7 @@ func TestServer(t *testing.T) {
 
 		t.Run("when the client CA is invalid it should fail to be created", func(t *testing.T) {
 			t.Parallel()
-			invalidCertPath := filepath.Join(tempDir, "invalid_ca.pem")
-			assert.NoError(t, os.WriteFile(invalidCertPath, []byte("invalid data"), 0644))
+			invalidCertPath := chain.WriteFile(t, "invalid_ca.pem", []byte("invalid data"))
 			srv, err := server.New(server.WithConfigProvider(func() (*server.Config, error) {
 				cfg := certPathsConfigProvider(t)
 				cfg.TLSMode = server.TLSModeMutualTLS
@@ -643,7 +515,7 @@ func TestServer(t *testing.T) {
 			request, err := http.NewRequest(http.MethodGet, "https://"+serverAddress, nil)
 			assert.NoError(t, err)
 			response, err := httpClient.Do(request)
-			assert.ErrorPart(t, err, "tls: certificate required")
+			assert.ErrorPart(t, err, "tls: unknown certificate authority")
 			assert.Nil(t, response)
 		})
 	})
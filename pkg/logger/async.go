@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy determines what an AsyncWriter does when its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the caller wait until there is room in the queue.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDrop discards the log line and increments the dropped count.
+	OverflowDrop
+)
+
+const (
+	// defaultAsyncQueueSize is the default amount of log lines an AsyncWriter can hold before overflowing.
+	defaultAsyncQueueSize = 1024
+)
+
+// asyncWriterConfig is configured by the AsyncWriterOption functions.
+type asyncWriterConfig struct {
+	queueSize      int
+	overflowPolicy OverflowPolicy
+}
+
+// AsyncWriterOption sets values on the asyncWriterConfig.
+type AsyncWriterOption func(*asyncWriterConfig)
+
+// WithQueueSize sets how many log lines can be queued before the overflow policy applies.
+func WithQueueSize(size int) AsyncWriterOption {
+	return func(c *asyncWriterConfig) {
+		c.queueSize = size
+	}
+}
+
+// WithOverflowPolicy sets what happens to a log line when the queue is full.
+func WithOverflowPolicy(policy OverflowPolicy) AsyncWriterOption {
+	return func(c *asyncWriterConfig) {
+		c.overflowPolicy = policy
+	}
+}
+
+// asyncMessage is an item in the queue of the AsyncWriter.
+// It either contains data to write or a channel to close once all prior data is written.
+type asyncMessage struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+// AsyncWriter is an io.Writer that queues the data and writes it to another io.Writer on a background goroutine.
+// It is meant to be used with SetOutput to remove the logging I/O from the caller.
+//
+//	writer := logger.NewAsyncWriter(os.Stdout)
+//	defer func() { _ = writer.Close() }()
+//	logger.SetOutput(writer)
+type AsyncWriter struct {
+	out            io.Writer
+	overflowPolicy OverflowPolicy
+	queue          chan asyncMessage
+	closeLock      sync.RWMutex
+	closed         bool
+	done           chan struct{}
+	dropped        atomic.Uint64
+	errLock        sync.Mutex
+	err            error
+}
+
+// NewAsyncWriter allocates an AsyncWriter and starts its background goroutine.
+// Close must be called to stop the goroutine.
+func NewAsyncWriter(out io.Writer, opts ...AsyncWriterOption) *AsyncWriter {
+	cfg := &asyncWriterConfig{
+		queueSize:      defaultAsyncQueueSize,
+		overflowPolicy: OverflowBlock,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.queueSize < 0 {
+		panic("The queue size of the async writer cannot be negative.")
+	}
+
+	writer := &AsyncWriter{
+		out:            out,
+		overflowPolicy: cfg.overflowPolicy,
+		queue:          make(chan asyncMessage, cfg.queueSize),
+		closeLock:      sync.RWMutex{},
+		closed:         false,
+		done:           make(chan struct{}),
+		dropped:        atomic.Uint64{},
+		errLock:        sync.Mutex{},
+		err:            nil,
+	}
+	go writer.run()
+	return writer
+}
+
+// run writes the queued data until the queue is closed.
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+	for message := range w.queue {
+		if message.flushed != nil {
+			close(message.flushed)
+			continue
+		}
+		if _, err := w.out.Write(message.data); err != nil {
+			w.errLock.Lock()
+			w.err = errors.Join(w.err, err)
+			w.errLock.Unlock()
+		}
+	}
+}
+
+// Write queues a copy of the data. Depending on the OverflowPolicy, it blocks or drops the data if the queue is full.
+// The returned error is only for a closed writer. Errors from the underlying writer are returned by Flush and Close.
+func (w *AsyncWriter) Write(data []byte) (int, error) {
+	w.closeLock.RLock()
+	defer w.closeLock.RUnlock()
+	if w.closed {
+		return 0, errors.New("the async writer is closed")
+	}
+
+	message := asyncMessage{
+		data:    append([]byte(nil), data...),
+		flushed: nil,
+	}
+	switch w.overflowPolicy {
+	case OverflowDrop:
+		select {
+		case w.queue <- message:
+		default:
+			w.dropped.Add(1)
+		}
+	default:
+		w.queue <- message
+	}
+
+	return len(data), nil
+}
+
+// Flush blocks until all the data queued before the call is written.
+// It returns the errors from the underlying writer since the last Flush.
+func (w *AsyncWriter) Flush() error {
+	w.closeLock.RLock()
+	if w.closed {
+		w.closeLock.RUnlock()
+		return errors.New("the async writer is closed")
+	}
+	flushed := make(chan struct{})
+	w.queue <- asyncMessage{
+		data:    nil,
+		flushed: flushed,
+	}
+	w.closeLock.RUnlock()
+	<-flushed
+	return w.takeErr()
+}
+
+// Close writes the remaining queued data and stops the background goroutine.
+// It returns the errors from the underlying writer since the last Flush.
+// Calling Close more than once does nothing.
+func (w *AsyncWriter) Close() error {
+	w.closeLock.Lock()
+	if w.closed {
+		w.closeLock.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.closeLock.Unlock()
+	<-w.done
+	return w.takeErr()
+}
+
+// Dropped returns how many writes were discarded because the queue was full.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// takeErr returns the accumulated write errors and resets them.
+func (w *AsyncWriter) takeErr() error {
+	w.errLock.Lock()
+	defer w.errLock.Unlock()
+	err := w.err
+	w.err = nil
+	return err
+}
@@ -0,0 +1,156 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/logger"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+type blockingWriter struct {
+	mu      sync.Mutex
+	unblock chan struct{}
+	output  bytes.Buffer
+	err     error
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	if w.unblock != nil {
+		<-w.unblock
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.output.Write(p)
+	return len(p), w.err
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.output.String()
+}
+
+func TestAsyncWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when data is written and flushed it should be in the output", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{}
+		writer := logger.NewAsyncWriter(out)
+		n, err := writer.Write([]byte("first\n"))
+		assert.NoError(t, err)
+		assert.Equals(t, n, len("first\n"))
+		_, err = writer.Write([]byte("second\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Flush())
+		assert.Equals(t, out.String(), "first\nsecond\n")
+		assert.NoError(t, writer.Close())
+	})
+
+	t.Run("when the writer is closed it should write the remaining data", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{}
+		writer := logger.NewAsyncWriter(out)
+		for i := 0; i < 100; i++ {
+			_, err := writer.Write([]byte("line\n"))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, writer.Close())
+		assert.Equals(t, out.String(), strings.Repeat("line\n", 100))
+	})
+
+	t.Run("when the buffer passed to write is reused it should not change the queued data", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{}
+		writer := logger.NewAsyncWriter(out)
+		buffer := []byte("first\n")
+		_, err := writer.Write(buffer)
+		assert.NoError(t, err)
+		copy(buffer, "xxxxx\n")
+		assert.NoError(t, writer.Close())
+		assert.Equals(t, out.String(), "first\n")
+	})
+
+	t.Run("when the writer is closed it should return an error on write and flush", func(t *testing.T) {
+		t.Parallel()
+		writer := logger.NewAsyncWriter(&blockingWriter{})
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, writer.Close())
+		_, err := writer.Write([]byte("data"))
+		assert.ErrorExact(t, err, "the async writer is closed")
+		assert.ErrorExact(t, writer.Flush(), "the async writer is closed")
+	})
+
+	t.Run("when the underlying writer fails it should return the error on flush", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{err: errors.New("write error")}
+		writer := logger.NewAsyncWriter(out)
+		_, err := writer.Write([]byte("data"))
+		assert.NoError(t, err)
+		assert.ErrorExact(t, writer.Flush(), "write error")
+		assert.NoError(t, writer.Flush())
+		_, err = writer.Write([]byte("data"))
+		assert.NoError(t, err)
+		assert.ErrorExact(t, writer.Close(), "write error")
+	})
+
+	t.Run("when the queue is full and the policy is drop it should count the dropped writes", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{unblock: make(chan struct{})}
+		writer := logger.NewAsyncWriter(out, logger.WithQueueSize(1), logger.WithOverflowPolicy(logger.OverflowDrop))
+		const writeCount = 10
+		for i := 0; i < writeCount; i++ {
+			_, err := writer.Write([]byte("data\n"))
+			assert.NoError(t, err)
+		}
+		assert.True(t, writer.Dropped() >= writeCount-2)
+		close(out.unblock)
+		assert.NoError(t, writer.Close())
+		assert.Equals(t, uint64(strings.Count(out.String(), "data\n"))+writer.Dropped(), uint64(writeCount))
+	})
+
+	t.Run("when the queue is full and the policy is block it should wait for room", func(t *testing.T) {
+		t.Parallel()
+		out := &blockingWriter{unblock: make(chan struct{})}
+		writer := logger.NewAsyncWriter(out, logger.WithQueueSize(1), logger.WithOverflowPolicy(logger.OverflowBlock))
+		const writeCount = 10
+		writesDone := make(chan struct{})
+		go func() {
+			for i := 0; i < writeCount; i++ {
+				_, err := writer.Write([]byte("data\n"))
+				assert.NoError(t, err, assert.Continue())
+			}
+			close(writesDone)
+		}()
+		close(out.unblock)
+		<-writesDone
+		assert.NoError(t, writer.Close())
+		assert.Equals(t, writer.Dropped(), uint64(0))
+		assert.Equals(t, out.String(), strings.Repeat("data\n", writeCount))
+	})
+
+	t.Run("when the queue size is negative it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			logger.NewAsyncWriter(&blockingWriter{}, logger.WithQueueSize(-1))
+		}, "The queue size of the async writer cannot be negative.")
+	})
+
+	t.Run("when it is used as the logger output it should write the log lines", func(t *testing.T) {
+		out := &blockingWriter{}
+		writer := logger.NewAsyncWriter(out)
+		logger.SetOutput(writer)
+		t.Cleanup(func() {
+			logger.SetOutput(os.Stdout)
+		})
+		logger.Error("async message")
+		assert.NoError(t, writer.Flush())
+		assert.Contains(t, out.String(), "async message")
+		assert.NoError(t, writer.Close())
+	})
+}
@@ -9,14 +9,24 @@ import (
 )
 
 // Config contains the values needed to configure the logger.
+// The log level and the log format are case-insensitive, and are validated when they are parsed by
+// ParseLevel and ParseFormat.
 type Config struct {
-	LogLevel string `config_format:"snake" config_default:"INFO" validate:"required,oneof=ERROR WARN INFO DEBUG TRACE"`
+	// LogLevel is the application log level. One of PANIC, FATAL, ERROR, WARN, INFO, DEBUG, or TRACE.
+	LogLevel string `config_format:"snake" config_default:"INFO" validate:"required"`
+
+	// LogLevelOverrides sets the log levels of named loggers. For example: "http=debug,migration=info".
+	LogLevelOverrides string `config_format:"snake" config_default:""`
+
+	// LogFormat selects the built-in formatter. One of DEFAULT or CONSOLE, which is meant for reading logs locally.
+	LogFormat Format `config_format:"snake" config_default:"DEFAULT" validate:"required"`
 }
 
 // loggerConfig is configured by the ConfigOption functions.
 type loggerConfig struct {
 	configProvider func() (*Config, error)
 	outputProvider func() (io.Writer, error)
+	format         *Format
 }
 
 // ConfigOption sets values on the loggerConfig.
@@ -36,6 +46,13 @@ func WithOutputProvider(provider func() (io.Writer, error)) ConfigOption {
 	}
 }
 
+// WithFormat sets the built-in formatter to use. It takes precedence over the LogFormat in the Config.
+func WithFormat(format Format) ConfigOption {
+	return func(c *loggerConfig) {
+		c.format = &format
+	}
+}
+
 // MustConfigure parses the Config and sets values for the application logger.
 func MustConfigure(opts ...ConfigOption) {
 	cfg := &loggerConfig{
@@ -45,6 +62,7 @@ func MustConfigure(opts ...ConfigOption) {
 		outputProvider: func() (io.Writer, error) {
 			return os.Stdout, nil
 		},
+		format: nil,
 	}
 
 	for _, opt := range opts {
@@ -62,6 +80,22 @@ func MustConfigure(opts ...ConfigOption) {
 	}
 	SetLevel(level)
 
+	nameToLevel, err := ParseNameLevels(envConf.LogLevelOverrides)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the log level overrides (%s).", err.Error()))
+	}
+	SetNameLevels(nameToLevel)
+
+	format := envConf.LogFormat
+	if cfg.format != nil {
+		format = *cfg.format
+	}
+	formatter, err := ParseFormat(format)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the log format (%s).", err.Error()))
+	}
+	SetFormatter(formatter)
+
 	output, err := cfg.outputProvider()
 	if err != nil {
 		panic(fmt.Sprintf("Failed to get logger output (%s).", err.Error()))
@@ -15,6 +15,8 @@ func TestMustConfigure(t *testing.T) {
 	t.Cleanup(func() {
 		SetOutput(os.Stdout)
 		SetLevel(LevelInfo)
+		SetFormatter(DefaultFormatter)
+		SetNameLevels(map[string]LogLevel{})
 	})
 
 	t.Run("when the config provider succeeds it sets the logger level", func(t *testing.T) {
@@ -66,4 +68,81 @@ func TestMustConfigure(t *testing.T) {
 		MustConfigure()
 		assert.Equals(t, appLogLevel, LevelInfo)
 	})
+
+	t.Run("when the config has the console format it should use the console formatter", func(t *testing.T) {
+		var outputBuffer bytes.Buffer
+		MustConfigure(WithConfigProvider(func() (*Config, error) {
+			return &Config{
+				LogLevel:  "info",
+				LogFormat: FormatConsole,
+			}, nil
+		}), WithOutputProvider(func() (io.Writer, error) {
+			return &outputBuffer, nil
+		}))
+		Error("test message")
+		assert.Contains(t, outputBuffer.String(), colorRed+"ERROR"+colorReset+" test message")
+	})
+
+	t.Run("when the format option is set it should take precedence over the config", func(t *testing.T) {
+		var outputBuffer bytes.Buffer
+		MustConfigure(WithConfigProvider(func() (*Config, error) {
+			return &Config{
+				LogLevel:  "info",
+				LogFormat: FormatDefault,
+			}, nil
+		}), WithOutputProvider(func() (io.Writer, error) {
+			return &outputBuffer, nil
+		}), WithFormat(FormatConsole))
+		Error("test message")
+		assert.Contains(t, outputBuffer.String(), colorRed+"ERROR"+colorReset+" test message")
+	})
+
+	t.Run("when the environment variables are lowercase it should accept them like the parsers do", func(t *testing.T) {
+		var outputBuffer bytes.Buffer
+		t.Setenv("LOG_LEVEL", "error")
+		t.Setenv("LOG_FORMAT", "console")
+		MustConfigure(WithOutputProvider(func() (io.Writer, error) {
+			return &outputBuffer, nil
+		}))
+		assert.Equals(t, appLogLevel, LevelError)
+		Error("test message")
+		assert.Contains(t, outputBuffer.String(), colorRed+"ERROR"+colorReset+" test message")
+	})
+
+	t.Run("when the environment variables set the panic or fatal level it should accept them", func(t *testing.T) {
+		t.Setenv("LOG_LEVEL", "PANIC")
+		MustConfigure()
+		assert.Equals(t, appLogLevel, LevelPanic)
+		t.Setenv("LOG_LEVEL", "fatal")
+		MustConfigure()
+		assert.Equals(t, appLogLevel, LevelFatal)
+	})
+
+	t.Run("when the format is incorrect it should panic", func(t *testing.T) {
+		assert.PanicExact(t, func() {
+			MustConfigure(WithFormat("incorrect"))
+		}, "Failed to parse the log format (invalid log format: incorrect).")
+	})
+
+	t.Run("when the config has level overrides it should set the name levels", func(t *testing.T) {
+		MustConfigure(WithConfigProvider(func() (*Config, error) {
+			return &Config{
+				LogLevel:          "info",
+				LogLevelOverrides: "http=debug",
+			}, nil
+		}))
+		assert.Equals(t, GetNameLevel("http"), LevelDebug)
+		assert.Equals(t, GetNameLevel("other"), LevelInfo)
+	})
+
+	t.Run("when the level overrides are incorrect it should panic", func(t *testing.T) {
+		assert.PanicExact(t, func() {
+			MustConfigure(WithConfigProvider(func() (*Config, error) {
+				return &Config{
+					LogLevel:          "info",
+					LogLevelOverrides: "http=incorrect",
+				}, nil
+			}))
+		}, "Failed to parse the log level overrides (invalid log level: incorrect).")
+	})
 }
@@ -34,6 +34,12 @@ func AddField(ctx *context.Context, key string, value any) Logger {
 	}
 }
 
+// AddName adds the name of a named logger to the context. The Loggers returned by AddField, AddFields,
+// and FromCtx for the context then use the level set for the name with SetNameLevels.
+func AddName(ctx *context.Context, name string) Logger {
+	return AddField(ctx, NameField, name)
+}
+
 // AddFields adds many fields to the context for the logger.
 func AddFields(ctx *context.Context, fieldsToAdd map[string]any) Logger {
 	fieldsNotCast := (*ctx).Value(contextKey)
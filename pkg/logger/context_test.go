@@ -20,7 +20,7 @@ func TestLoggerContext(t *testing.T) {
 			SetOutput(os.Stdout)
 		})
 		fieldsMap := make(map[string]any)
-		SetFormatter(func(fields map[string]any, msg string) string {
+		SetFormatter(func(level LogLevel, fields map[string]any, msg string) string {
 			maps.Copy(fieldsMap, fields)
 			return msg
 		})
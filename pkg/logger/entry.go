@@ -17,146 +17,176 @@ type entry struct {
 	fields map[string]any
 }
 
+// enabled returns true if the level is allowed for the entry. The lock must be held by the caller.
+// A level set for the name of the entry, in its NameField, takes precedence over the application log level.
+func (l *entry) enabled(level LogLevel) bool {
+	if name, isNamed := l.fields[NameField].(string); isNamed {
+		if nameLevel, hasNameLevel := appNameToLevel[name]; hasNameLevel {
+			return nameLevel >= level
+		}
+	}
+	return appLogLevel >= level
+}
+
+// write formats the message and writes it unless a processor dropped it.
+func (l *entry) write(level LogLevel, msg string) {
+	if formatted, keep := formatLog(level, l.fields, msg); keep {
+		appLogger.Println(formatted)
+	}
+}
+
+// mustFormat formats the message for entries that cannot be dropped.
+// If a processor drops the record, the original fields and message are formatted instead.
+// The lock is released before returning so the caller can panic or exit without holding it.
+func (l *entry) mustFormat(level LogLevel, msg string) string {
+	lock.RLock()
+	defer lock.RUnlock()
+	if formatted, keep := formatLog(level, l.fields, msg); keep {
+		return formatted
+	}
+	return appLogFormatter(level, redactFields(l.fields), msg)
+}
+
 func (l *entry) Panic(args ...any) {
-	appLogger.Panicln(formatLog(l.fields, fmt.Sprint(args...)))
+	appLogger.Panicln(l.mustFormat(LevelPanic, fmt.Sprint(args...)))
 }
 
 func (l *entry) Panicf(format string, args ...any) {
-	appLogger.Panicln(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	appLogger.Panicln(l.mustFormat(LevelPanic, fmt.Sprintf(format, args...)))
 }
 
 func (l *entry) PanicFn(fn LogFn) {
-	appLogger.Panicln(formatLog(l.fields, fmt.Sprint(fn()...)))
+	appLogger.Panicln(l.mustFormat(LevelPanic, fmt.Sprint(fn()...)))
 }
 
 func (l *entry) Fatal(args ...any) {
-	appLogger.Fatalln(formatLog(l.fields, fmt.Sprint(args...)))
+	appLogger.Fatalln(l.mustFormat(LevelFatal, fmt.Sprint(args...)))
 }
 
 func (l *entry) Fatalf(format string, args ...any) {
-	appLogger.Fatalln(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	appLogger.Fatalln(l.mustFormat(LevelFatal, fmt.Sprintf(format, args...)))
 }
 
 func (l *entry) FatalFn(fn LogFn) {
-	appLogger.Fatalln(formatLog(l.fields, fmt.Sprint(fn()...)))
+	appLogger.Fatalln(l.mustFormat(LevelFatal, fmt.Sprint(fn()...)))
 }
 
 func (l *entry) Error(args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelError {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(args...)))
+	if l.enabled(LevelError) {
+		l.write(LevelError, fmt.Sprint(args...))
 	}
 }
 
 func (l *entry) Errorf(format string, args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelError {
-		appLogger.Println(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	if l.enabled(LevelError) {
+		l.write(LevelError, fmt.Sprintf(format, args...))
 	}
 }
 
 func (l *entry) ErrorFn(fn LogFn) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelError {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(fn()...)))
+	if l.enabled(LevelError) {
+		l.write(LevelError, fmt.Sprint(fn()...))
 	}
 }
 
 func (l *entry) Warn(args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelWarn {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(args...)))
+	if l.enabled(LevelWarn) {
+		l.write(LevelWarn, fmt.Sprint(args...))
 	}
 }
 
 func (l *entry) Warnf(format string, args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelWarn {
-		appLogger.Println(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	if l.enabled(LevelWarn) {
+		l.write(LevelWarn, fmt.Sprintf(format, args...))
 	}
 }
 
 func (l *entry) WarnFn(fn LogFn) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelWarn {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(fn()...)))
+	if l.enabled(LevelWarn) {
+		l.write(LevelWarn, fmt.Sprint(fn()...))
 	}
 }
 
 func (l *entry) Info(args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelInfo {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(args...)))
+	if l.enabled(LevelInfo) {
+		l.write(LevelInfo, fmt.Sprint(args...))
 	}
 }
 
 func (l *entry) Infof(format string, args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelInfo {
-		appLogger.Println(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	if l.enabled(LevelInfo) {
+		l.write(LevelInfo, fmt.Sprintf(format, args...))
 	}
 }
 
 func (l *entry) InfoFn(fn LogFn) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelInfo {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(fn()...)))
+	if l.enabled(LevelInfo) {
+		l.write(LevelInfo, fmt.Sprint(fn()...))
 	}
 }
 
 func (l *entry) Debug(args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelDebug {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(args...)))
+	if l.enabled(LevelDebug) {
+		l.write(LevelDebug, fmt.Sprint(args...))
 	}
 }
 
 func (l *entry) Debugf(format string, args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelDebug {
-		appLogger.Println(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	if l.enabled(LevelDebug) {
+		l.write(LevelDebug, fmt.Sprintf(format, args...))
 	}
 }
 
 func (l *entry) DebugFn(fn LogFn) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelDebug {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(fn()...)))
+	if l.enabled(LevelDebug) {
+		l.write(LevelDebug, fmt.Sprint(fn()...))
 	}
 }
 
 func (l *entry) Trace(args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelTrace {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(args...)))
+	if l.enabled(LevelTrace) {
+		l.write(LevelTrace, fmt.Sprint(args...))
 	}
 }
 
 func (l *entry) Tracef(format string, args ...any) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelTrace {
-		appLogger.Println(formatLog(l.fields, fmt.Sprintf(format, args...)))
+	if l.enabled(LevelTrace) {
+		l.write(LevelTrace, fmt.Sprintf(format, args...))
 	}
 }
 
 func (l *entry) TraceFn(fn LogFn) {
 	lock.RLock()
 	defer lock.RUnlock()
-	if appLogLevel >= LevelTrace {
-		appLogger.Println(formatLog(l.fields, fmt.Sprint(fn()...)))
+	if l.enabled(LevelTrace) {
+		l.write(LevelTrace, fmt.Sprint(fn()...))
 	}
 }
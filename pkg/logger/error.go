@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	codederrors "github.com/TriangleSide/GoTools/pkg/errors"
+)
+
+const (
+	// ErrorField is the field key that holds the error added with AddError.
+	ErrorField = "error"
+)
+
+// AddError adds the error to the context for the logger. Errors from the errors package are added as a group
+// with their message, code, metadata, and stack, so redacted keys in the metadata are masked.
+// Other errors are added as their message.
+func AddError(ctx *context.Context, err error) Logger {
+	return AddField(ctx, ErrorField, errorFieldValue(err))
+}
+
+// errorFieldValue returns the value of the error field.
+func errorFieldValue(err error) any {
+	if err == nil {
+		return nil
+	}
+	var codedErr *codederrors.Error
+	if !errors.As(err, &codedErr) {
+		return err.Error()
+	}
+	group := map[string]any{
+		"message": err.Error(),
+		"code":    string(codedErr.Code()),
+	}
+	if metadata := codederrors.MetadataOf(err); len(metadata) > 0 {
+		group["metadata"] = metadata
+	}
+	if stack := codedErr.Stack(); len(stack) > 0 {
+		frames := make([]string, 0, len(stack))
+		for _, frame := range stack {
+			frames = append(frames, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		group["stack"] = frames
+	}
+	return group
+}
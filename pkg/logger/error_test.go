@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"strings"
+	"testing"
+
+	codederrors "github.com/TriangleSide/GoTools/pkg/errors"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestAddError(t *testing.T) {
+	recordFields := func(t *testing.T) map[string]any {
+		t.Helper()
+		SetOutput(&strings.Builder{})
+		t.Cleanup(func() {
+			SetOutput(os.Stdout)
+		})
+		fieldsMap := make(map[string]any)
+		SetFormatter(func(level LogLevel, fields map[string]any, msg string) string {
+			maps.Copy(fieldsMap, fields)
+			return msg
+		})
+		t.Cleanup(func() {
+			SetFormatter(DefaultFormatter)
+		})
+		return fieldsMap
+	}
+
+	t.Run("when a standard error is added it should log its message", func(t *testing.T) {
+		fieldsMap := recordFields(t)
+		ctx := context.Background()
+		AddError(&ctx, errors.New("standard error")).Error("msg")
+		assert.Equals(t, fieldsMap, map[string]any{ErrorField: "standard error"})
+	})
+
+	t.Run("when a nil error is added it should log a nil field", func(t *testing.T) {
+		fieldsMap := recordFields(t)
+		ctx := context.Background()
+		AddError(&ctx, nil).Error("msg")
+		assert.Equals(t, fieldsMap, map[string]any{ErrorField: nil})
+	})
+
+	t.Run("when a coded error is added it should log a group with its code and redacted metadata", func(t *testing.T) {
+		fieldsMap := recordFields(t)
+		ctx := context.Background()
+		err := codederrors.New(codederrors.CodeUnauthenticated, "invalid login",
+			codederrors.WithMetadata("user", "alice"),
+			codederrors.WithMetadata("password", "secret"))
+		AddError(&ctx, err).Error("msg")
+		assert.Equals(t, fieldsMap, map[string]any{ErrorField: map[string]any{
+			"message":  "invalid login",
+			"code":     "UNAUTHENTICATED",
+			"metadata": map[string]any{"user": "alice", "password": RedactedValue},
+		}})
+	})
+
+	t.Run("when a coded error has a stack it should log the frames", func(t *testing.T) {
+		fieldsMap := recordFields(t)
+		ctx := context.Background()
+		AddError(&ctx, codederrors.New(codederrors.CodeInternal, "failure", codederrors.WithStack())).Error("msg")
+		group, isGroup := fieldsMap[ErrorField].(map[string]any)
+		assert.True(t, isGroup)
+		frames, isFrames := group["stack"].([]string)
+		assert.True(t, isFrames)
+		assert.True(t, len(frames) > 0)
+		assert.Contains(t, frames[0], "error_test.go:")
+	})
+}
@@ -3,6 +3,8 @@ package logger
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,15 +16,49 @@ func SetOutput(out io.Writer) {
 	appLogger.SetOutput(out)
 }
 
-// FormatterFunc defines a function type that takes in a map of fields and a message string
+// GetOutput returns the writer of the application logger.
+func GetOutput() io.Writer {
+	lock.RLock()
+	defer lock.RUnlock()
+	return appLogger.Writer()
+}
+
+// FormatterFunc defines a function type that takes in the level, a map of fields, and a message string
 // and returns a formatted log string. This allows for customizable log formatting.
-type FormatterFunc func(fields map[string]any, msg string) string
+//
+// Breaking change: the level was added as the first parameter. A formatter written for the previous
+// func(fields map[string]any, msg string) string signature can be adapted by ignoring the level.
+type FormatterFunc func(level LogLevel, fields map[string]any, msg string) string
+
+// Format is the name of a built-in formatter.
+type Format string
+
+const (
+	// FormatDefault selects the DefaultFormatter.
+	FormatDefault Format = "DEFAULT"
+
+	// FormatConsole selects the ConsoleFormatter.
+	FormatConsole Format = "CONSOLE"
+)
+
+// ParseFormat returns the built-in FormatterFunc for a Format.
+// An empty format is treated as FormatDefault.
+func ParseFormat(format Format) (FormatterFunc, error) {
+	switch Format(strings.ToUpper(string(format))) {
+	case "", FormatDefault:
+		return DefaultFormatter, nil
+	case FormatConsole:
+		return ConsoleFormatter, nil
+	default:
+		return nil, fmt.Errorf("invalid log format: %s", format)
+	}
+}
 
 // appLogFormatter holds the current log formatter function.
 var appLogFormatter FormatterFunc = DefaultFormatter
 
 // DefaultFormatter formats the log entry.
-func DefaultFormatter(fields map[string]any, msg string) string {
+func DefaultFormatter(_ LogLevel, fields map[string]any, msg string) string {
 	timestamp := time.Now().UTC().Format(time.DateTime)
 	fieldsSb := strings.Builder{}
 	for k, v := range fields {
@@ -31,6 +67,71 @@ func DefaultFormatter(fields map[string]any, msg string) string {
 	return fmt.Sprintf("%s %s%s", timestamp, fieldsSb.String(), msg)
 }
 
+const (
+	// colorReset resets the terminal color.
+	colorReset = "\033[0m"
+
+	// colorRed, colorYellow, colorGreen, colorBlue, and colorGray are the terminal colors of the levels.
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+	colorBlue   = "\033[34m"
+	colorGray   = "\033[90m"
+)
+
+// levelColor returns the terminal color of a log level.
+func levelColor(level LogLevel) string {
+	switch level {
+	case LevelPanic, LevelFatal, LevelError:
+		return colorRed
+	case LevelWarn:
+		return colorYellow
+	case LevelInfo:
+		return colorGreen
+	case LevelDebug:
+		return colorBlue
+	default:
+		return colorGray
+	}
+}
+
+// ConsoleFormatter formats the log entry to be read by a human in a terminal.
+// The level is colorized and the fields are sorted by key and written as compact key=value pairs.
+// Values that contain spaces or quotes are quoted.
+func ConsoleFormatter(level LogLevel, fields map[string]any, msg string) string {
+	sb := strings.Builder{}
+	sb.WriteString(colorGray)
+	sb.WriteString(time.Now().Format(time.TimeOnly))
+	sb.WriteString(colorReset)
+	sb.WriteString(" ")
+	sb.WriteString(levelColor(level))
+	sb.WriteString(fmt.Sprintf("%-5s", level.String()))
+	sb.WriteString(colorReset)
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := fmt.Sprintf("%v", fields[key])
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(colorGray)
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(colorReset)
+		sb.WriteString(value)
+	}
+
+	return sb.String()
+}
+
 // SetFormatter sets a custom log formatter function.
 // It replaces the default log formatter with the provided one.
 func SetFormatter(formatter FormatterFunc) {
@@ -39,7 +140,24 @@ func SetFormatter(formatter FormatterFunc) {
 	appLogFormatter = formatter
 }
 
+// GetFormatter returns the current log formatter function.
+func GetFormatter() FormatterFunc {
+	lock.RLock()
+	defer lock.RUnlock()
+	return appLogFormatter
+}
+
 // formatLog formats the log message using the fields in the context and the provided message.
-func formatLog(fields map[string]any, msg string) string {
-	return appLogFormatter(fields, msg)
+// The processors are run first, and false is returned if one of them dropped the entry.
+// The values of redacted fields are masked before being passed to the formatter.
+func formatLog(level LogLevel, fields map[string]any, msg string) (string, bool) {
+	record := &Record{
+		Level:   level,
+		Fields:  fields,
+		Message: msg,
+	}
+	if !processRecord(record) {
+		return "", false
+	}
+	return appLogFormatter(record.Level, redactFields(record.Fields), record.Message), true
 }
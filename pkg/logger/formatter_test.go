@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"testing"
 
 	"github.com/TriangleSide/GoTools/pkg/test/assert"
@@ -9,7 +11,8 @@ import (
 
 func TestFormatter(t *testing.T) {
 	t.Run("when the fields are nil it should format without fields", func(t *testing.T) {
-		msg := formatLog(nil, "test message")
+		msg, written := formatLog(LevelInfo, nil, "test message")
+		assert.True(t, written)
 		assert.Contains(t, msg, "test message")
 	})
 
@@ -20,7 +23,8 @@ func TestFormatter(t *testing.T) {
 			"key2": 2,
 		})
 		testEntry := testLogger.(*entry)
-		msg := formatLog(testEntry.fields, "test message")
+		msg, written := formatLog(LevelInfo, testEntry.fields, "test message")
+		assert.True(t, written)
 		assert.Contains(t, msg, "key1=value1")
 		assert.Contains(t, msg, "key2=2")
 	})
@@ -29,10 +33,78 @@ func TestFormatter(t *testing.T) {
 		t.Cleanup(func() {
 			SetFormatter(DefaultFormatter)
 		})
-		SetFormatter(func(fields map[string]any, msg string) string {
+		SetFormatter(func(level LogLevel, fields map[string]any, msg string) string {
 			return "custom: " + msg
 		})
-		msg := formatLog(nil, "test message")
+		msg, written := formatLog(LevelInfo, nil, "test message")
+		assert.True(t, written)
 		assert.Contains(t, msg, "custom: test message")
+		msg = GetFormatter()(LevelInfo, nil, "other message")
+		assert.Equals(t, msg, "custom: other message")
+	})
+
+	t.Run("when SetOutput is called it should be returned by GetOutput", func(t *testing.T) {
+		previous := GetOutput()
+		t.Cleanup(func() {
+			SetOutput(previous)
+		})
+		buffer := &bytes.Buffer{}
+		SetOutput(buffer)
+		assert.Equals(t, GetOutput(), io.Writer(buffer))
+		Error("test message")
+		assert.Contains(t, buffer.String(), "test message")
+	})
+
+	t.Run("when the console formatter is used it should colorize the level and sort the fields", func(t *testing.T) {
+		msg := ConsoleFormatter(LevelWarn, map[string]any{
+			"b":   2,
+			"a":   "value",
+			"key": "has space",
+		}, "test message")
+		assert.Contains(t, msg, colorYellow+"WARN "+colorReset+" test message")
+		assert.Contains(t, msg, "a="+colorReset+"value "+colorGray+"b="+colorReset+"2 "+colorGray+"key="+colorReset+`"has space"`)
+	})
+
+	t.Run("when the console formatter is used it should use a different color per level", func(t *testing.T) {
+		testCases := []struct {
+			level LogLevel
+			color string
+		}{
+			{LevelPanic, colorRed},
+			{LevelFatal, colorRed},
+			{LevelError, colorRed},
+			{LevelWarn, colorYellow},
+			{LevelInfo, colorGreen},
+			{LevelDebug, colorBlue},
+			{LevelTrace, colorGray},
+		}
+		for _, testCase := range testCases {
+			msg := ConsoleFormatter(testCase.level, nil, "test message")
+			assert.Contains(t, msg, testCase.color+testCase.level.String())
+		}
+	})
+
+	t.Run("when a format is parsed it should return the matching formatter", func(t *testing.T) {
+		testCases := []struct {
+			format   Format
+			expected string
+			hasError bool
+		}{
+			{"", "test message", false},
+			{FormatDefault, "test message", false},
+			{"console", colorReset + " test message", false},
+			{FormatConsole, colorReset + " test message", false},
+			{"invalid", "", true},
+		}
+		for _, testCase := range testCases {
+			formatter, err := ParseFormat(testCase.format)
+			if testCase.hasError {
+				assert.ErrorExact(t, err, "invalid log format: "+string(testCase.format))
+				assert.Nil(t, formatter)
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, formatter(LevelInfo, nil, "test message"), testCase.expected)
+		}
 	})
 }
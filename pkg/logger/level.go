@@ -9,7 +9,11 @@ import (
 type LogLevel int
 
 const (
-	LevelError LogLevel = iota
+	// LevelPanic and LevelFatal are used to label entries that panic or exit the application.
+	// They are always logged regardless of the configured log level.
+	LevelPanic LogLevel = iota - 2
+	LevelFatal
+	LevelError
 	LevelWarn
 	LevelInfo
 	LevelDebug
@@ -19,6 +23,9 @@ const (
 // appLogLevel is the configured log level for the application.
 var appLogLevel = LevelInfo
 
+// appNameToLevel holds the log levels that override appLogLevel for named loggers.
+var appNameToLevel = map[string]LogLevel{}
+
 // SetLevel sets the application log level.
 func SetLevel(level LogLevel) {
 	lock.Lock()
@@ -33,9 +40,58 @@ func GetLevel() LogLevel {
 	return appLogLevel
 }
 
+// SetNameLevels sets the log levels of named loggers. A named logger uses its level instead of the
+// application log level. Calling it with an empty map removes all the overrides.
+func SetNameLevels(nameToLevel map[string]LogLevel) {
+	lock.Lock()
+	defer lock.Unlock()
+	appNameToLevel = make(map[string]LogLevel, len(nameToLevel))
+	for name, level := range nameToLevel {
+		appNameToLevel[name] = level
+	}
+}
+
+// GetNameLevel returns the log level of a named logger. If the name has no override, the application level is returned.
+func GetNameLevel(name string) LogLevel {
+	lock.RLock()
+	defer lock.RUnlock()
+	if level, hasLevel := appNameToLevel[name]; hasLevel {
+		return level
+	}
+	return appLogLevel
+}
+
+// ParseNameLevels parses a comma separated list of name=level pairs. For example: "http=debug,migration=info".
+func ParseNameLevels(nameLevels string) (map[string]LogLevel, error) {
+	nameToLevel := make(map[string]LogLevel)
+	if strings.TrimSpace(nameLevels) == "" {
+		return nameToLevel, nil
+	}
+	for _, nameLevel := range strings.Split(nameLevels, ",") {
+		name, levelStr, hasSeparator := strings.Cut(nameLevel, "=")
+		name = strings.TrimSpace(name)
+		if !hasSeparator || name == "" {
+			return nil, fmt.Errorf("invalid name level: %s", nameLevel)
+		}
+		if _, alreadyFound := nameToLevel[name]; alreadyFound {
+			return nil, fmt.Errorf("duplicate name level: %s", name)
+		}
+		level, err := ParseLevel(strings.TrimSpace(levelStr))
+		if err != nil {
+			return nil, err
+		}
+		nameToLevel[name] = level
+	}
+	return nameToLevel, nil
+}
+
 // String converts a LogLevel to its string representation.
 func (l LogLevel) String() string {
 	switch l {
+	case LevelPanic:
+		return "PANIC"
+	case LevelFatal:
+		return "FATAL"
 	case LevelError:
 		return "ERROR"
 	case LevelWarn:
@@ -54,6 +110,10 @@ func (l LogLevel) String() string {
 // ParseLevel parses a string into a LogLevel.
 func ParseLevel(level string) (LogLevel, error) {
 	switch strings.ToUpper(level) {
+	case "PANIC":
+		return LevelPanic, nil
+	case "FATAL":
+		return LevelFatal, nil
 	case "ERROR":
 		return LevelError, nil
 	case "WARN":
@@ -13,6 +13,8 @@ func TestLogLevel(t *testing.T) {
 			level    logger.LogLevel
 			expected string
 		}{
+			{logger.LevelPanic, "PANIC"},
+			{logger.LevelFatal, "FATAL"},
 			{logger.LevelError, "ERROR"},
 			{logger.LevelWarn, "WARN"},
 			{logger.LevelInfo, "INFO"},
@@ -33,6 +35,8 @@ func TestLogLevel(t *testing.T) {
 			expected logger.LogLevel
 			hasError bool
 		}{
+			{"PANIC", logger.LevelPanic, false},
+			{"FATAL", logger.LevelFatal, false},
 			{"ERROR", logger.LevelError, false},
 			{"WARN", logger.LevelWarn, false},
 			{"INFO", logger.LevelInfo, false},
@@ -77,6 +81,8 @@ func TestLogLevel(t *testing.T) {
 			expected logger.LogLevel
 			hasError bool
 		}{
+			{"PANIC", logger.LevelPanic, false},
+			{"FATAL", logger.LevelFatal, false},
 			{"ERROR", logger.LevelError, false},
 			{"WARN", logger.LevelWarn, false},
 			{"INFO", logger.LevelInfo, false},
@@ -96,4 +102,42 @@ func TestLogLevel(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("when parsing name levels", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected map[string]logger.LogLevel
+			err      string
+		}{
+			{"", map[string]logger.LogLevel{}, ""},
+			{"  ", map[string]logger.LogLevel{}, ""},
+			{"http=debug", map[string]logger.LogLevel{"http": logger.LevelDebug}, ""},
+			{" http = DEBUG , migration=info", map[string]logger.LogLevel{"http": logger.LevelDebug, "migration": logger.LevelInfo}, ""},
+			{"http", nil, "invalid name level: http"},
+			{"=debug", nil, "invalid name level: =debug"},
+			{"http=debug,http=info", nil, "duplicate name level: http"},
+			{"http=invalid", nil, "invalid log level: invalid"},
+		}
+
+		for _, testCase := range testCases {
+			nameToLevel, err := logger.ParseNameLevels(testCase.input)
+			if testCase.err != "" {
+				assert.ErrorExact(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equals(t, nameToLevel, testCase.expected)
+		}
+	})
+
+	t.Run("when name levels are set it should return them for the name", func(t *testing.T) {
+		t.Cleanup(func() {
+			logger.SetNameLevels(map[string]logger.LogLevel{})
+			logger.SetLevel(logger.LevelInfo)
+		})
+		logger.SetLevel(logger.LevelWarn)
+		logger.SetNameLevels(map[string]logger.LogLevel{"http": logger.LevelTrace})
+		assert.Equals(t, logger.GetNameLevel("http"), logger.LevelTrace)
+		assert.Equals(t, logger.GetNameLevel("other"), logger.LevelWarn)
+	})
 }
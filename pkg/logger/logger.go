@@ -5,6 +5,11 @@ var (
 	appEntry Logger = &entry{}
 )
 
+const (
+	// NameField is the field key that holds the name of a named logger.
+	NameField = "logger"
+)
+
 // LogFn is used by the Logger and is invoked selectively when the log level is allowed.
 type LogFn func() []any
 
@@ -33,6 +38,15 @@ type Logger interface {
 	TraceFn(fn LogFn)
 }
 
+// Named returns a Logger with a name. The name is added as a field, and the log level set for
+// the name with SetNameLevels takes precedence over the application log level.
+// Use AddName to keep the name in a context along with its other fields.
+func Named(name string) Logger {
+	return &entry{
+		fields: map[string]any{NameField: name},
+	}
+}
+
 func Panic(args ...any) {
 	appEntry.Panic(args...)
 }
@@ -34,7 +34,7 @@ func TestLogger(t *testing.T) {
 	setAndRecordOutput := func() *bytes.Buffer {
 		var output bytes.Buffer
 		logger.SetOutput(&output)
-		logger.SetFormatter(func(fields map[string]any, msg string) string {
+		logger.SetFormatter(func(level logger.LogLevel, fields map[string]any, msg string) string {
 			return msg
 		})
 		return &output
@@ -95,6 +95,63 @@ func TestLogger(t *testing.T) {
 	})
 }
 
+func TestNamedLogger(t *testing.T) {
+	var output bytes.Buffer
+	var recordedFields map[string]any
+	logger.SetOutput(&output)
+	logger.SetFormatter(func(level logger.LogLevel, fields map[string]any, msg string) string {
+		recordedFields = fields
+		return msg
+	})
+	t.Cleanup(func() {
+		logger.SetOutput(os.Stdout)
+		logger.SetFormatter(logger.DefaultFormatter)
+		logger.SetLevel(logger.LevelInfo)
+		logger.SetNameLevels(map[string]logger.LogLevel{})
+	})
+
+	logger.SetLevel(logger.LevelInfo)
+	logger.SetNameLevels(map[string]logger.LogLevel{
+		"http":      logger.LevelDebug,
+		"migration": logger.LevelError,
+	})
+
+	t.Run("when a named logger has a level override it should use it", func(t *testing.T) {
+		output.Reset()
+		logger.Named("http").Debug("D")
+		logger.Named("migration").Warn("W")
+		logger.Named("migration").Error("E")
+		assert.Equals(t, strings.ReplaceAll(output.String(), "\n", ""), "DE")
+	})
+
+	t.Run("when a named logger has no level override it should use the application level", func(t *testing.T) {
+		output.Reset()
+		logger.Named("other").Debug("D")
+		logger.Named("other").Info("I")
+		logger.Debug("D")
+		assert.Equals(t, strings.ReplaceAll(output.String(), "\n", ""), "I")
+	})
+
+	t.Run("when a named logger logs it should include the name and the context fields", func(t *testing.T) {
+		ctx := context.Background()
+		logger.AddField(&ctx, "key", "value")
+		logger.AddName(&ctx, "http")
+		logger.FromCtx(ctx).Info("I")
+		assert.Equals(t, recordedFields, map[string]any{"key": "value", logger.NameField: "http"})
+	})
+
+	t.Run("when fields are added after the name it should keep the level override of the name", func(t *testing.T) {
+		output.Reset()
+		ctx := context.Background()
+		logger.AddName(&ctx, "http").Debug("A")
+		logger.AddField(&ctx, "key", "value").Debug("B")
+		logger.AddFields(&ctx, map[string]any{"other": "value"}).Debug("C")
+		logger.FromCtx(ctx).Debug("D")
+		logger.FromCtx(context.Background()).Debug("E")
+		assert.Equals(t, strings.ReplaceAll(output.String(), "\n", ""), "ABCD")
+	})
+}
+
 func testFatalScenario(t *testing.T, uniqueEnvName string, testName string, fatalCallback func()) {
 	t.Helper()
 	t.Parallel()
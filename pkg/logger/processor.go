@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"maps"
+)
+
+// Record is a log entry before it is formatted and written.
+type Record struct {
+	Level   LogLevel
+	Fields  map[string]any
+	Message string
+}
+
+// Processor is invoked on every Record before it is formatted. It can mutate or enrich the Record
+// by changing its fields and message. Returning false drops the Record and nothing is written.
+// Records for LevelPanic and LevelFatal cannot be dropped since the application still panics or exits.
+type Processor func(record *Record) bool
+
+var (
+	// appProcessors are invoked in order on each Record.
+	appProcessors []Processor
+)
+
+// AddProcessor appends a Processor to the end of the processing pipeline.
+func AddProcessor(processor Processor) {
+	lock.Lock()
+	defer lock.Unlock()
+	appProcessors = append(appProcessors, processor)
+}
+
+// SetProcessors replaces the processing pipeline with the provided processors.
+// Calling it without processors removes all of them.
+func SetProcessors(processors ...Processor) {
+	lock.Lock()
+	defer lock.Unlock()
+	appProcessors = append([]Processor(nil), processors...)
+}
+
+// processRecord runs the processors on the Record. It returns false if the Record was dropped.
+// The fields are copied first since they are shared with the context they came from.
+func processRecord(record *Record) bool {
+	if len(appProcessors) == 0 {
+		return true
+	}
+	fieldsCopy := make(map[string]any, len(record.Fields))
+	maps.Copy(fieldsCopy, record.Fields)
+	record.Fields = fieldsCopy
+	for _, processor := range appProcessors {
+		if !processor(record) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/logger"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestProcessors(t *testing.T) {
+	setAndRecordOutput := func(t *testing.T) (*bytes.Buffer, *[]map[string]any) {
+		t.Helper()
+		var output bytes.Buffer
+		logger.SetOutput(&output)
+		recordedFields := make([]map[string]any, 0)
+		logger.SetFormatter(func(level logger.LogLevel, fields map[string]any, msg string) string {
+			recordedFields = append(recordedFields, fields)
+			return level.String() + " " + msg
+		})
+		t.Cleanup(func() {
+			logger.SetOutput(os.Stdout)
+			logger.SetFormatter(logger.DefaultFormatter)
+			logger.SetProcessors()
+		})
+		return &output, &recordedFields
+	}
+
+	t.Run("when a processor enriches a record it should be included in the output", func(t *testing.T) {
+		output, recordedFields := setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Fields["hostname"] = "test-host"
+			record.Message = "enriched " + record.Message
+			return true
+		})
+		logger.Error("msg")
+		assert.Equals(t, strings.TrimSpace(output.String()), "ERROR enriched msg")
+		assert.Equals(t, *recordedFields, []map[string]any{{"hostname": "test-host"}})
+	})
+
+	t.Run("when a processor drops a record it should not be written", func(t *testing.T) {
+		output, _ := setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			return record.Message != "drop"
+		})
+		logger.Error("drop")
+		logger.Error("keep")
+		assert.Equals(t, strings.TrimSpace(output.String()), "ERROR keep")
+	})
+
+	t.Run("when a processor drops a record the next processors should not be invoked", func(t *testing.T) {
+		_, _ = setAndRecordOutput(t)
+		secondCalled := false
+		logger.SetProcessors(func(record *logger.Record) bool {
+			return false
+		}, func(record *logger.Record) bool {
+			secondCalled = true
+			return true
+		})
+		logger.Error("msg")
+		assert.False(t, secondCalled)
+	})
+
+	t.Run("when processors are chained they should be invoked in order", func(t *testing.T) {
+		output, _ := setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Message += " first"
+			return true
+		})
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Message += " second"
+			return true
+		})
+		logger.Error("msg")
+		assert.Equals(t, strings.TrimSpace(output.String()), "ERROR msg first second")
+	})
+
+	t.Run("when a processor changes the level it should be passed to the formatter", func(t *testing.T) {
+		output, _ := setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Level = logger.LevelWarn
+			return true
+		})
+		logger.Error("msg")
+		assert.Equals(t, strings.TrimSpace(output.String()), "WARN msg")
+	})
+
+	t.Run("when a processor mutates the fields it should not modify the context fields", func(t *testing.T) {
+		_, _ = setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Fields["key"] = "mutated"
+			return true
+		})
+		ctx := context.Background()
+		logger.AddField(&ctx, "key", "value").Error("msg")
+		fieldsCopy := make(map[string]any)
+		logger.SetProcessors(func(record *logger.Record) bool {
+			fieldsCopy["key"] = record.Fields["key"]
+			return true
+		})
+		logger.FromCtx(ctx).Error("msg")
+		assert.Equals(t, fieldsCopy["key"], "value")
+	})
+
+	t.Run("when a processor adds a redacted field it should be masked", func(t *testing.T) {
+		_, recordedFields := setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Fields["token"] = "secret"
+			return true
+		})
+		logger.Error("msg")
+		assert.Equals(t, *recordedFields, []map[string]any{{"token": logger.RedactedValue}})
+	})
+
+	t.Run("when a panic record is dropped it should still panic", func(t *testing.T) {
+		_, _ = setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			return false
+		})
+		assert.PanicPart(t, func() {
+			logger.Panic("msg")
+		}, "PANIC msg")
+	})
+
+	t.Run("when a panic record is dropped it should still format the message", func(t *testing.T) {
+		_, _ = setAndRecordOutput(t)
+		logger.AddProcessor(func(record *logger.Record) bool {
+			record.Message = "changed"
+			return false
+		})
+		assert.PanicPart(t, func() {
+			logger.Panicf("panic %s", "message")
+		}, "PANIC panic message")
+	})
+
+	t.Run("when the processors are replaced while panicking it should not race", func(t *testing.T) {
+		_, _ = setAndRecordOutput(t)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 100 {
+				logger.SetProcessors(func(record *logger.Record) bool {
+					return true
+				})
+			}
+		}()
+		for range 100 {
+			assert.PanicPart(t, func() {
+				logger.Panic("msg")
+			}, "PANIC msg")
+		}
+		<-done
+	})
+}
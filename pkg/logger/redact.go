@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"strings"
+)
+
+const (
+	// RedactedValue replaces the value of a redacted field.
+	RedactedValue = "[REDACTED]"
+)
+
+var (
+	// appRedactedKeys is the set of lower-cased field keys that have their values redacted.
+	appRedactedKeys = map[string]struct{}{
+		"password":      {},
+		"token":         {},
+		"authorization": {},
+	}
+)
+
+// SetRedactedKeys sets the field keys that have their values replaced with RedactedValue before an entry is formatted.
+// The keys are case-insensitive and apply to fields in nested map[string]any groups as well.
+// By default, the keys password, token, and authorization are redacted.
+func SetRedactedKeys(keys ...string) {
+	lock.Lock()
+	defer lock.Unlock()
+	appRedactedKeys = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		appRedactedKeys[strings.ToLower(key)] = struct{}{}
+	}
+}
+
+// redactFields returns a copy of the fields with the values of the redacted keys masked.
+// The fields are not modified since they are shared with the context they came from.
+func redactFields(fields map[string]any) map[string]any {
+	if len(fields) == 0 || len(appRedactedKeys) == 0 {
+		return fields
+	}
+	redacted := make(map[string]any, len(fields))
+	for key, value := range fields {
+		if _, shouldRedact := appRedactedKeys[strings.ToLower(key)]; shouldRedact {
+			redacted[key] = RedactedValue
+		} else if group, isGroup := value.(map[string]any); isGroup {
+			redacted[key] = redactFields(group)
+		} else {
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Cleanup(func() {
+		SetRedactedKeys("password", "token", "authorization")
+	})
+
+	t.Run("when the fields are empty it should return them as is", func(t *testing.T) {
+		assert.Nil(t, redactFields(nil))
+		assert.Equals(t, len(redactFields(map[string]any{})), 0)
+	})
+
+	t.Run("when a field has a default redacted key it should be masked regardless of the case", func(t *testing.T) {
+		redacted := redactFields(map[string]any{
+			"Password":      "secret",
+			"TOKEN":         "secret",
+			"authorization": "secret",
+			"user":          "name",
+		})
+		assert.Equals(t, redacted, map[string]any{
+			"Password":      RedactedValue,
+			"TOKEN":         RedactedValue,
+			"authorization": RedactedValue,
+			"user":          "name",
+		})
+	})
+
+	t.Run("when a field is in a nested group it should be masked", func(t *testing.T) {
+		fields := map[string]any{
+			"request": map[string]any{
+				"headers": map[string]any{
+					"authorization": "secret",
+					"accept":        "json",
+				},
+			},
+		}
+		redacted := redactFields(fields)
+		assert.Equals(t, redacted, map[string]any{
+			"request": map[string]any{
+				"headers": map[string]any{
+					"authorization": RedactedValue,
+					"accept":        "json",
+				},
+			},
+		})
+		assert.Equals(t, fields["request"].(map[string]any)["headers"].(map[string]any)["authorization"], "secret")
+	})
+
+	t.Run("when the redacted keys are set it should replace the defaults", func(t *testing.T) {
+		SetRedactedKeys("Secret")
+		redacted := redactFields(map[string]any{
+			"secret":   "value",
+			"password": "value",
+		})
+		assert.Equals(t, redacted, map[string]any{
+			"secret":   RedactedValue,
+			"password": "value",
+		})
+		SetRedactedKeys()
+		redacted = redactFields(map[string]any{
+			"secret": "value",
+		})
+		assert.Equals(t, redacted, map[string]any{
+			"secret": "value",
+		})
+	})
+
+	t.Run("when a context field is redacted it should not be modified in the context", func(t *testing.T) {
+		SetRedactedKeys("password")
+		ctx := context.Background()
+		testLogger := AddField(&ctx, "password", "secret")
+		msg, written := formatLog(LevelInfo, testLogger.(*entry).fields, "test message")
+		assert.True(t, written)
+		assert.Contains(t, msg, "password="+RedactedValue)
+		assert.Equals(t, FromCtx(ctx).(*entry).fields["password"], "secret")
+	})
+}
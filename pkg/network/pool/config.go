@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"io"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// config holds the configuration of a Pool.
+type config[T io.Closer] struct {
+	maxSize     int
+	maxIdle     int
+	idleTimeout *time.Duration
+	healthCheck func(T) error
+	clock       timestamp.Clock
+}
+
+// Option configures a Pool. It has the connection type of the Pool, so that the health check
+// is checked against the Pool when compiling.
+type Option[T io.Closer] func(*config[T])
+
+// WithMaxSize bounds the number of open connections, whether they are idle or borrowed.
+// When the bound is reached, Get waits until a connection is returned or discarded.
+func WithMaxSize[T io.Closer](maxSize int) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.maxSize = maxSize
+	}
+}
+
+// WithMaxIdle bounds the number of idle connections. Connections that are returned
+// while the bound is reached are closed. Without it, every open connection can be idle.
+func WithMaxIdle[T io.Closer](maxIdle int) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.maxIdle = maxIdle
+	}
+}
+
+// WithIdleTimeout closes the connections that have been idle for longer than the timeout
+// instead of handing them out.
+func WithIdleTimeout[T io.Closer](timeout time.Duration) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.idleTimeout = &timeout
+	}
+}
+
+// WithHealthCheck sets a function that is invoked on an idle connection before it is handed out.
+// Connections that fail the check are closed.
+func WithHealthCheck[T io.Closer](healthCheck func(conn T) error) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.healthCheck = healthCheck
+	}
+}
+
+// WithClock sets the Clock used to measure how long the connections have been idle.
+func WithClock[T io.Closer](clock timestamp.Clock) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.clock = clock
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure[T io.Closer](opts ...Option[T]) *config[T] {
+	cfg := &config[T]{
+		maxSize:     10,
+		maxIdle:     0,
+		idleTimeout: nil,
+		healthCheck: nil,
+		clock:       timestamp.SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.maxSize <= 0 {
+		panic("The max size must be greater than zero.")
+	}
+	if cfg.maxIdle < 0 {
+		panic("The max idle cannot be negative.")
+	}
+	if cfg.idleTimeout != nil && *cfg.idleTimeout <= 0 {
+		panic("The idle timeout must be greater than zero.")
+	}
+	return cfg
+}
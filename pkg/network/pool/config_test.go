@@ -0,0 +1,38 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/network/pool"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	factory := func(context.Context) (*testConn, error) {
+		return &testConn{}, nil
+	}
+
+	t.Run("when the options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			pool.New(factory, pool.WithMaxSize[*testConn](0))
+		}, "The max size must be greater than zero.")
+		assert.PanicExact(t, func() {
+			pool.New(factory, pool.WithMaxIdle[*testConn](-1))
+		}, "The max idle cannot be negative.")
+		assert.PanicExact(t, func() {
+			pool.New(factory, pool.WithIdleTimeout[*testConn](0))
+		}, "The idle timeout must be greater than zero.")
+	})
+
+	t.Run("when the options are valid it should create the pool", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New(factory, pool.WithMaxSize[*testConn](1), pool.WithMaxIdle[*testConn](1), pool.WithIdleTimeout[*testConn](time.Second))
+		assert.NotNil(t, p)
+		assert.NoError(t, p.Close())
+	})
+}
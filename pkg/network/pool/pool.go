@@ -0,0 +1,225 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// ErrClosed is returned when a connection is requested from a closed Pool.
+var ErrClosed = errors.New("the pool is closed")
+
+// Factory creates a new connection.
+type Factory[T io.Closer] func(ctx context.Context) (T, error)
+
+// idleConn is a connection that is waiting in the Pool to be borrowed.
+type idleConn[T io.Closer] struct {
+	conn       T
+	returnedAt time.Time
+}
+
+// Pool reuses connections to avoid paying the cost of opening one for each use.
+// Connections are borrowed with Get and handed back with Put, or with Discard if they are broken.
+// It is safe for concurrent use.
+type Pool[T io.Closer] struct {
+	factory     Factory[T]
+	maxSize     int
+	maxIdle     int
+	idleTimeout *time.Duration
+	healthCheck func(T) error
+	clock       timestamp.Clock
+
+	lock    sync.Mutex
+	idle    []idleConn[T]
+	open    int
+	closed  bool
+	changed chan struct{}
+}
+
+// New creates a Pool that opens connections with the factory.
+func New[T io.Closer](factory Factory[T], opts ...Option[T]) *Pool[T] {
+	cfg := configure(opts...)
+	return &Pool[T]{
+		factory:     factory,
+		maxSize:     cfg.maxSize,
+		maxIdle:     cfg.maxIdle,
+		idleTimeout: cfg.idleTimeout,
+		healthCheck: cfg.healthCheck,
+		clock:       cfg.clock,
+		idle:        make([]idleConn[T], 0),
+		open:        0,
+		closed:      false,
+		changed:     make(chan struct{}),
+	}
+}
+
+// notify wakes up the routines waiting for a connection. The lock must be held.
+func (p *Pool[T]) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// Get borrows a connection. The idle connections that timed out are closed first, then the most recently
+// returned idle connection is reused if it is healthy, otherwise a new one is opened. If the Pool is at its max size, Get waits until a connection
+// is returned or discarded, or until the context is done.
+func (p *Pool[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		p.lock.Lock()
+		if p.closed {
+			p.lock.Unlock()
+			return zero, ErrClosed
+		}
+
+		if expired := p.takeExpired(); len(expired) > 0 {
+			p.lock.Unlock()
+			closeIdle(expired)
+			continue
+		}
+
+		if len(p.idle) > 0 {
+			last := p.idle[len(p.idle)-1]
+			p.idle = p.idle[:len(p.idle)-1]
+			p.lock.Unlock()
+			if p.usable(last) {
+				return last.conn, nil
+			}
+			p.Discard(last.conn)
+			continue
+		}
+
+		if p.open < p.maxSize {
+			p.open++
+			p.lock.Unlock()
+			conn, err := p.factory(ctx)
+			if err != nil {
+				p.lock.Lock()
+				p.open--
+				p.notify()
+				p.lock.Unlock()
+				return zero, fmt.Errorf("failed to open a connection (%w)", err)
+			}
+			return conn, nil
+		}
+
+		changed := p.changed
+		p.lock.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// takeExpired removes the idle connections that exceeded the idle timeout and frees their places in the Pool.
+// The idle connections are ordered from the oldest to the most recently returned, so the expired ones
+// are at the start. This frees connections that would never be popped, for example after a burst of use.
+// The lock must be held, and the returned connections must be closed by the caller once it is released.
+func (p *Pool[T]) takeExpired() []idleConn[T] {
+	if p.idleTimeout == nil {
+		return nil
+	}
+	expiredCount := 0
+	for expiredCount < len(p.idle) && p.clock.Since(p.idle[expiredCount].returnedAt) > *p.idleTimeout {
+		expiredCount++
+	}
+	if expiredCount == 0 {
+		return nil
+	}
+	expired := p.idle[:expiredCount:expiredCount]
+	p.idle = append(make([]idleConn[T], 0, len(p.idle)-expiredCount), p.idle[expiredCount:]...)
+	p.open -= expiredCount
+	p.notify()
+	return expired
+}
+
+// closeIdle closes the connections and ignores the errors since they are no longer in use.
+func closeIdle[T io.Closer](idle []idleConn[T]) {
+	for _, idleConn := range idle {
+		_ = idleConn.conn.Close()
+	}
+}
+
+// usable returns true if the idle connection passes the health check.
+// The idle connections that timed out are already removed by takeExpired.
+func (p *Pool[T]) usable(idle idleConn[T]) bool {
+	if p.healthCheck != nil && p.healthCheck(idle.conn) != nil {
+		return false
+	}
+	return true
+}
+
+// Put returns a borrowed connection to the Pool so it can be reused. The connection is closed
+// instead if the Pool is closed or already has the max number of idle connections.
+// The idle connections that timed out are closed as well.
+func (p *Pool[T]) Put(conn T) {
+	p.lock.Lock()
+	expired := p.takeExpired()
+	defer closeIdle(expired)
+	if p.closed || (p.maxIdle > 0 && len(p.idle) >= p.maxIdle) {
+		p.lock.Unlock()
+		p.Discard(conn)
+		return
+	}
+	p.idle = append(p.idle, idleConn[T]{
+		conn:       conn,
+		returnedAt: p.clock.Now(),
+	})
+	p.notify()
+	p.lock.Unlock()
+}
+
+// Discard closes a borrowed connection and frees its place in the Pool.
+// It is used for connections that are broken and should not be reused.
+func (p *Pool[T]) Discard(conn T) {
+	p.lock.Lock()
+	p.open--
+	p.notify()
+	p.lock.Unlock()
+	_ = conn.Close()
+}
+
+// Stats are the number of connections in the Pool.
+type Stats struct {
+	Open int
+	Idle int
+}
+
+// Stats returns the number of open and idle connections. Open includes the borrowed connections.
+func (p *Pool[T]) Stats() Stats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return Stats{
+		Open: p.open,
+		Idle: len(p.idle),
+	}
+}
+
+// Close closes the idle connections and makes Get return ErrClosed. Borrowed connections
+// are closed when they are returned. It is safe to call Close multiple times.
+func (p *Pool[T]) Close() error {
+	p.lock.Lock()
+	idle := p.idle
+	p.idle = make([]idleConn[T], 0)
+	p.open -= len(idle)
+	p.closed = true
+	p.notify()
+	p.lock.Unlock()
+
+	var errs []error
+	for _, idleConn := range idle {
+		if err := idleConn.conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close the idle connections (%w)", errors.Join(errs...))
+	}
+	return nil
+}
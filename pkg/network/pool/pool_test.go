@@ -0,0 +1,268 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/network/pool"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+type testConn struct {
+	id       int
+	closed   atomic.Bool
+	closeErr error
+	healthy  atomic.Bool
+}
+
+func (c *testConn) Close() error {
+	c.closed.Store(true)
+	return c.closeErr
+}
+
+type connFactory struct {
+	created atomic.Int64
+	err     error
+}
+
+func (f *connFactory) create(context.Context) (*testConn, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	conn := &testConn{id: int(f.created.Add(1))}
+	conn.healthy.Store(true)
+	return conn, nil
+}
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a connection is returned it should be reused", func(t *testing.T) {
+		t.Parallel()
+		factory := &connFactory{}
+		p := pool.New(factory.create)
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		p.Put(first)
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 1, Idle: 1})
+		second, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, second.id, first.id)
+		assert.Equals(t, factory.created.Load(), int64(1))
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 1, Idle: 0})
+	})
+
+	t.Run("when the factory fails it should return an error and free the slot", func(t *testing.T) {
+		t.Parallel()
+		factory := &connFactory{err: errors.New("dial error")}
+		p := pool.New(factory.create, pool.WithMaxSize[*testConn](1))
+		conn, err := p.Get(context.Background())
+		assert.ErrorExact(t, err, "failed to open a connection (dial error)")
+		assert.Nil(t, conn)
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 0, Idle: 0})
+	})
+
+	t.Run("when the pool is at its max size it should wait for a connection to be returned", func(t *testing.T) {
+		t.Parallel()
+		factory := &connFactory{}
+		p := pool.New(factory.create, pool.WithMaxSize[*testConn](1))
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		borrowed := make(chan *testConn)
+		go func() {
+			conn, getErr := p.Get(context.Background())
+			assert.NoError(t, getErr, assert.Continue())
+			borrowed <- conn
+		}()
+		select {
+		case <-borrowed:
+			t.Fatal("Get returned before a connection was returned.")
+		case <-time.After(time.Millisecond * 10):
+		}
+		p.Put(first)
+		assert.Equals(t, (<-borrowed).id, first.id)
+	})
+
+	t.Run("when the pool is at its max size and a connection is discarded it should open a new one", func(t *testing.T) {
+		t.Parallel()
+		factory := &connFactory{}
+		p := pool.New(factory.create, pool.WithMaxSize[*testConn](1))
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		borrowed := make(chan *testConn)
+		go func() {
+			conn, getErr := p.Get(context.Background())
+			assert.NoError(t, getErr, assert.Continue())
+			borrowed <- conn
+		}()
+		p.Discard(first)
+		assert.True(t, first.closed.Load())
+		assert.Equals(t, (<-borrowed).id, 2)
+	})
+
+	t.Run("when the context is done while waiting it should return the context error", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New((&connFactory{}).create, pool.WithMaxSize[*testConn](1))
+		_, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		conn, err := p.Get(ctx)
+		assert.ErrorExact(t, err, context.DeadlineExceeded.Error())
+		assert.Nil(t, conn)
+	})
+
+	t.Run("when there are more idle connections than the max idle it should close the extra ones", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New((&connFactory{}).create, pool.WithMaxIdle[*testConn](1))
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		second, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		p.Put(first)
+		p.Put(second)
+		assert.False(t, first.closed.Load())
+		assert.True(t, second.closed.Load())
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 1, Idle: 1})
+	})
+
+	t.Run("when an idle connection times out it should be closed instead of reused", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		p := pool.New((&connFactory{}).create, pool.WithIdleTimeout[*testConn](time.Minute), pool.WithClock[*testConn](clock))
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		p.Put(first)
+		clock.Advance(time.Minute)
+		conn, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, conn.id, first.id)
+		p.Put(conn)
+		clock.Advance(time.Minute + time.Nanosecond)
+		conn, err = p.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, conn.id, 2)
+		assert.True(t, first.closed.Load())
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 1, Idle: 0})
+	})
+
+	t.Run("when there is a burst of use followed by steady single use it should close the old idle connections", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		p := pool.New((&connFactory{}).create, pool.WithIdleTimeout[*testConn](time.Minute), pool.WithClock[*testConn](clock))
+		burst := make([]*testConn, 5)
+		for i := range burst {
+			conn, err := p.Get(context.Background())
+			assert.NoError(t, err)
+			burst[i] = conn
+		}
+		for _, conn := range burst {
+			p.Put(conn)
+		}
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 5, Idle: 5})
+		for range 3 {
+			clock.Advance(time.Second * 40)
+			conn, err := p.Get(context.Background())
+			assert.NoError(t, err)
+			p.Put(conn)
+		}
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 1, Idle: 1})
+		for _, conn := range burst[:4] {
+			assert.True(t, conn.closed.Load())
+		}
+		assert.False(t, burst[4].closed.Load())
+	})
+
+	t.Run("when an idle connection fails the health check it should be closed instead of reused", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New((&connFactory{}).create, pool.WithHealthCheck(func(conn *testConn) error {
+			if !conn.healthy.Load() {
+				return errors.New("unhealthy")
+			}
+			return nil
+		}))
+		first, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		first.healthy.Store(false)
+		p.Put(first)
+		conn, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equals(t, conn.id, 2)
+		assert.True(t, first.closed.Load())
+	})
+
+	t.Run("when the pool is closed it should close the connections and reject new requests", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New((&connFactory{}).create)
+		idle, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		borrowed, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		p.Put(idle)
+		assert.NoError(t, p.Close())
+		assert.NoError(t, p.Close())
+		assert.True(t, idle.closed.Load())
+		assert.False(t, borrowed.closed.Load())
+		_, err = p.Get(context.Background())
+		assert.ErrorExact(t, err, pool.ErrClosed.Error())
+		p.Put(borrowed)
+		assert.True(t, borrowed.closed.Load())
+		assert.Equals(t, p.Stats(), pool.Stats{Open: 0, Idle: 0})
+	})
+
+	t.Run("when the pool is closed it should wake up the waiting routines", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New((&connFactory{}).create, pool.WithMaxSize[*testConn](1))
+		_, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		errs := make(chan error)
+		go func() {
+			_, getErr := p.Get(context.Background())
+			errs <- getErr
+		}()
+		time.Sleep(time.Millisecond * 10)
+		assert.NoError(t, p.Close())
+		assert.ErrorExact(t, <-errs, pool.ErrClosed.Error())
+	})
+
+	t.Run("when closing an idle connection fails it should return the error", func(t *testing.T) {
+		t.Parallel()
+		p := pool.New(func(context.Context) (*testConn, error) {
+			return &testConn{closeErr: errors.New("close error")}, nil
+		})
+		conn, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		p.Put(conn)
+		assert.ErrorExact(t, p.Close(), "failed to close the idle connections (close error)")
+	})
+
+	t.Run("when many routines use the pool it should not exceed the max size", func(t *testing.T) {
+		t.Parallel()
+		const maxSize = 3
+		factory := &connFactory{}
+		p := pool.New(factory.create, pool.WithMaxSize[*testConn](maxSize))
+		inUse := atomic.Int64{}
+		wg := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < 100; k++ {
+					conn, err := p.Get(context.Background())
+					assert.NoError(t, err, assert.Continue())
+					assert.LessOrEqual(t, inUse.Add(1), int64(maxSize), assert.Continue())
+					inUse.Add(-1)
+					p.Put(conn)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, factory.created.Load(), int64(maxSize))
+		assert.NoError(t, p.Close())
+	})
+}
@@ -0,0 +1,91 @@
+package ptr
+
+// ToSlice returns a slice of pointers to copies of the values.
+// A nil slice returns nil.
+func ToSlice[T any](values []T) []*T {
+	if values == nil {
+		return nil
+	}
+	pointers := make([]*T, len(values))
+	for i, value := range values {
+		pointers[i] = Of(value)
+	}
+	return pointers
+}
+
+// FromSlice returns a slice of the values the pointers point to.
+// Nil pointers become the zero value of T, so the result has the same length as the input.
+// A nil slice returns nil.
+func FromSlice[T any](pointers []*T) []T {
+	if pointers == nil {
+		return nil
+	}
+	values := make([]T, len(pointers))
+	for i, pointer := range pointers {
+		if pointer != nil {
+			values[i] = *pointer
+		}
+	}
+	return values
+}
+
+// FromSliceSkipNil returns a slice of the values the pointers point to. Nil pointers are skipped.
+// A nil slice returns nil.
+func FromSliceSkipNil[T any](pointers []*T) []T {
+	if pointers == nil {
+		return nil
+	}
+	values := make([]T, 0, len(pointers))
+	for _, pointer := range pointers {
+		if pointer != nil {
+			values = append(values, *pointer)
+		}
+	}
+	return values
+}
+
+// ToMap returns a map with pointers to copies of the values.
+// A nil map returns nil.
+func ToMap[K comparable, V any](values map[K]V) map[K]*V {
+	if values == nil {
+		return nil
+	}
+	pointers := make(map[K]*V, len(values))
+	for key, value := range values {
+		pointers[key] = Of(value)
+	}
+	return pointers
+}
+
+// FromMap returns a map of the values the pointers point to.
+// Nil pointers become the zero value of V, so the result has the same keys as the input.
+// A nil map returns nil.
+func FromMap[K comparable, V any](pointers map[K]*V) map[K]V {
+	if pointers == nil {
+		return nil
+	}
+	values := make(map[K]V, len(pointers))
+	for key, pointer := range pointers {
+		var value V
+		if pointer != nil {
+			value = *pointer
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// FromMapSkipNil returns a map of the values the pointers point to. Keys with nil pointers are skipped.
+// A nil map returns nil.
+func FromMapSkipNil[K comparable, V any](pointers map[K]*V) map[K]V {
+	if pointers == nil {
+		return nil
+	}
+	values := make(map[K]V, len(pointers))
+	for key, pointer := range pointers {
+		if pointer != nil {
+			values[key] = *pointer
+		}
+	}
+	return values
+}
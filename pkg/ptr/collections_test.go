@@ -0,0 +1,78 @@
+package ptr_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when values are converted to pointers it should point to copies of them", func(t *testing.T) {
+		t.Parallel()
+		values := []int{1, 2, 3}
+		pointers := ptr.ToSlice(values)
+		assert.Equals(t, len(pointers), 3)
+		for i, pointer := range pointers {
+			assert.Equals(t, *pointer, values[i])
+		}
+		*pointers[0] = 10
+		assert.Equals(t, values[0], 1)
+	})
+
+	t.Run("when pointers are converted to values it should use the zero value for nil", func(t *testing.T) {
+		t.Parallel()
+		values := ptr.FromSlice([]*string{ptr.Of("a"), nil, ptr.Of("c")})
+		assert.Equals(t, values, []string{"a", "", "c"})
+	})
+
+	t.Run("when nil pointers are skipped it should only return the values of the others", func(t *testing.T) {
+		t.Parallel()
+		values := ptr.FromSliceSkipNil([]*string{ptr.Of("a"), nil, ptr.Of("c")})
+		assert.Equals(t, values, []string{"a", "c"})
+		assert.Equals(t, ptr.FromSliceSkipNil([]*string{nil}), []string{})
+	})
+
+	t.Run("when the slices are nil it should return nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, ptr.ToSlice[int](nil))
+		assert.Nil(t, ptr.FromSlice[int](nil))
+		assert.Nil(t, ptr.FromSliceSkipNil[int](nil))
+	})
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when values are converted to pointers it should point to copies of them", func(t *testing.T) {
+		t.Parallel()
+		values := map[string]int{"a": 1, "b": 2}
+		pointers := ptr.ToMap(values)
+		assert.Equals(t, len(pointers), 2)
+		assert.Equals(t, *pointers["a"], 1)
+		assert.Equals(t, *pointers["b"], 2)
+		*pointers["a"] = 10
+		assert.Equals(t, values["a"], 1)
+	})
+
+	t.Run("when pointers are converted to values it should use the zero value for nil", func(t *testing.T) {
+		t.Parallel()
+		values := ptr.FromMap(map[string]*int{"a": ptr.Of(1), "b": nil})
+		assert.Equals(t, values, map[string]int{"a": 1, "b": 0})
+	})
+
+	t.Run("when nil pointers are skipped it should only return the values of the others", func(t *testing.T) {
+		t.Parallel()
+		values := ptr.FromMapSkipNil(map[string]*int{"a": ptr.Of(1), "b": nil})
+		assert.Equals(t, values, map[string]int{"a": 1})
+	})
+
+	t.Run("when the maps are nil it should return nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, ptr.ToMap[string, int](nil))
+		assert.Nil(t, ptr.FromMap[string, int](nil))
+		assert.Nil(t, ptr.FromMapSkipNil[string, int](nil))
+	})
+}
@@ -13,6 +13,20 @@ func Of[T any](val T) *T {
 	return valPtr
 }
 
+// Value returns the value the pointer points to, or the zero value of T if the pointer is nil.
+func Value[T any](p *T) T {
+	var zero T
+	return ValueOr(p, zero)
+}
+
+// ValueOr returns the value the pointer points to, or the default value if the pointer is nil.
+func ValueOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
 // Is checks if the generic parameter is a pointer type.
 // Returns true if T is a pointer, false otherwise.
 func Is[T any]() bool {
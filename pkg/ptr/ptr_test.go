@@ -39,6 +39,30 @@ func TestPtr(t *testing.T) {
 	})
 }
 
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the pointer is not nil it should return the value it points to", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, ptr.Value(ptr.Of(123)), 123)
+		assert.Equals(t, ptr.ValueOr(ptr.Of(123), 456), 123)
+		assert.Equals(t, ptr.ValueOr(ptr.Of(0), 456), 0)
+	})
+
+	t.Run("when the pointer is nil it should return the zero value", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, ptr.Value[int](nil), 0)
+		assert.Equals(t, ptr.Value[string](nil), "")
+		assert.Nil(t, ptr.Value[*int](nil))
+	})
+
+	t.Run("when the pointer is nil it should return the default value", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, ptr.ValueOr(nil, 456), 456)
+		assert.Equals(t, ptr.ValueOr(nil, "default"), "default")
+	})
+}
+
 func TestIs(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/datastructures/cache"
+)
+
+// Keyed holds a Limiter per key, like a client address or a job name. The limiter of a key is created
+// on first use and is evicted after it has not been used for the TTL.
+//
+// An evicted key gets a new Limiter with its full allowance, so the TTL should be at least the time it takes
+// a Limiter to fully recover. For a TokenBucket and a SlidingWindow, the TTL of a key is raised to that time
+// automatically, which is burst/rate seconds for a TokenBucket and the window for a SlidingWindow.
+type Keyed[Key comparable] struct {
+	limiters   *cache.Cache[Key, Limiter]
+	newLimiter func() Limiter
+	ttl        time.Duration
+}
+
+// recoverer is implemented by the limiters that know how long they take to give back their full allowance.
+type recoverer interface {
+	fullRecovery() time.Duration
+}
+
+// NewKeyed creates a Keyed limiter. The function creates the Limiter of a key when it is first used.
+// The TTL of a key is the longest of the TTL and the time it takes its Limiter to fully recover.
+// Close must be called to stop the eviction of unused keys.
+func NewKeyed[Key comparable](ttl time.Duration, newLimiter func() Limiter, opts ...Option) *Keyed[Key] {
+	if ttl <= 0 {
+		panic("The TTL must be greater than zero.")
+	}
+	cfg := configure(opts...)
+	return &Keyed[Key]{
+		limiters: cache.New[Key, Limiter](
+			cache.WithDefaultTTL[Key, Limiter](ttl),
+			cache.WithExpirationInterval[Key, Limiter](ttl),
+			cache.WithClock[Key, Limiter](cfg.clock),
+		),
+		newLimiter: newLimiter,
+		ttl:        ttl,
+	}
+}
+
+// limiter returns the Limiter of the key and resets its TTL.
+func (k *Keyed[Key]) limiter(key Key) Limiter {
+	limiter, _ := k.limiters.GetOrSet(key, func(Key) (Limiter, *time.Duration, error) {
+		newLimiter := k.newLimiter()
+		if recovering, isRecovering := newLimiter.(recoverer); isRecovering && recovering.fullRecovery() > k.ttl {
+			ttl := recovering.fullRecovery()
+			return newLimiter, &ttl, nil
+		}
+		return newLimiter, nil, nil
+	})
+	k.limiters.Touch(key)
+	return limiter
+}
+
+// Allow reports whether an event may happen now for the key.
+func (k *Keyed[Key]) Allow(key Key) bool {
+	return k.limiter(key).Allow()
+}
+
+// Wait blocks until an event may happen for the key or the context is done.
+func (k *Keyed[Key]) Wait(ctx context.Context, key Key) error {
+	return k.limiter(key).Wait(ctx)
+}
+
+// Len returns the number of keys that have a Limiter.
+func (k *Keyed[Key]) Len() int {
+	return k.limiters.Len()
+}
+
+// Close stops the background eviction of the unused keys.
+func (k *Keyed[Key]) Close() {
+	k.limiters.Close()
+}
@@ -0,0 +1,88 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ratelimit"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestKeyed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the TTL is invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			ratelimit.NewKeyed[string](0, func() ratelimit.Limiter { return nil })
+		}, "The TTL must be greater than zero.")
+	})
+
+	t.Run("when different keys are used it should limit them independently", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		keyed := ratelimit.NewKeyed[string](time.Minute, func() ratelimit.Limiter {
+			return ratelimit.NewTokenBucket(1, 1, ratelimit.WithClock(clock))
+		}, ratelimit.WithClock(clock))
+		defer keyed.Close()
+		assert.True(t, keyed.Allow("first"))
+		assert.False(t, keyed.Allow("first"))
+		assert.True(t, keyed.Allow("second"))
+		assert.NoError(t, keyed.Wait(context.Background(), "third"))
+		assert.Equals(t, keyed.Len(), 3)
+	})
+
+	t.Run("when a key is unused for the TTL it should be evicted", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		created := 0
+		keyed := ratelimit.NewKeyed[string](time.Minute, func() ratelimit.Limiter {
+			created++
+			return &allowOnce{}
+		}, ratelimit.WithClock(clock))
+		defer keyed.Close()
+		assert.True(t, keyed.Allow("key"))
+		clock.Advance(time.Second * 59)
+		assert.False(t, keyed.Allow("key"))
+		clock.Advance(time.Second * 59)
+		assert.False(t, keyed.Allow("key"))
+		clock.Advance(time.Minute + time.Second)
+		assert.True(t, keyed.Allow("key"))
+		assert.Equals(t, created, 2)
+	})
+
+	t.Run("when the TTL is shorter than the recovery of the limiter it should keep the key until it recovers", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		created := 0
+		keyed := ratelimit.NewKeyed[string](time.Second, func() ratelimit.Limiter {
+			created++
+			return ratelimit.NewTokenBucket(1.0/60, 1, ratelimit.WithClock(clock))
+		}, ratelimit.WithClock(clock))
+		defer keyed.Close()
+		assert.True(t, keyed.Allow("key"))
+		clock.Advance(time.Second * 2)
+		assert.False(t, keyed.Allow("key"))
+		clock.Advance(time.Second * 30)
+		assert.False(t, keyed.Allow("key"))
+		clock.Advance(time.Second * 30)
+		assert.True(t, keyed.Allow("key"))
+		assert.Equals(t, created, 1)
+	})
+}
+
+// allowOnce is a Limiter that allows a single event and does not know how long it takes to recover.
+type allowOnce struct {
+	used bool
+}
+
+func (l *allowOnce) Allow() bool {
+	allowed := !l.used
+	l.used = true
+	return allowed
+}
+
+func (l *allowOnce) Wait(context.Context) error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// Limiter controls how frequently events are allowed to happen.
+type Limiter interface {
+	// Allow reports whether an event may happen now, and consumes the allowance if it may.
+	Allow() bool
+
+	// Wait blocks until an event may happen or the context is done.
+	Wait(ctx context.Context) error
+}
+
+// config holds the configuration of the limiters.
+type config struct {
+	clock timestamp.Clock
+}
+
+// Option configures a limiter.
+type Option func(*config)
+
+// WithClock sets the Clock used to measure time. It is meant for tests that use a timestamp.FakeClock.
+func WithClock(clock timestamp.Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = clock
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		clock: timestamp.SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// sleep waits for the duration on the clock or until the context is done.
+func sleep(ctx context.Context, clock timestamp.Clock, d time.Duration) error {
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
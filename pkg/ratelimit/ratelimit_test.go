@@ -0,0 +1,31 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ratelimit"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func newFakeClock() *timestamp.FakeClock {
+	return timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the limiters are created without a clock it should use the system clock", func(t *testing.T) {
+		t.Parallel()
+		limiters := []ratelimit.Limiter{
+			ratelimit.NewTokenBucket(1000, 1),
+			ratelimit.NewSlidingWindow(1, time.Millisecond),
+		}
+		for _, limiter := range limiters {
+			assert.True(t, limiter.Allow())
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// SlidingWindow is a Limiter that allows at most a number of events in any window of time.
+// Unlike fixed windows, it does not allow twice the limit around the boundary of a window.
+type SlidingWindow struct {
+	lock   sync.Mutex
+	clock  timestamp.Clock
+	limit  int
+	window time.Duration
+	events []time.Time
+}
+
+// Ensure SlidingWindow implements Limiter.
+var _ Limiter = (*SlidingWindow)(nil)
+
+// NewSlidingWindow creates a SlidingWindow that allows limit events per window.
+func NewSlidingWindow(limit int, window time.Duration, opts ...Option) *SlidingWindow {
+	if limit <= 0 {
+		panic("The limit must be greater than zero.")
+	}
+	if window <= 0 {
+		panic("The window must be greater than zero.")
+	}
+	cfg := configure(opts...)
+	return &SlidingWindow{
+		clock:  cfg.clock,
+		limit:  limit,
+		window: window,
+		events: make([]time.Time, 0, limit),
+	}
+}
+
+// fullRecovery returns the time it takes for all the events to leave the window.
+func (w *SlidingWindow) fullRecovery() time.Duration {
+	return w.window
+}
+
+// prune removes the events that are out of the window and returns the current time. The lock must be held.
+func (w *SlidingWindow) prune() time.Time {
+	now := w.clock.Now()
+	windowStart := now.Add(-w.window)
+	expired := 0
+	for expired < len(w.events) && !w.events[expired].After(windowStart) {
+		expired++
+	}
+	w.events = append(w.events[:0], w.events[expired:]...)
+	return now
+}
+
+// tryAcquire records an event if the limit allows it. Otherwise, it returns how long to wait for
+// the oldest event to leave the window.
+func (w *SlidingWindow) tryAcquire() (bool, time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	now := w.prune()
+	if len(w.events) < w.limit {
+		w.events = append(w.events, now)
+		return true, 0
+	}
+	return false, w.events[0].Add(w.window).Sub(now)
+}
+
+// Allow records an event if fewer than the limit happened in the window.
+func (w *SlidingWindow) Allow() bool {
+	allowed, _ := w.tryAcquire()
+	return allowed
+}
+
+// Remaining returns the number of events that are allowed in the window right now.
+func (w *SlidingWindow) Remaining() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.prune()
+	return w.limit - len(w.events)
+}
+
+// Wait blocks until an event is allowed in the window or the context is done.
+func (w *SlidingWindow) Wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		allowed, delay := w.tryAcquire()
+		if allowed {
+			return nil
+		}
+		if err := sleep(ctx, w.clock, delay); err != nil {
+			return err
+		}
+	}
+}
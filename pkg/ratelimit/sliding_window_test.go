@@ -0,0 +1,72 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ratelimit"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the parameters are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			ratelimit.NewSlidingWindow(0, time.Second)
+		}, "The limit must be greater than zero.")
+		assert.PanicExact(t, func() {
+			ratelimit.NewSlidingWindow(1, 0)
+		}, "The window must be greater than zero.")
+	})
+
+	t.Run("when the limit is reached it should deny events until the oldest leaves the window", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		window := ratelimit.NewSlidingWindow(2, time.Minute, ratelimit.WithClock(clock))
+		assert.True(t, window.Allow())
+		clock.Advance(time.Second * 30)
+		assert.True(t, window.Allow())
+		assert.False(t, window.Allow())
+		assert.Equals(t, window.Remaining(), 0)
+		clock.Advance(time.Second * 29)
+		assert.False(t, window.Allow())
+		clock.Advance(time.Second)
+		assert.Equals(t, window.Remaining(), 1)
+		assert.True(t, window.Allow())
+		assert.False(t, window.Allow())
+	})
+
+	t.Run("when the limit is reached it should wait until an event is allowed", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		window := ratelimit.NewSlidingWindow(1, time.Minute, ratelimit.WithClock(clock))
+		assert.NoError(t, window.Wait(context.Background()))
+		done := make(chan error)
+		go func() {
+			done <- window.Wait(context.Background())
+		}()
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		assert.NoError(t, <-done)
+		assert.False(t, window.Allow())
+	})
+
+	t.Run("when the context is done while waiting it should return its error", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		window := ratelimit.NewSlidingWindow(1, time.Minute, ratelimit.WithClock(clock))
+		assert.True(t, window.Allow())
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- window.Wait(ctx)
+		}()
+		clock.BlockUntil(1)
+		cancel()
+		assert.ErrorExact(t, <-done, context.Canceled.Error())
+		assert.Equals(t, window.Remaining(), 0)
+	})
+}
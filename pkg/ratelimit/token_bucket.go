@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// TokenBucket is a Limiter that refills tokens at a constant rate up to a burst size.
+// Each event consumes a token, so it allows bursts while keeping the average rate.
+type TokenBucket struct {
+	lock       sync.Mutex
+	clock      timestamp.Clock
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// Ensure TokenBucket implements Limiter.
+var _ Limiter = (*TokenBucket)(nil)
+
+// NewTokenBucket creates a TokenBucket that refills at the rate in tokens per second and holds
+// at most burst tokens. The bucket starts full.
+func NewTokenBucket(rate float64, burst int, opts ...Option) *TokenBucket {
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		panic("The rate must be a finite number greater than zero.")
+	}
+	if burst <= 0 {
+		panic("The burst must be greater than zero.")
+	}
+	cfg := configure(opts...)
+	return &TokenBucket{
+		clock:      cfg.clock,
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: cfg.clock.Now(),
+	}
+}
+
+// fullRecovery returns the time it takes an empty bucket to refill to its burst.
+func (b *TokenBucket) fullRecovery() time.Duration {
+	seconds := b.burst / b.rate
+	if seconds >= float64(math.MaxInt64/int64(time.Second)) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// refill adds the tokens accumulated since the last refill. The lock must be held.
+func (b *TokenBucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.lastRefill = now
+}
+
+// Allow consumes a token if one is available.
+func (b *TokenBucket) Allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Tokens returns the number of tokens that are currently available.
+func (b *TokenBucket) Tokens() float64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+// Wait reserves a token and blocks until it is available. Waiting routines are served in order.
+// If the context is done first, the reservation is given back.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	b.refill()
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+	}
+	b.lock.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	if err := sleep(ctx, b.clock, delay); err != nil {
+		b.lock.Lock()
+		b.refill()
+		b.tokens = math.Min(b.burst, b.tokens+1)
+		b.lock.Unlock()
+		return err
+	}
+	return nil
+}
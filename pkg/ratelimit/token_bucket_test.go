@@ -0,0 +1,105 @@
+package ratelimit_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ratelimit"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the parameters are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		for _, rate := range []float64{0, -1, math.Inf(1), math.NaN()} {
+			assert.PanicExact(t, func() {
+				ratelimit.NewTokenBucket(rate, 1)
+			}, "The rate must be a finite number greater than zero.")
+		}
+		assert.PanicExact(t, func() {
+			ratelimit.NewTokenBucket(1, 0)
+		}, "The burst must be greater than zero.")
+	})
+
+	t.Run("when the burst is used up it should deny events until tokens are refilled", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		bucket := ratelimit.NewTokenBucket(2, 3, ratelimit.WithClock(clock))
+		for range 3 {
+			assert.True(t, bucket.Allow())
+		}
+		assert.False(t, bucket.Allow())
+		clock.Advance(time.Millisecond * 499)
+		assert.False(t, bucket.Allow())
+		clock.Advance(time.Millisecond)
+		assert.True(t, bucket.Allow())
+		assert.False(t, bucket.Allow())
+	})
+
+	t.Run("when the bucket is idle it should not refill more than the burst", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		bucket := ratelimit.NewTokenBucket(10, 2, ratelimit.WithClock(clock))
+		assert.True(t, bucket.Allow())
+		clock.Advance(time.Hour)
+		assert.Equals(t, bucket.Tokens(), 2.0)
+	})
+
+	t.Run("when a token is available it should not wait", func(t *testing.T) {
+		t.Parallel()
+		bucket := ratelimit.NewTokenBucket(1, 1, ratelimit.WithClock(newFakeClock()))
+		assert.NoError(t, bucket.Wait(context.Background()))
+		assert.False(t, bucket.Allow())
+	})
+
+	t.Run("when no token is available it should wait until one is refilled", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		bucket := ratelimit.NewTokenBucket(1, 1, ratelimit.WithClock(clock))
+		assert.True(t, bucket.Allow())
+		done := make(chan error)
+		go func() {
+			done <- bucket.Wait(context.Background())
+		}()
+		clock.BlockUntil(1)
+		clock.Advance(time.Millisecond * 999)
+		select {
+		case <-done:
+			t.Fatal("Wait returned before a token was refilled.")
+		default:
+		}
+		clock.Advance(time.Millisecond)
+		assert.NoError(t, <-done)
+		assert.False(t, bucket.Allow())
+	})
+
+	t.Run("when the context is done while waiting it should return the reserved token", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		bucket := ratelimit.NewTokenBucket(1, 1, ratelimit.WithClock(clock))
+		assert.True(t, bucket.Allow())
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- bucket.Wait(ctx)
+		}()
+		clock.BlockUntil(1)
+		cancel()
+		assert.ErrorExact(t, <-done, context.Canceled.Error())
+		clock.Advance(time.Second)
+		assert.True(t, bucket.Allow())
+	})
+
+	t.Run("when the context is already done it should return its error", func(t *testing.T) {
+		t.Parallel()
+		bucket := ratelimit.NewTokenBucket(1, 1, ratelimit.WithClock(newFakeClock()))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorExact(t, bucket.Wait(ctx), context.Canceled.Error())
+		assert.True(t, bucket.Allow())
+	})
+}
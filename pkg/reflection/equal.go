@@ -0,0 +1,273 @@
+package reflection
+
+import (
+	"math"
+	"math/cmplx"
+	"reflect"
+)
+
+// equalConfig is the configuration for the Equal function.
+type equalConfig struct {
+	ignoredFields  map[string]struct{}
+	floatTolerance float64
+	nilEqualsEmpty bool
+}
+
+// EqualOption is used to set parameters for the Equal function.
+type EqualOption func(*equalConfig)
+
+// WithIgnoredFields skips struct fields when comparing. A field is skipped if its name or its path,
+// as formatted by Walk, is one of the names.
+func WithIgnoredFields(names ...string) EqualOption {
+	return func(c *equalConfig) {
+		for _, name := range names {
+			c.ignoredFields[name] = struct{}{}
+		}
+	}
+}
+
+// WithFloatTolerance makes floats and complex numbers equal if they differ by at most the tolerance.
+func WithFloatTolerance(tolerance float64) EqualOption {
+	if tolerance < 0 {
+		panic("The float tolerance must not be negative.")
+	}
+	return func(c *equalConfig) {
+		c.floatTolerance = tolerance
+	}
+}
+
+// WithNilEqualsEmpty makes nil slices and maps equal to empty ones.
+func WithNilEqualsEmpty() EqualOption {
+	return func(c *equalConfig) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// configureEqual applies the options to the default configuration.
+func configureEqual(opts ...EqualOption) *equalConfig {
+	cfg := &equalConfig{
+		ignoredFields:  make(map[string]struct{}),
+		floatTolerance: 0,
+		nilEqualsEmpty: false,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Equal reports whether a and b are deeply equal. Without options, it follows the rules of reflect.DeepEqual.
+// The options relax the comparison, for example to ignore fields or to tolerate rounding errors in floats.
+func Equal(a any, b any, opts ...EqualOption) bool {
+	c := newComparer(nil, opts...)
+	return c.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// Difference is a value that is not the same in the two compared values.
+// A and B are invalid reflect values if the path only exists in the other value,
+// like a map key or a slice element past the end of the shorter slice.
+type Difference struct {
+	Path string
+	A    reflect.Value
+	B    reflect.Value
+}
+
+// Diff returns the differences between a and b, compared in the same way as Equal.
+// Differences are reported at the deepest path where they can be located, so two structs that differ
+// in one field result in a single Difference for that field. It returns nil if the values are equal.
+func Diff(a any, b any, opts ...EqualOption) []Difference {
+	var differences []Difference
+	c := newComparer(func(path string, a reflect.Value, b reflect.Value) {
+		differences = append(differences, Difference{Path: path, A: a, B: b})
+	}, opts...)
+	c.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return differences
+}
+
+// comparedKey identifies a pair of pointers, maps, or slices that are being compared.
+type comparedKey struct {
+	a         uintptr
+	b         uintptr
+	valueType reflect.Type
+}
+
+// comparer holds the state of a deep comparison.
+// Without a report function, it stops at the first difference.
+type comparer struct {
+	config  *equalConfig
+	visited map[comparedKey]struct{}
+	report  func(path string, a reflect.Value, b reflect.Value)
+}
+
+// newComparer returns a comparer with the options applied.
+func newComparer(report func(path string, a reflect.Value, b reflect.Value), opts ...EqualOption) *comparer {
+	return &comparer{
+		config:  configureEqual(opts...),
+		visited: make(map[comparedKey]struct{}),
+		report:  report,
+	}
+}
+
+// differ reports the difference at the path, if there is a report function, and returns false.
+func (c *comparer) differ(path string, a reflect.Value, b reflect.Value) bool {
+	if c.report != nil {
+		c.report(path, a, b)
+	}
+	return false
+}
+
+// same returns the equality of the values at the path and reports them if they differ.
+func (c *comparer) same(equal bool, path string, a reflect.Value, b reflect.Value) bool {
+	if !equal {
+		return c.differ(path, a, b)
+	}
+	return true
+}
+
+// compare returns true if the values at the path are deeply equal.
+func (c *comparer) compare(path string, a reflect.Value, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return c.same(a.IsValid() == b.IsValid(), path, a, b)
+	}
+	if a.Type() != b.Type() {
+		return c.differ(path, a, b)
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return c.same(a.IsNil() == b.IsNil(), path, a, b)
+		}
+		if c.alreadyCompared(a, b) {
+			return true
+		}
+		return c.compare(path, a.Elem(), b.Elem())
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !c.config.nilEqualsEmpty {
+			return c.differ(path, a, b)
+		}
+		if c.alreadyCompared(a, b) {
+			return true
+		}
+		return c.compareMaps(path, a, b)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return c.same(a.IsNil() == b.IsNil(), path, a, b)
+		}
+		return c.compare(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		equal := true
+		for fieldIndex := 0; fieldIndex < a.NumField() && (equal || c.report != nil); fieldIndex++ {
+			fieldName := a.Type().Field(fieldIndex).Name
+			fieldPath := FieldPath(path, fieldName)
+			if c.ignored(fieldName, fieldPath) {
+				continue
+			}
+			equal = c.compare(fieldPath, a.Field(fieldIndex), b.Field(fieldIndex)) && equal
+		}
+		return equal
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() && !c.config.nilEqualsEmpty {
+			return c.differ(path, a, b)
+		}
+		if a.Len() == b.Len() && (a.Len() == 0 || a.Pointer() == b.Pointer()) {
+			return true
+		}
+		if c.alreadyCompared(a, b) {
+			return true
+		}
+		return c.compareElements(path, a, b)
+	case reflect.Array:
+		return c.compareElements(path, a, b)
+	case reflect.Float32, reflect.Float64:
+		return c.same(a.Float() == b.Float() || math.Abs(a.Float()-b.Float()) <= c.config.floatTolerance, path, a, b)
+	case reflect.Complex64, reflect.Complex128:
+		return c.same(a.Complex() == b.Complex() || cmplx.Abs(a.Complex()-b.Complex()) <= c.config.floatTolerance, path, a, b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.same(a.Int() == b.Int(), path, a, b)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return c.same(a.Uint() == b.Uint(), path, a, b)
+	case reflect.String:
+		return c.same(a.String() == b.String(), path, a, b)
+	case reflect.Bool:
+		return c.same(a.Bool() == b.Bool(), path, a, b)
+	case reflect.Func:
+		return c.same(a.IsNil() && b.IsNil(), path, a, b)
+	case reflect.Chan, reflect.UnsafePointer:
+		return c.same(a.Pointer() == b.Pointer(), path, a, b)
+	default:
+		return c.differ(path, a, b)
+	}
+}
+
+// alreadyCompared returns true if the pointers or maps are the same or if the pointers, maps, or slices
+// are already being compared, which stops cycles. Otherwise, it marks them as being compared.
+// Slices that share their first element can have different lengths, so they are never the same here.
+func (c *comparer) alreadyCompared(a reflect.Value, b reflect.Value) bool {
+	if a.Kind() != reflect.Slice && a.Pointer() == b.Pointer() {
+		return true
+	}
+	key := comparedKey{a: a.Pointer(), b: b.Pointer(), valueType: a.Type()}
+	if _, visited := c.visited[key]; visited {
+		return true
+	}
+	c.visited[key] = struct{}{}
+	return false
+}
+
+// compareMaps returns true if the maps have the same keys with equal values.
+// Keys that are only in one of the maps are reported with an invalid value for the other.
+func (c *comparer) compareMaps(path string, a reflect.Value, b reflect.Value) bool {
+	if a.Len() != b.Len() && c.report == nil {
+		return false
+	}
+	keys := a.MapKeys()
+	if c.report != nil {
+		keys = sortedMapKeys(a)
+	}
+	equal := true
+	for _, key := range keys {
+		equal = c.compare(KeyPath(path, key), a.MapIndex(key), b.MapIndex(key)) && equal
+		if !equal && c.report == nil {
+			return false
+		}
+	}
+	for _, key := range sortedMapKeys(b) {
+		if !a.MapIndex(key).IsValid() {
+			equal = c.differ(KeyPath(path, key), reflect.Value{}, b.MapIndex(key))
+		}
+	}
+	return equal
+}
+
+// compareElements returns true if the slices or arrays have the same length and equal elements.
+// Elements past the end of the shorter one are reported with an invalid value for it.
+func (c *comparer) compareElements(path string, a reflect.Value, b reflect.Value) bool {
+	if a.Len() != b.Len() && c.report == nil {
+		return false
+	}
+	equal := true
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		var aElement, bElement reflect.Value
+		if i < a.Len() {
+			aElement = a.Index(i)
+		}
+		if i < b.Len() {
+			bElement = b.Index(i)
+		}
+		equal = c.compare(IndexPath(path, i), aElement, bElement) && equal
+		if !equal && c.report == nil {
+			return false
+		}
+	}
+	return equal
+}
+
+// ignored returns true if the field should be skipped.
+func (c *comparer) ignored(fieldName string, fieldPath string) bool {
+	if _, ignored := c.config.ignoredFields[fieldName]; ignored {
+		return true
+	}
+	_, ignored := c.config.ignoredFields[fieldPath]
+	return ignored
+}
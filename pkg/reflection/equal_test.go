@@ -0,0 +1,198 @@
+package reflection_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/reflection"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID    int
+		Name  string
+		Price float64
+	}
+
+	type order struct {
+		ID      int
+		Items   []item
+		Notes   map[string]string
+		Owner   *item
+		private string
+	}
+
+	t.Run("when no options are given it should agree with reflect.DeepEqual", func(t *testing.T) {
+		t.Parallel()
+		channel := make(chan int)
+		shared := &item{ID: 1}
+		cases := [][2]any{
+			{nil, nil},
+			{1, 1},
+			{1, 2},
+			{1, int64(1)},
+			{"a", "a"},
+			{1.5, 1.5},
+			{math.NaN(), math.NaN()},
+			{complex(1, 2), complex(1, 2)},
+			{true, false},
+			{uint8(1), uint8(1)},
+			{[]int(nil), []int{}},
+			{[]int{1, 2}, []int{1, 2}},
+			{[]int{1, 2}, []int{2, 1}},
+			{[2]int{1, 2}, [2]int{1, 2}},
+			{map[string]int(nil), map[string]int{}},
+			{map[string]int{"a": 1}, map[string]int{"a": 1}},
+			{map[string]int{"a": 1}, map[string]int{"b": 1}},
+			{map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}},
+			{shared, shared},
+			{&item{ID: 1}, &item{ID: 1}},
+			{&item{ID: 1}, (*item)(nil)},
+			{order{private: "a"}, order{private: "b"}},
+			{order{Items: []item{{ID: 1}}}, order{Items: []item{{ID: 1}}}},
+			{[]any{1, "a"}, []any{1, "a"}},
+			{[]any{1, nil}, []any{1, "a"}},
+			{channel, channel},
+			{channel, make(chan int)},
+			{(func())(nil), (func())(nil)},
+			{func() {}, func() {}},
+		}
+		for _, pair := range cases {
+			assert.Equals(t, reflection.Equal(pair[0], pair[1]), reflect.DeepEqual(pair[0], pair[1]))
+		}
+	})
+
+	t.Run("when values have cycles it should compare them without looping", func(t *testing.T) {
+		t.Parallel()
+		type node struct {
+			Value int
+			Next  *node
+		}
+		a := &node{Value: 1}
+		a.Next = a
+		b := &node{Value: 1}
+		b.Next = b
+		assert.True(t, reflection.Equal(a, b))
+		c := &node{Value: 1}
+		c.Next = &node{Value: 2}
+		assert.False(t, reflection.Equal(a, c))
+	})
+
+	t.Run("when slices and maps contain themselves it should compare them without looping", func(t *testing.T) {
+		t.Parallel()
+		a := []any{nil, 1}
+		a[0] = a
+		b := []any{nil, 2}
+		b[0] = b
+		c := []any{nil, 1}
+		c[0] = c
+		assert.False(t, reflection.Equal(a, b))
+		assert.True(t, reflection.Equal(a, c))
+		differences := reflection.Diff(a, b)
+		assert.Equals(t, len(differences), 1)
+		assert.Equals(t, differences[0].Path, "[1]")
+
+		m := map[string]any{"value": 1}
+		m["self"] = m
+		n := map[string]any{"value": 2}
+		n["self"] = n
+		assert.False(t, reflection.Equal(m, n))
+		differences = reflection.Diff(m, n)
+		assert.Equals(t, len(differences), 1)
+		assert.Equals(t, differences[0].Path, `["value"]`)
+	})
+
+	t.Run("when fields are ignored it should skip them by name or path", func(t *testing.T) {
+		t.Parallel()
+		a := order{ID: 1, Items: []item{{ID: 1, Name: "a"}}, Owner: &item{ID: 1, Name: "owner"}}
+		b := order{ID: 2, Items: []item{{ID: 2, Name: "a"}}, Owner: &item{ID: 2, Name: "owner"}}
+		assert.False(t, reflection.Equal(a, b))
+		assert.False(t, reflection.Equal(a, b, reflection.WithIgnoredFields("Owner.ID")))
+		assert.True(t, reflection.Equal(a, b, reflection.WithIgnoredFields("ID")))
+		assert.True(t, reflection.Equal(a, b, reflection.WithIgnoredFields("ID", "Owner.ID", "Items[0].ID")))
+		b.Items[0].Name = "b"
+		assert.False(t, reflection.Equal(a, b, reflection.WithIgnoredFields("ID")))
+	})
+
+	t.Run("when a float tolerance is given it should accept small differences", func(t *testing.T) {
+		t.Parallel()
+		tenth, fifth := 0.1, 0.2
+		a := item{Price: tenth + fifth}
+		b := item{Price: 0.3}
+		assert.False(t, reflection.Equal(a, b))
+		assert.True(t, reflection.Equal(a, b, reflection.WithFloatTolerance(1e-9)))
+		assert.False(t, reflection.Equal(item{Price: 1}, item{Price: 1.1}, reflection.WithFloatTolerance(1e-9)))
+		assert.True(t, reflection.Equal(complex(1, 1), complex(1, 1.0000001), reflection.WithFloatTolerance(1e-6)))
+		assert.True(t, reflection.Equal(float32(1), float32(1.0000001), reflection.WithFloatTolerance(1e-6)))
+		assert.False(t, reflection.Equal(math.NaN(), math.NaN(), reflection.WithFloatTolerance(1)))
+	})
+
+	t.Run("when a negative float tolerance is given it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			reflection.WithFloatTolerance(-1)
+		}, "The float tolerance must not be negative.")
+	})
+
+	t.Run("when nil equals empty it should treat nil slices and maps as empty", func(t *testing.T) {
+		t.Parallel()
+		a := order{}
+		b := order{Items: []item{}, Notes: map[string]string{}}
+		assert.False(t, reflection.Equal(a, b))
+		assert.True(t, reflection.Equal(a, b, reflection.WithNilEqualsEmpty()))
+		assert.True(t, reflection.Equal(b, a, reflection.WithNilEqualsEmpty()))
+		assert.False(t, reflection.Equal(a, order{Items: []item{{}}}, reflection.WithNilEqualsEmpty()))
+		assert.False(t, reflection.Equal(a, order{Notes: map[string]string{"a": ""}}, reflection.WithNilEqualsEmpty()))
+	})
+
+	t.Run("when values are diffed it should report each difference at its path", func(t *testing.T) {
+		t.Parallel()
+		a := order{
+			ID:    1,
+			Items: []item{{ID: 1, Name: "a"}, {ID: 2}},
+			Notes: map[string]string{"same": "x", "changed": "a", "removed": "r"},
+			Owner: &item{Name: "owner"},
+		}
+		b := order{
+			ID:    2,
+			Items: []item{{ID: 1, Name: "b"}},
+			Notes: map[string]string{"same": "x", "changed": "b", "added": "n"},
+			Owner: nil,
+		}
+		differences := reflection.Diff(a, b)
+		paths := make([]string, 0, len(differences))
+		for _, difference := range differences {
+			paths = append(paths, difference.Path)
+		}
+		assert.Equals(t, paths, []string{
+			"ID",
+			"Items[0].Name",
+			"Items[1]",
+			`Notes["changed"]`,
+			`Notes["removed"]`,
+			`Notes["added"]`,
+			"Owner",
+		})
+		assert.Equals(t, differences[0].A.Int(), int64(1))
+		assert.Equals(t, differences[0].B.Int(), int64(2))
+		assert.False(t, differences[2].B.IsValid())
+		assert.False(t, differences[5].A.IsValid())
+	})
+
+	t.Run("when diffed values are equal it should return nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, reflection.Diff(order{ID: 1}, order{ID: 1}))
+		assert.Nil(t, reflection.Diff(order{ID: 1}, order{ID: 2}, reflection.WithIgnoredFields("ID")))
+	})
+
+	t.Run("when diffed values have different types it should report the root", func(t *testing.T) {
+		t.Parallel()
+		differences := reflection.Diff(1, "1")
+		assert.Equals(t, len(differences), 1)
+		assert.Equals(t, differences[0].Path, "")
+	})
+}
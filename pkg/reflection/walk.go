@@ -0,0 +1,117 @@
+package reflection
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// WalkFunc is called by Walk for every value it visits. The path locates the value in the walked value,
+// for example "Server.Ports[0]" or `Labels["env"]`, and is empty for the walked value itself.
+// Returning false skips the fields or elements of the value.
+type WalkFunc func(path string, value reflect.Value) bool
+
+// Walk visits the value and every struct field, slice and array element, and map entry in it, depth first.
+// Pointers and interfaces are visited and then followed, so their fields and elements have the same path prefix
+// as the pointer. A pointer that was already visited is not followed again, and a map or slice is not walked
+// again while its own entries or elements are being walked, which stops cycles.
+// Map entries are visited in the order of their formatted keys so that walks are deterministic.
+func Walk(value any, fn WalkFunc) {
+	w := &walker{
+		fn:      fn,
+		visited: make(map[visitKey]struct{}),
+		walking: make(map[visitKey]struct{}),
+	}
+	w.walk("", reflect.ValueOf(value))
+}
+
+// visitKey identifies a pointer that was already visited, or a map or slice that is being walked.
+type visitKey struct {
+	pointer   uintptr
+	length    int
+	valueType reflect.Type
+}
+
+// walker holds the state of a Walk.
+type walker struct {
+	fn      WalkFunc
+	visited map[visitKey]struct{}
+	walking map[visitKey]struct{}
+}
+
+// walk visits the value at the path and then its children.
+func (w *walker) walk(path string, value reflect.Value) {
+	if !w.fn(path, value) {
+		return
+	}
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return
+		}
+		if value.Kind() == reflect.Ptr {
+			key := visitKey{pointer: value.Pointer(), valueType: value.Type()}
+			if _, visited := w.visited[key]; visited {
+				return
+			}
+			w.visited[key] = struct{}{}
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return
+	}
+	if (value.Kind() == reflect.Map || value.Kind() == reflect.Slice) && value.Len() > 0 {
+		key := visitKey{pointer: value.Pointer(), length: value.Len(), valueType: value.Type()}
+		if _, walking := w.walking[key]; walking {
+			return
+		}
+		w.walking[key] = struct{}{}
+		defer delete(w.walking, key)
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		for fieldIndex := 0; fieldIndex < value.NumField(); fieldIndex++ {
+			w.walk(FieldPath(path, value.Type().Field(fieldIndex).Name), value.Field(fieldIndex))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			w.walk(IndexPath(path, i), value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(value) {
+			w.walk(KeyPath(path, key), value.MapIndex(key))
+		}
+	default:
+	}
+}
+
+// FieldPath returns the path of a struct field in the value at the parent path.
+func FieldPath(parent string, fieldName string) string {
+	if parent == "" {
+		return fieldName
+	}
+	return parent + "." + fieldName
+}
+
+// IndexPath returns the path of a slice or array element in the value at the parent path.
+func IndexPath(parent string, index int) string {
+	return fmt.Sprintf("%s[%d]", parent, index)
+}
+
+// KeyPath returns the path of a map entry in the value at the parent path. String keys are quoted.
+func KeyPath(parent string, key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return fmt.Sprintf("%s[%q]", parent, key.String())
+	}
+	return fmt.Sprintf("%s[%v]", parent, key)
+}
+
+// sortedMapKeys returns the keys of the map sorted by their formatted value.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
@@ -0,0 +1,119 @@
+package reflection_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/reflection"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host  string
+		Ports []int
+	}
+
+	type testStruct struct {
+		Name    string
+		Server  *server
+		Labels  map[string]int
+		Any     any
+		private bool
+	}
+
+	collect := func(value any) []string {
+		var paths []string
+		reflection.Walk(value, func(path string, value reflect.Value) bool {
+			paths = append(paths, path)
+			return true
+		})
+		return paths
+	}
+
+	t.Run("when a value is walked it should visit every field, element, and entry with its path", func(t *testing.T) {
+		t.Parallel()
+		paths := collect(testStruct{
+			Name:   "name",
+			Server: &server{Host: "host", Ports: []int{80, 443}},
+			Labels: map[string]int{"b": 2, "a": 1},
+			Any:    [1]string{"value"},
+		})
+		assert.Equals(t, paths, []string{
+			"",
+			"Name",
+			"Server",
+			"Server.Host",
+			"Server.Ports",
+			"Server.Ports[0]",
+			"Server.Ports[1]",
+			"Labels",
+			`Labels["a"]`,
+			`Labels["b"]`,
+			"Any",
+			"Any[0]",
+			"private",
+		})
+	})
+
+	t.Run("when a visited value is given it should be the value at the path", func(t *testing.T) {
+		t.Parallel()
+		values := map[string]any{}
+		reflection.Walk(map[int]*server{1: {Host: "host"}}, func(path string, value reflect.Value) bool {
+			if value.CanInterface() {
+				values[path] = value.Interface()
+			}
+			return true
+		})
+		assert.Equals(t, values["[1].Host"], any("host"))
+		assert.Equals(t, values["[1].Ports"], any([]int(nil)))
+	})
+
+	t.Run("when the callback returns false it should not visit the children", func(t *testing.T) {
+		t.Parallel()
+		var paths []string
+		reflection.Walk(testStruct{Server: &server{Host: "host"}}, func(path string, value reflect.Value) bool {
+			paths = append(paths, path)
+			return path != "Server"
+		})
+		assert.Equals(t, paths, []string{"", "Name", "Server", "Labels", "Any", "private"})
+	})
+
+	t.Run("when the value has a cycle it should visit each pointer once", func(t *testing.T) {
+		t.Parallel()
+		type node struct {
+			Next *node
+		}
+		first := &node{}
+		first.Next = &node{Next: first}
+		assert.Equals(t, collect(first), []string{"", "Next", "Next.Next"})
+	})
+
+	t.Run("when a map or slice contains itself it should not walk it again", func(t *testing.T) {
+		t.Parallel()
+		m := map[string]any{"value": 1}
+		m["self"] = m
+		assert.Equals(t, collect(m), []string{"", `["self"]`, `["value"]`})
+		s := []any{nil, 1}
+		s[0] = s
+		assert.Equals(t, collect(s), []string{"", "[0]", "[1]"})
+	})
+
+	t.Run("when a map is referenced twice it should walk both references", func(t *testing.T) {
+		t.Parallel()
+		shared := map[string]int{"key": 1}
+		value := struct {
+			A map[string]int
+			B map[string]int
+		}{A: shared, B: shared}
+		assert.Equals(t, collect(value), []string{"", "A", `A["key"]`, "B", `B["key"]`})
+	})
+
+	t.Run("when nil is walked it should only visit the root", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, collect(nil), []string{""})
+		assert.Equals(t, collect((*server)(nil)), []string{""})
+	})
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// config holds the configuration of a Scheduler.
+type config struct {
+	clock    timestamp.Clock
+	location *time.Location
+	hooks    []Hook
+}
+
+// Option configures a Scheduler.
+type Option func(*config)
+
+// WithClock sets the Clock used to wait for the runs. It is meant for tests that use a timestamp.FakeClock.
+func WithClock(clock timestamp.Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = clock
+	}
+}
+
+// WithLocation sets the location the cron expressions are evaluated in. The default is the local time.
+func WithLocation(location *time.Location) Option {
+	return func(cfg *config) {
+		cfg.location = location
+	}
+}
+
+// WithHooks adds hooks that are called around each run of every job.
+func WithHooks(hooks ...Hook) Option {
+	return func(cfg *config) {
+		cfg.hooks = append(cfg.hooks, hooks...)
+	}
+}
+
+// configure creates a config out of the provided options and validates it.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		clock:    timestamp.SystemClock(),
+		location: time.Local,
+		hooks:    make([]Hook, 0),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.location == nil {
+		panic("The location cannot be nil.")
+	}
+	for _, hook := range cfg.hooks {
+		if hook == nil {
+			panic("The hooks cannot be nil.")
+		}
+	}
+	return cfg
+}
+
+// OverlapPolicy determines what happens when a job is due while its previous run is not done.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip skips the run. This is the default.
+	OverlapSkip OverlapPolicy = iota
+
+	// OverlapAllow starts the run alongside the previous one.
+	OverlapAllow
+
+	// OverlapQueue starts the run once the previous one is done. At most one run is queued.
+	OverlapQueue
+)
+
+// jobConfig holds the configuration of a job.
+type jobConfig struct {
+	overlapPolicy OverlapPolicy
+	jitter        time.Duration
+}
+
+// JobOption configures a job.
+type JobOption func(*jobConfig)
+
+// WithOverlapPolicy sets what happens when the job is due while its previous run is not done.
+func WithOverlapPolicy(policy OverlapPolicy) JobOption {
+	return func(cfg *jobConfig) {
+		cfg.overlapPolicy = policy
+	}
+}
+
+// WithJitter delays each run by a random duration up to the maximum. It spreads the load of jobs
+// that are scheduled at the same time across many instances.
+func WithJitter(maximum time.Duration) JobOption {
+	return func(cfg *jobConfig) {
+		cfg.jitter = maximum
+	}
+}
+
+// configureJob creates a jobConfig out of the provided options and validates it.
+func configureJob(opts ...JobOption) *jobConfig {
+	cfg := &jobConfig{
+		overlapPolicy: OverlapSkip,
+		jitter:        0,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	switch cfg.overlapPolicy {
+	case OverlapSkip, OverlapAllow, OverlapQueue:
+	default:
+		panic("The overlap policy is not valid.")
+	}
+	if cfg.jitter < 0 {
+		panic("The jitter cannot be negative.")
+	}
+	return cfg
+}
@@ -0,0 +1,36 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/scheduler"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the scheduler options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			scheduler.New(scheduler.WithLocation(nil))
+		}, "The location cannot be nil.")
+		assert.PanicExact(t, func() {
+			scheduler.New(scheduler.WithHooks(nil))
+		}, "The hooks cannot be nil.")
+	})
+
+	t.Run("when the job options are invalid it should panic", func(t *testing.T) {
+		t.Parallel()
+		sched := scheduler.New()
+		job := func(context.Context) error { return nil }
+		assert.PanicExact(t, func() {
+			sched.MustAdd("job", scheduler.Every(time.Second), job, scheduler.WithOverlapPolicy(scheduler.OverlapPolicy(10)))
+		}, "The overlap policy is not valid.")
+		assert.PanicExact(t, func() {
+			sched.MustAdd("job", scheduler.Every(time.Second), job, scheduler.WithJitter(-1))
+		}, "The jitter cannot be negative.")
+	})
+}
@@ -0,0 +1,258 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule determines when a job runs.
+type Schedule interface {
+	// Next returns the first time after the given time that the job should run.
+	Next(after time.Time) time.Time
+}
+
+// interval is a Schedule with a fixed time between the runs.
+type interval struct {
+	every time.Duration
+}
+
+// Every returns a Schedule that runs a job on a fixed interval.
+func Every(every time.Duration) Schedule {
+	if every <= 0 {
+		panic("The interval must be greater than zero.")
+	}
+	return interval{every: every}
+}
+
+// Next returns the time one interval after the given time.
+func (i interval) Next(after time.Time) time.Time {
+	return after.Add(i.every)
+}
+
+// cronField describes one of the five fields of a cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	// cronFields are the fields of a cron expression in order.
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: map[string]int{
+			"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+			"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+		}},
+		{name: "day of week", min: 0, max: 7, names: map[string]int{
+			"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+		}},
+	}
+
+	// cronDescriptors are the shorthands for common cron expressions.
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+const (
+	// cronSearchLimit is how far in the future Next looks for a matching time, which is enough for a leap day.
+	cronSearchLimit = time.Hour * 24 * 366 * 5
+)
+
+// cron is a Schedule parsed from a cron expression. Each field is a bit set of the allowed values.
+type cron struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	anyDOM      bool
+	anyDOW      bool
+}
+
+// ParseCron parses a standard cron expression with the fields minute, hour, day of month, month, and day of week.
+// The fields accept "*", values, ranges like "1-5", lists like "1,15", and steps like "*/15" or "0-30/10".
+// The months and days of the week can be names like "JAN" or "MON", and Sunday is 0 or 7. When both the day of
+// the month and the day of the week are restricted, a day matching either of them is allowed.
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly, and "@every <duration>"
+// are also accepted. The times are evaluated in the location of the time given to Next.
+func ParseCron(expression string) (Schedule, error) {
+	trimmed := strings.TrimSpace(expression)
+	if strings.HasPrefix(trimmed, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(trimmed, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s' (%w)", expression, err)
+		}
+		if every <= 0 {
+			return nil, fmt.Errorf("invalid cron expression '%s' (the interval must be greater than zero)", expression)
+		}
+		return Every(every), nil
+	}
+	if strings.HasPrefix(trimmed, "@") {
+		descriptor, found := cronDescriptors[strings.ToLower(trimmed)]
+		if !found {
+			return nil, fmt.Errorf("invalid cron expression '%s' (unknown descriptor)", expression)
+		}
+		trimmed = descriptor
+	}
+
+	fields := strings.Fields(trimmed)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression '%s' (expected %d fields but got %d)", expression, len(cronFields), len(fields))
+	}
+	sets := make([]uint64, len(cronFields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s' (%w)", expression, err)
+		}
+		sets[i] = set
+	}
+
+	daysOfWeek := sets[4]
+	if daysOfWeek&(1<<7) != 0 {
+		daysOfWeek = (daysOfWeek | 1) &^ (1 << 7)
+	}
+	return &cron{
+		minutes:     sets[0],
+		hours:       sets[1],
+		daysOfMonth: sets[2],
+		months:      sets[3],
+		daysOfWeek:  daysOfWeek,
+		anyDOM:      isWildcard(fields[2]),
+		anyDOW:      isWildcard(fields[4]),
+	}, nil
+}
+
+// MustParseCron is like ParseCron but panics if the expression cannot be parsed.
+func MustParseCron(expression string) Schedule {
+	schedule, err := ParseCron(expression)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the cron expression (%s).", err.Error()))
+	}
+	return schedule
+}
+
+// isWildcard returns true if the field allows any value.
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField parses a comma separated list of ranges into a bit set.
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsedStep, err := strconv.Atoi(stepPart)
+			if err != nil || parsedStep <= 0 {
+				return 0, fmt.Errorf("the step '%s' of the %s field must be a number greater than zero", stepPart, spec.name)
+			}
+			step = parsedStep
+		}
+
+		var low, high int
+		switch {
+		case isWildcard(rangePart):
+			low, high = spec.min, spec.max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(lowPart, spec); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(highPart, spec); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("the range '%s' of the %s field must not be descending", rangePart, spec.name)
+			}
+		default:
+			value, err := parseCronValue(rangePart, spec)
+			if err != nil {
+				return 0, err
+			}
+			low, high = value, value
+			if hasStep {
+				high = spec.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a number or a name of a field and checks that it is in range.
+func parseCronValue(value string, spec cronField) (int, error) {
+	if named, found := spec.names[strings.ToUpper(value)]; found {
+		return named, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("the value '%s' of the %s field is not a number", value, spec.name)
+	}
+	if parsed < spec.min || parsed > spec.max {
+		return 0, fmt.Errorf("the value '%s' of the %s field is out of the range %d-%d", value, spec.name, spec.min, spec.max)
+	}
+	return parsed, nil
+}
+
+// Next returns the first minute after the given time that matches the expression. It skips the months, days,
+// and hours that do not match. The zero time is returned if no time matches within five years, like the 30th of February.
+func (c *cron) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the day of month and day of week fields. If both are restricted, either can match.
+func (c *cron) dayMatches(t time.Time) bool {
+	domMatch := c.daysOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := c.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.anyDOM && c.anyDOW:
+		return true
+	case c.anyDOM:
+		return dowMatch
+	case c.anyDOW:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
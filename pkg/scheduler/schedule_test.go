@@ -0,0 +1,134 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/scheduler"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestEvery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the interval is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			scheduler.Every(0)
+		}, "The interval must be greater than zero.")
+	})
+
+	t.Run("when the next time is requested it should add the interval", func(t *testing.T) {
+		t.Parallel()
+		start := date(2024, time.January, 1, 0, 0)
+		assert.Equals(t, scheduler.Every(time.Second*90).Next(start), start.Add(time.Second*90))
+	})
+}
+
+func TestParseCron(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the next times are requested it should follow the expression", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			expression string
+			after      time.Time
+			expected   []time.Time
+		}{
+			{"* * * * *", date(2024, time.January, 1, 0, 0).Add(time.Second * 30), []time.Time{
+				date(2024, time.January, 1, 0, 1), date(2024, time.January, 1, 0, 2),
+			}},
+			{"*/15 * * * *", date(2024, time.January, 1, 0, 7), []time.Time{
+				date(2024, time.January, 1, 0, 15), date(2024, time.January, 1, 0, 30),
+			}},
+			{"0 9-17/4 * * *", date(2024, time.January, 1, 10, 0), []time.Time{
+				date(2024, time.January, 1, 13, 0), date(2024, time.January, 1, 17, 0), date(2024, time.January, 2, 9, 0),
+			}},
+			{"30 2 1,15 * *", date(2024, time.January, 2, 0, 0), []time.Time{
+				date(2024, time.January, 15, 2, 30), date(2024, time.February, 1, 2, 30),
+			}},
+			{"0 0 * * MON-FRI", date(2024, time.January, 5, 12, 0), []time.Time{
+				date(2024, time.January, 8, 0, 0), date(2024, time.January, 9, 0, 0),
+			}},
+			{"0 0 * * 7", date(2024, time.January, 1, 0, 0), []time.Time{
+				date(2024, time.January, 7, 0, 0), date(2024, time.January, 14, 0, 0),
+			}},
+			{"0 0 13 * 5", date(2024, time.January, 1, 0, 0), []time.Time{
+				date(2024, time.January, 5, 0, 0), date(2024, time.January, 12, 0, 0), date(2024, time.January, 13, 0, 0),
+			}},
+			{"0 0 29 feb *", date(2024, time.March, 1, 0, 0), []time.Time{
+				date(2028, time.February, 29, 0, 0),
+			}},
+			{"5/20 * * * *", date(2024, time.January, 1, 0, 0), []time.Time{
+				date(2024, time.January, 1, 0, 5), date(2024, time.January, 1, 0, 25), date(2024, time.January, 1, 0, 45),
+			}},
+			{"@hourly", date(2024, time.January, 1, 0, 0), []time.Time{date(2024, time.January, 1, 1, 0)}},
+			{"@daily", date(2024, time.January, 1, 0, 0), []time.Time{date(2024, time.January, 2, 0, 0)}},
+			{"@weekly", date(2024, time.January, 1, 0, 0), []time.Time{date(2024, time.January, 7, 0, 0)}},
+			{"@monthly", date(2024, time.January, 1, 0, 0), []time.Time{date(2024, time.February, 1, 0, 0)}},
+			{"@yearly", date(2024, time.January, 1, 0, 0), []time.Time{date(2025, time.January, 1, 0, 0)}},
+			{"@every 90s", date(2024, time.January, 1, 0, 0), []time.Time{date(2024, time.January, 1, 0, 0).Add(time.Second * 90)}},
+		}
+		for _, testCase := range testCases {
+			schedule := scheduler.MustParseCron(testCase.expression)
+			after := testCase.after
+			for _, expected := range testCase.expected {
+				next := schedule.Next(after)
+				if !next.Equal(expected) {
+					t.Fatalf("Expected %s after %s to be %s but got %s.", testCase.expression, after, expected, next)
+				}
+				after = next
+			}
+		}
+	})
+
+	t.Run("when no time matches the expression it should return the zero time", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, scheduler.MustParseCron("0 0 30 2 *").Next(date(2024, time.January, 1, 0, 0)).IsZero())
+	})
+
+	t.Run("when the expression is evaluated it should use the location of the time", func(t *testing.T) {
+		t.Parallel()
+		location := time.FixedZone("UTC+2", 2*60*60)
+		next := scheduler.MustParseCron("0 9 * * *").Next(time.Date(2024, time.January, 1, 0, 0, 0, 0, location))
+		assert.Equals(t, next, time.Date(2024, time.January, 1, 9, 0, 0, 0, location))
+	})
+
+	t.Run("when the expression is invalid it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			expression string
+			errPart    string
+		}{
+			{"* * * *", "expected 5 fields but got 4"},
+			{"60 * * * *", "the value '60' of the minute field is out of the range 0-59"},
+			{"* 24 * * *", "the value '24' of the hour field is out of the range 0-23"},
+			{"* * 0 * *", "the value '0' of the day of month field is out of the range 1-31"},
+			{"* * * 13 *", "the value '13' of the month field is out of the range 1-12"},
+			{"* * * * 8", "the value '8' of the day of week field is out of the range 0-7"},
+			{"a * * * *", "the value 'a' of the minute field is not a number"},
+			{"*/0 * * * *", "the step '0' of the minute field must be a number greater than zero"},
+			{"10-5 * * * *", "the range '10-5' of the minute field must not be descending"},
+			{"@often", "unknown descriptor"},
+			{"@every nope", "time: invalid duration"},
+			{"@every -1s", "the interval must be greater than zero"},
+		}
+		for _, testCase := range testCases {
+			schedule, err := scheduler.ParseCron(testCase.expression)
+			assert.ErrorPart(t, err, testCase.errPart)
+			assert.ErrorPart(t, err, "invalid cron expression '"+testCase.expression+"'")
+			assert.Nil(t, schedule)
+		}
+	})
+
+	t.Run("when MustParseCron is called with an invalid expression it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicPart(t, func() {
+			scheduler.MustParseCron("invalid")
+		}, "Failed to parse the cron expression (invalid cron expression 'invalid'")
+	})
+}
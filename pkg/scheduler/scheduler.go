@@ -0,0 +1,258 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/contextutil"
+)
+
+// Job is the work done on each run. The context is canceled if the Scheduler is stopped before the run is done.
+type Job func(ctx context.Context) error
+
+// RunInfo describes a run of a job for the hooks.
+type RunInfo struct {
+	Job         string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+}
+
+// Hook is called before each run of a job, like to start a span or a timer. The returned context is given
+// to the job, and the returned function is called with the error of the run once it is done.
+type Hook func(ctx context.Context, info RunInfo) (context.Context, func(err error))
+
+// scheduledJob is a Job with its schedule and the state of its runs.
+type scheduledJob struct {
+	name      string
+	schedule  Schedule
+	job       Job
+	cfg       *jobConfig
+	lock      sync.Mutex
+	running   int
+	queued    bool
+	queuedFor time.Time
+}
+
+// Scheduler runs jobs on schedules. It implements the Start and Stop of app.Component.
+//
+//	sched := scheduler.New()
+//	sched.MustAdd("cleanup", scheduler.MustParseCron("0 3 * * *"), cleanup)
+//	sched.MustAdd("refresh", scheduler.Every(time.Minute), refresh, scheduler.WithJitter(time.Second*10))
+//	err := sched.Start(ctx)
+type Scheduler struct {
+	cfg     *config
+	lock    sync.Mutex
+	jobs    map[string]*scheduledJob
+	started bool
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stop    chan struct{}
+	loops   sync.WaitGroup
+	runs    sync.WaitGroup
+}
+
+// New creates a Scheduler.
+func New(opts ...Option) *Scheduler {
+	return &Scheduler{
+		cfg:  configure(opts...),
+		jobs: make(map[string]*scheduledJob),
+		stop: make(chan struct{}),
+	}
+}
+
+// MustAdd adds a job to the Scheduler. If the Scheduler is started, the job is scheduled right away.
+// It panics if a job with the same name was already added.
+func (s *Scheduler) MustAdd(name string, schedule Schedule, job Job, opts ...JobOption) {
+	if name == "" {
+		panic("The name of the job cannot be empty.")
+	}
+	if schedule == nil || job == nil {
+		panic("The schedule and the job cannot be nil.")
+	}
+	cfg := configureJob(opts...)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		panic("The scheduler is stopped.")
+	}
+	if _, alreadyAdded := s.jobs[name]; alreadyAdded {
+		panic(fmt.Sprintf("The job %s is already added.", name))
+	}
+	scheduled := &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		job:      job,
+		cfg:      cfg,
+	}
+	s.jobs[name] = scheduled
+	if s.started {
+		s.loops.Add(1)
+		go s.loop(scheduled)
+	}
+}
+
+// Start schedules the jobs. The runs get a context with the values of the context, but that is only canceled by Stop.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.started || s.stopped {
+		return errors.New("the scheduler can only be started once")
+	}
+	s.started = true
+	s.ctx, s.cancel = context.WithCancel(contextutil.Detach(ctx))
+	for _, job := range s.jobs {
+		s.loops.Add(1)
+		go s.loop(job)
+	}
+	return nil
+}
+
+// Stop stops scheduling the jobs and waits for the running jobs to be done. If the context is done first,
+// the context of the runs is canceled and an error is returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.lock.Lock()
+	if s.stopped || !s.started {
+		s.stopped = true
+		s.lock.Unlock()
+		return nil
+	}
+	s.stopped = true
+	close(s.stop)
+	s.lock.Unlock()
+
+	s.loops.Wait()
+	defer s.cancel()
+	runsDone := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(runsDone)
+	}()
+	select {
+	case <-runsDone:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for the running jobs (%w)", ctx.Err())
+	}
+}
+
+// now returns the time of the clock in the location of the Scheduler.
+func (s *Scheduler) now() time.Time {
+	return s.cfg.clock.Now().In(s.cfg.location)
+}
+
+// loop waits for each scheduled time of the job and dispatches its runs until the Scheduler is stopped.
+// Scheduled times that were missed are skipped.
+func (s *Scheduler) loop(job *scheduledJob) {
+	defer s.loops.Done()
+	scheduledAt := s.now()
+	for {
+		now := s.now()
+		next := job.schedule.Next(scheduledAt)
+		if next.Before(now) {
+			next = job.schedule.Next(now)
+		}
+		if next.IsZero() {
+			return
+		}
+		delay := next.Sub(now)
+		if job.cfg.jitter > 0 {
+			delay += rand.N(job.cfg.jitter)
+		}
+		timer := s.cfg.clock.NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-s.stop:
+			timer.Stop()
+			return
+		}
+		scheduledAt = next
+		s.dispatch(job, scheduledAt)
+	}
+}
+
+// dispatch starts a run of the job according to its overlap policy.
+func (s *Scheduler) dispatch(job *scheduledJob, scheduledAt time.Time) {
+	job.lock.Lock()
+	defer job.lock.Unlock()
+	if job.running > 0 {
+		switch job.cfg.overlapPolicy {
+		case OverlapSkip:
+			return
+		case OverlapQueue:
+			job.queued = true
+			job.queuedFor = scheduledAt
+			return
+		case OverlapAllow:
+		}
+	}
+	job.running++
+	s.runs.Add(1)
+	go s.run(job, scheduledAt)
+}
+
+// run executes the job, and then the queued run if there is one and the Scheduler is not stopped.
+func (s *Scheduler) run(job *scheduledJob, scheduledAt time.Time) {
+	defer s.runs.Done()
+	for {
+		s.execute(job, scheduledAt)
+		job.lock.Lock()
+		if job.queued && !s.isStopped() {
+			job.queued = false
+			scheduledAt = job.queuedFor
+			job.lock.Unlock()
+			continue
+		}
+		job.queued = false
+		job.running--
+		job.lock.Unlock()
+		return
+	}
+}
+
+// isStopped returns true if Stop was called.
+func (s *Scheduler) isStopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// execute calls the hooks and the job. A panic of the job is converted into an error.
+func (s *Scheduler) execute(job *scheduledJob, scheduledAt time.Time) {
+	ctx := s.ctx
+	info := RunInfo{
+		Job:         job.name,
+		ScheduledAt: scheduledAt,
+		StartedAt:   s.now(),
+	}
+	finishers := make([]func(error), 0, len(s.cfg.hooks))
+	for _, hook := range s.cfg.hooks {
+		var finish func(error)
+		ctx, finish = hook(ctx, info)
+		if finish != nil {
+			finishers = append(finishers, finish)
+		}
+	}
+	err := call(ctx, job.job)
+	for i := len(finishers) - 1; i >= 0; i-- {
+		finishers[i](err)
+	}
+}
+
+// call calls the job and converts a panic into an error.
+func call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("the job panicked (%v)", recovered)
+		}
+	}()
+	return job(ctx)
+}
@@ -0,0 +1,304 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/scheduler"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+type runRecorder struct {
+	lock  sync.Mutex
+	infos []scheduler.RunInfo
+	errs  []error
+	done  chan struct{}
+}
+
+func newRunRecorder() *runRecorder {
+	return &runRecorder{done: make(chan struct{}, 100)}
+}
+
+func (r *runRecorder) hook(ctx context.Context, info scheduler.RunInfo) (context.Context, func(error)) {
+	return ctx, func(err error) {
+		r.lock.Lock()
+		r.infos = append(r.infos, info)
+		r.errs = append(r.errs, err)
+		r.lock.Unlock()
+		r.done <- struct{}{}
+	}
+}
+
+func (r *runRecorder) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("The run did not finish in time.")
+	}
+}
+
+func (r *runRecorder) results() ([]scheduler.RunInfo, []error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]scheduler.RunInfo{}, r.infos...), append([]error{}, r.errs...)
+}
+
+func newTestScheduler(t *testing.T, recorder *runRecorder) (*scheduler.Scheduler, *timestamp.FakeClock) {
+	t.Helper()
+	clock := timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 30, 0, time.UTC))
+	sched := scheduler.New(
+		scheduler.WithClock(clock),
+		scheduler.WithLocation(time.UTC),
+		scheduler.WithHooks(recorder.hook),
+	)
+	t.Cleanup(func() {
+		assert.NoError(t, sched.Stop(context.Background()))
+	})
+	return sched, clock
+}
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a cron job is due it should run it with the hooks", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		var runs atomic.Int64
+		sched.MustAdd("report", scheduler.MustParseCron("*/5 * * * *"), func(context.Context) error {
+			if runs.Add(1) == 2 {
+				return errors.New("job error")
+			}
+			return nil
+		})
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute*4 + time.Second*30)
+		recorder.wait(t)
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute * 5)
+		recorder.wait(t)
+
+		infos, errs := recorder.results()
+		assert.Equals(t, infos, []scheduler.RunInfo{
+			{Job: "report", ScheduledAt: time.Date(2024, time.January, 1, 0, 5, 0, 0, time.UTC), StartedAt: time.Date(2024, time.January, 1, 0, 5, 0, 0, time.UTC)},
+			{Job: "report", ScheduledAt: time.Date(2024, time.January, 1, 0, 10, 0, 0, time.UTC), StartedAt: time.Date(2024, time.January, 1, 0, 10, 0, 0, time.UTC)},
+		})
+		assert.NoError(t, errs[0])
+		assert.ErrorExact(t, errs[1], "job error")
+	})
+
+	t.Run("when a job panics it should recover and report the panic to the hooks", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		sched.MustAdd("panics", scheduler.Every(time.Minute), func(context.Context) error {
+			panic("job failure")
+		})
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		recorder.wait(t)
+		_, errs := recorder.results()
+		assert.ErrorExact(t, errs[0], "the job panicked (job failure)")
+	})
+
+	t.Run("when the hooks return a context it should be given to the job", func(t *testing.T) {
+		t.Parallel()
+		type contextKey struct{}
+		recorder := newRunRecorder()
+		clock := timestamp.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		sched := scheduler.New(scheduler.WithClock(clock), scheduler.WithHooks(
+			func(ctx context.Context, _ scheduler.RunInfo) (context.Context, func(error)) {
+				return context.WithValue(ctx, contextKey{}, "span"), nil
+			},
+			recorder.hook,
+		))
+		defer func() { assert.NoError(t, sched.Stop(context.Background())) }()
+		values := make(chan any, 1)
+		sched.MustAdd("traced", scheduler.Every(time.Minute), func(ctx context.Context) error {
+			values <- ctx.Value(contextKey{})
+			return nil
+		})
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		recorder.wait(t)
+		assert.Equals(t, <-values, any("span"))
+	})
+
+	t.Run("when a job overlaps itself it should follow its overlap policy", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			policy        scheduler.OverlapPolicy
+			expectedRuns  int64
+			maxConcurrent int64
+		}{
+			{scheduler.OverlapSkip, 1, 1},
+			{scheduler.OverlapQueue, 2, 1},
+			{scheduler.OverlapAllow, 3, 3},
+		}
+		for _, testCase := range testCases {
+			recorder := newRunRecorder()
+			sched, clock := newTestScheduler(t, recorder)
+			release := make(chan struct{})
+			var running atomic.Int64
+			var maxRunning atomic.Int64
+			var runs atomic.Int64
+			sched.MustAdd("slow", scheduler.Every(time.Minute), func(context.Context) error {
+				runs.Add(1)
+				current := running.Add(1)
+				defer running.Add(-1)
+				for observed := maxRunning.Load(); current > observed; observed = maxRunning.Load() {
+					if maxRunning.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				<-release
+				return nil
+			}, scheduler.WithOverlapPolicy(testCase.policy))
+			assert.NoError(t, sched.Start(context.Background()))
+			for range 3 {
+				clock.BlockUntil(1)
+				clock.Advance(time.Minute)
+			}
+			clock.BlockUntil(1)
+			if testCase.policy == scheduler.OverlapAllow {
+				for running.Load() != 3 {
+					time.Sleep(time.Millisecond)
+				}
+			}
+			close(release)
+			for range testCase.expectedRuns {
+				recorder.wait(t)
+			}
+			assert.NoError(t, sched.Stop(context.Background()))
+			assert.Equals(t, runs.Load(), testCase.expectedRuns)
+			assert.Equals(t, maxRunning.Load(), testCase.maxConcurrent)
+		}
+	})
+
+	t.Run("when the scheduler is stopped it should wait for the running jobs", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		sched.MustAdd("slow", scheduler.Every(time.Minute), func(context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		<-started
+		stopped := make(chan error)
+		go func() {
+			stopped <- sched.Stop(context.Background())
+		}()
+		select {
+		case <-stopped:
+			t.Fatal("Stop returned before the job was done.")
+		case <-time.After(time.Millisecond * 10):
+		}
+		close(release)
+		assert.NoError(t, <-stopped)
+		assert.Equals(t, clock.Waiters(), 0)
+	})
+
+	t.Run("when the stop context is done it should cancel the running jobs", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		started := make(chan struct{})
+		sched.MustAdd("stuck", scheduler.Every(time.Minute), func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		assert.ErrorExact(t, sched.Stop(ctx), "failed to wait for the running jobs (context deadline exceeded)")
+		recorder.wait(t)
+		_, errs := recorder.results()
+		assert.ErrorExact(t, errs[0], context.Canceled.Error())
+	})
+
+	t.Run("when the start context is canceled it should keep running the jobs", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		sched.MustAdd("job", scheduler.Every(time.Minute), func(ctx context.Context) error {
+			return ctx.Err()
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		assert.NoError(t, sched.Start(ctx))
+		cancel()
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		recorder.wait(t)
+		_, errs := recorder.results()
+		assert.NoError(t, errs[0])
+	})
+
+	t.Run("when a job is added after the start it should be scheduled", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		assert.NoError(t, sched.Start(context.Background()))
+		sched.MustAdd("late", scheduler.Every(time.Minute), func(context.Context) error { return nil })
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		recorder.wait(t)
+		infos, _ := recorder.results()
+		assert.Equals(t, infos[0].Job, "late")
+	})
+
+	t.Run("when a job has jitter it should run by the end of the jitter", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRunRecorder()
+		sched, clock := newTestScheduler(t, recorder)
+		sched.MustAdd("jittered", scheduler.Every(time.Minute), func(context.Context) error { return nil }, scheduler.WithJitter(time.Second*10))
+		assert.NoError(t, sched.Start(context.Background()))
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute + time.Second*10)
+		recorder.wait(t)
+		infos, _ := recorder.results()
+		assert.Equals(t, infos[0].ScheduledAt, time.Date(2024, time.January, 1, 0, 1, 30, 0, time.UTC))
+		assert.False(t, infos[0].StartedAt.Before(infos[0].ScheduledAt))
+	})
+
+	t.Run("when the scheduler is misused it should panic or return an error", func(t *testing.T) {
+		t.Parallel()
+		sched := scheduler.New()
+		job := func(context.Context) error { return nil }
+		sched.MustAdd("job", scheduler.Every(time.Minute), job)
+		assert.PanicExact(t, func() {
+			sched.MustAdd("job", scheduler.Every(time.Minute), job)
+		}, "The job job is already added.")
+		assert.PanicExact(t, func() {
+			sched.MustAdd("", scheduler.Every(time.Minute), job)
+		}, "The name of the job cannot be empty.")
+		assert.PanicExact(t, func() {
+			sched.MustAdd("nil", nil, job)
+		}, "The schedule and the job cannot be nil.")
+		assert.NoError(t, sched.Start(context.Background()))
+		assert.ErrorExact(t, sched.Start(context.Background()), "the scheduler can only be started once")
+		assert.NoError(t, sched.Stop(context.Background()))
+		assert.NoError(t, sched.Stop(context.Background()))
+		assert.PanicExact(t, func() {
+			sched.MustAdd("other", scheduler.Every(time.Minute), job)
+		}, "The scheduler is stopped.")
+	})
+}
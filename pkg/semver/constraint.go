@@ -0,0 +1,285 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// operator is the comparison of a comparator.
+type operator string
+
+const (
+	operatorEqual          operator = "="
+	operatorNotEqual       operator = "!="
+	operatorGreater        operator = ">"
+	operatorGreaterOrEqual operator = ">="
+	operatorLess           operator = "<"
+	operatorLessOrEqual    operator = "<="
+)
+
+// comparator checks a version against a bound.
+type comparator struct {
+	operator operator
+	version  Version
+}
+
+// check returns true if the version satisfies the comparator.
+func (c comparator) check(version Version) bool {
+	result := version.Compare(c.version)
+	switch c.operator {
+	case operatorEqual:
+		return result == 0
+	case operatorNotEqual:
+		return result != 0
+	case operatorGreater:
+		return result > 0
+	case operatorGreaterOrEqual:
+		return result >= 0
+	case operatorLess:
+		return result < 0
+	case operatorLessOrEqual:
+		return result <= 0
+	default:
+		panic(fmt.Sprintf("Unknown operator %s.", c.operator))
+	}
+}
+
+// Constraint is a set of version ranges. A version satisfies the Constraint if it satisfies
+// all the comparators of at least one of its ranges.
+//
+// The ranges are separated by "||", and the comparators of a range are separated by spaces or commas.
+// The comparators are:
+//   - "1.2.3" or "=1.2.3" for an exact version. Partial versions like "1.2" or "1.2.x" match any patch.
+//   - "!=1.2.3" for any version but an exact one.
+//   - ">", ">=", "<", and "<=" for bounds. Partial versions are filled in, so ">1.2" means ">=1.3.0".
+//   - "~1.2.3" for patch updates (>=1.2.3 <1.3.0). "~1" allows minor updates.
+//   - "^1.2.3" for updates that do not change the left-most non-zero number (>=1.2.3 <2.0.0).
+//   - "*" or "x" for any version.
+//
+// A prerelease version only satisfies a range if one of its comparators has a prerelease of the
+// same major, minor, and patch. So ">=1.0.0-rc.1" matches "1.0.0-rc.2" but not "1.1.0-rc.1".
+type Constraint struct {
+	original string
+	ranges   [][]comparator
+}
+
+// ParseConstraint parses a constraint expression like "^1.2.0", "~1.4", or ">=1.0.0 <2.0.0".
+func ParseConstraint(constraint string) (*Constraint, error) {
+	parsed := &Constraint{
+		original: strings.TrimSpace(constraint),
+		ranges:   make([][]comparator, 0),
+	}
+	for _, rangeExpression := range strings.Split(constraint, "||") {
+		comparators, err := parseRange(rangeExpression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint '%s' (%w)", constraint, err)
+		}
+		parsed.ranges = append(parsed.ranges, comparators)
+	}
+	return parsed, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics if the constraint cannot be parsed.
+func MustParseConstraint(constraint string) *Constraint {
+	parsed, err := ParseConstraint(constraint)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the constraint (%s).", err.Error()))
+	}
+	return parsed
+}
+
+// Check returns true if the version satisfies the Constraint.
+func (c *Constraint) Check(version Version) bool {
+	for _, comparators := range c.ranges {
+		if rangeAllows(comparators, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was parsed.
+func (c *Constraint) String() string {
+	return c.original
+}
+
+// rangeAllows returns true if the version satisfies every comparator of the range.
+func rangeAllows(comparators []comparator, version Version) bool {
+	for _, comp := range comparators {
+		if !comp.check(version) {
+			return false
+		}
+	}
+	if !version.IsPrerelease() {
+		return true
+	}
+	for _, comp := range comparators {
+		if comp.version.IsPrerelease() &&
+			comp.version.Major == version.Major &&
+			comp.version.Minor == version.Minor &&
+			comp.version.Patch == version.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRange parses the comparators of a range. An operator can be separated from its version by spaces.
+func parseRange(rangeExpression string) ([]comparator, error) {
+	tokens := strings.FieldsFunc(rangeExpression, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	if len(tokens) == 0 {
+		return nil, errors.New("the range must not be empty")
+	}
+	comparators := make([]comparator, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if strings.Trim(token, "=!<>~^") == "" && i+1 < len(tokens) {
+			i++
+			token += tokens[i]
+		}
+		parsed, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, parsed...)
+	}
+	return comparators, nil
+}
+
+// parseComparator parses an operator and a possibly partial version into bounds.
+func parseComparator(token string) ([]comparator, error) {
+	prefix := token[:len(token)-len(strings.TrimLeft(token, "=!<>~^"))]
+	partial, err := parsePartial(token[len(prefix):])
+	if err != nil {
+		return nil, err
+	}
+
+	if partial.parts == 0 {
+		switch prefix {
+		case "", "=", ">=", "<=", "~", "^":
+			return []comparator{}, nil
+		default:
+			return nil, fmt.Errorf("the operator '%s' cannot be used with a wildcard", prefix)
+		}
+	}
+
+	lower := partial.version
+	switch prefix {
+	case "", "=":
+		if partial.parts == 3 {
+			return []comparator{{operator: operatorEqual, version: lower}}, nil
+		}
+		return []comparator{
+			{operator: operatorGreaterOrEqual, version: lower},
+			{operator: operatorLess, version: partial.next(partial.parts)},
+		}, nil
+	case "!=":
+		if partial.parts != 3 {
+			return nil, fmt.Errorf("the operator '%s' requires a full version", prefix)
+		}
+		return []comparator{{operator: operatorNotEqual, version: lower}}, nil
+	case ">":
+		if partial.parts == 3 {
+			return []comparator{{operator: operatorGreater, version: lower}}, nil
+		}
+		return []comparator{{operator: operatorGreaterOrEqual, version: partial.next(partial.parts)}}, nil
+	case ">=":
+		return []comparator{{operator: operatorGreaterOrEqual, version: lower}}, nil
+	case "<":
+		return []comparator{{operator: operatorLess, version: lower}}, nil
+	case "<=":
+		if partial.parts == 3 {
+			return []comparator{{operator: operatorLessOrEqual, version: lower}}, nil
+		}
+		return []comparator{{operator: operatorLess, version: partial.next(partial.parts)}}, nil
+	case "~":
+		upperPart := 2
+		if partial.parts == 1 {
+			upperPart = 1
+		}
+		return []comparator{
+			{operator: operatorGreaterOrEqual, version: lower},
+			{operator: operatorLess, version: partial.next(upperPart)},
+		}, nil
+	case "^":
+		upperPart := partial.parts
+		switch {
+		case lower.Major != 0 || partial.parts == 1:
+			upperPart = 1
+		case lower.Minor != 0 || partial.parts == 2:
+			upperPart = 2
+		}
+		return []comparator{
+			{operator: operatorGreaterOrEqual, version: lower},
+			{operator: operatorLess, version: partial.next(upperPart)},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator '%s'", prefix)
+	}
+}
+
+// partialVersion is a version where only the first parts are specified.
+type partialVersion struct {
+	version Version
+	parts   int
+}
+
+// next returns the lowest release after the versions that share the first parts of the partial version.
+// For example, the next of 1.2.3 with 2 parts is 1.3.0.
+func (p partialVersion) next(parts int) Version {
+	switch parts {
+	case 1:
+		return Version{Major: p.version.Major + 1}
+	case 2:
+		return Version{Major: p.version.Major, Minor: p.version.Minor + 1}
+	default:
+		return Version{Major: p.version.Major, Minor: p.version.Minor, Patch: p.version.Patch + 1}
+	}
+}
+
+// parsePartial parses a version where the minor and patch numbers can be omitted or be a wildcard (x, X, or *).
+func parsePartial(version string) (partialVersion, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, _, _ = strings.Cut(core, "-")
+	numbers := strings.Split(core, ".")
+	if len(numbers) > 3 {
+		return partialVersion{}, fmt.Errorf("invalid version '%s' (expected the format MAJOR.MINOR.PATCH)", version)
+	}
+
+	parts := 0
+	for _, number := range numbers {
+		if number == "x" || number == "X" || number == "*" {
+			break
+		}
+		parts++
+	}
+	for _, number := range numbers[parts:] {
+		if number != "x" && number != "X" && number != "*" {
+			return partialVersion{}, fmt.Errorf("invalid version '%s' (a number cannot follow a wildcard)", version)
+		}
+	}
+
+	if parts == 3 {
+		parsed, err := Parse(version)
+		if err != nil {
+			return partialVersion{}, err
+		}
+		return partialVersion{version: parsed, parts: parts}, nil
+	}
+	if core != strings.TrimPrefix(version, "v") {
+		return partialVersion{}, fmt.Errorf("invalid version '%s' (a partial version cannot have a prerelease or build metadata)", version)
+	}
+
+	partial := partialVersion{parts: parts}
+	for i, target := range []*uint64{&partial.version.Major, &partial.version.Minor}[:min(parts, 2)] {
+		parsed, err := parseNumber(numbers[i])
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("invalid version '%s' (%w)", version, err)
+		}
+		*target = parsed
+	}
+	return partial, nil
+}
@@ -0,0 +1,98 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/semver"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestConstraint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when versions are checked against constraints it should match the expected versions", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			constraint string
+			matches    []string
+			misses     []string
+		}{
+			{"1.2.3", []string{"1.2.3", "1.2.3+build"}, []string{"1.2.4", "1.2.3-rc.1"}},
+			{"=1.2", []string{"1.2.0", "1.2.9"}, []string{"1.1.9", "1.3.0"}},
+			{"1.x", []string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0"}},
+			{"1.2.*", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+			{"*", []string{"0.0.0", "99.0.0"}, []string{"1.0.0-rc.1"}},
+			{"!=1.2.3", []string{"1.2.2", "1.2.4"}, []string{"1.2.3"}},
+			{">1.2.3", []string{"1.2.4", "2.0.0"}, []string{"1.2.3", "1.0.0"}},
+			{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+			{">=1.2", []string{"1.2.0", "3.0.0"}, []string{"1.1.9"}},
+			{"<1.2", []string{"1.1.9"}, []string{"1.2.0"}},
+			{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+			{"<=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+			{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0"}},
+			{"~1.4", []string{"1.4.0", "1.4.9"}, []string{"1.5.0", "1.3.9"}},
+			{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+			{"^1.2.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0", "2.0.0-rc.1"}},
+			{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+			{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+			{"^0.0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0"}},
+			{"^0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0"}},
+			{">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0"}},
+			{">= 1.0.0, < 2.0.0", []string{"1.5.0"}, []string{"2.1.0"}},
+			{"^1.0.0 || ^3.0.0", []string{"1.1.0", "3.2.1"}, []string{"2.0.0", "4.0.0"}},
+			{">=1.0.0-rc.1", []string{"1.0.0-rc.2", "1.0.0", "1.1.0"}, []string{"1.0.0-beta", "1.1.0-rc.1"}},
+			{"v1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		}
+		for _, testCase := range testCases {
+			constraint, err := semver.ParseConstraint(testCase.constraint)
+			assert.NoError(t, err)
+			for _, version := range testCase.matches {
+				if !constraint.Check(semver.MustParse(version)) {
+					t.Fatalf("Expected %s to satisfy %s.", version, testCase.constraint)
+				}
+			}
+			for _, version := range testCase.misses {
+				if constraint.Check(semver.MustParse(version)) {
+					t.Fatalf("Expected %s to not satisfy %s.", version, testCase.constraint)
+				}
+			}
+		}
+	})
+
+	t.Run("when invalid constraints are parsed it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			constraint string
+			errPart    string
+		}{
+			{"", "the range must not be empty"},
+			{"^1.0.0 ||", "the range must not be empty"},
+			{"=>1.0.0", "unknown operator '=>'"},
+			{">x", "the operator '>' cannot be used with a wildcard"},
+			{"!=1.2", "the operator '!=' requires a full version"},
+			{"1.x.3", "a number cannot follow a wildcard"},
+			{"1.2.3.4", "expected the format MAJOR.MINOR.PATCH"},
+			{"1.2-rc.1", "a partial version cannot have a prerelease or build metadata"},
+			{"1.a", "the number 'a' must only contain digits"},
+			{">=", "the number '' must only contain digits"},
+			{"1.2.03", "the number '03' must not have leading zeros"},
+		}
+		for _, testCase := range testCases {
+			constraint, err := semver.ParseConstraint(testCase.constraint)
+			assert.ErrorPart(t, err, testCase.errPart)
+			assert.Nil(t, constraint)
+		}
+	})
+
+	t.Run("when MustParseConstraint is called with an invalid constraint it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicPart(t, func() {
+			semver.MustParseConstraint("=>1.0.0")
+		}, "Failed to parse the constraint (invalid constraint '=>1.0.0'")
+	})
+
+	t.Run("when a constraint is formatted it should return the parsed expression", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, semver.MustParseConstraint(" >=1.0.0 <2.0.0 ").String(), ">=1.0.0 <2.0.0")
+	})
+}
@@ -0,0 +1,219 @@
+package semver
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as defined by https://semver.org.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// Parse parses a semantic version like 1.2.3-rc.1+build.5. A leading "v" is allowed.
+func Parse(version string) (Version, error) {
+	remaining := strings.TrimPrefix(version, "v")
+	parsed := Version{}
+
+	if core, build, hasBuild := strings.Cut(remaining, "+"); hasBuild {
+		identifiers, err := parseIdentifiers(build, false)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid build metadata in version '%s' (%w)", version, err)
+		}
+		parsed.Build = identifiers
+		remaining = core
+	}
+
+	if core, prerelease, hasPrerelease := strings.Cut(remaining, "-"); hasPrerelease {
+		identifiers, err := parseIdentifiers(prerelease, true)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid prerelease in version '%s' (%w)", version, err)
+		}
+		parsed.Prerelease = identifiers
+		remaining = core
+	}
+
+	numbers := strings.Split(remaining, ".")
+	if len(numbers) != 3 {
+		return Version{}, fmt.Errorf("invalid version '%s' (expected the format MAJOR.MINOR.PATCH)", version)
+	}
+	for i, target := range []*uint64{&parsed.Major, &parsed.Minor, &parsed.Patch} {
+		number, err := parseNumber(numbers[i])
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version '%s' (%w)", version, err)
+		}
+		*target = number
+	}
+
+	return parsed, nil
+}
+
+// MustParse is like Parse but panics if the version cannot be parsed.
+func MustParse(version string) Version {
+	parsed, err := Parse(version)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse the version (%s).", err.Error()))
+	}
+	return parsed
+}
+
+// parseNumber parses a numeric part of a version. Leading zeros are not allowed.
+func parseNumber(number string) (uint64, error) {
+	if !isNumeric(number) {
+		return 0, fmt.Errorf("the number '%s' must only contain digits", number)
+	}
+	if len(number) > 1 && number[0] == '0' {
+		return 0, fmt.Errorf("the number '%s' must not have leading zeros", number)
+	}
+	parsed, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("the number '%s' is out of range", number)
+	}
+	return parsed, nil
+}
+
+// parseIdentifiers parses the dot separated identifiers of the prerelease or build metadata.
+// Numeric prerelease identifiers must not have leading zeros.
+func parseIdentifiers(identifiers string, prerelease bool) ([]string, error) {
+	parts := strings.Split(identifiers, ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("the identifiers '%s' must not be empty", identifiers)
+		}
+		for _, char := range part {
+			if !isIdentifierChar(char) {
+				return nil, fmt.Errorf("the identifier '%s' must only contain alphanumerics and hyphens", part)
+			}
+		}
+		if prerelease && isNumeric(part) && len(part) > 1 && part[0] == '0' {
+			return nil, fmt.Errorf("the identifier '%s' must not have leading zeros", part)
+		}
+	}
+	return parts, nil
+}
+
+// isIdentifierChar returns true if the character is allowed in an identifier.
+func isIdentifierChar(char rune) bool {
+	return (char >= '0' && char <= '9') || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '-'
+}
+
+// isNumeric returns true if the value is a non-empty string of digits.
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the version in its canonical form, without a leading "v".
+func (v Version) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch))
+	if len(v.Prerelease) > 0 {
+		sb.WriteString("-")
+		sb.WriteString(strings.Join(v.Prerelease, "."))
+	}
+	if len(v.Build) > 0 {
+		sb.WriteString("+")
+		sb.WriteString(strings.Join(v.Build, "."))
+	}
+	return sb.String()
+}
+
+// IsPrerelease returns true if the version has prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1 if v has a lower precedence than other, 1 if it has a higher precedence, and 0 otherwise.
+// The build metadata is ignored, and a prerelease has a lower precedence than its release, so 1.0.0-alpha < 1.0.0.
+func (v Version) Compare(other Version) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareIdentifiers(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.Prerelease), len(other.Prerelease))
+}
+
+// compareIdentifiers compares prerelease identifiers. Numeric identifiers are compared numerically
+// and have a lower precedence than alphanumeric identifiers, which are compared lexically.
+func compareIdentifiers(a string, b string) int {
+	aNumeric := isNumeric(a)
+	bNumeric := isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// Equal returns true if the versions have the same precedence.
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+// LessThan returns true if v has a lower precedence than other.
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// GreaterThan returns true if v has a higher precedence than other.
+func (v Version) GreaterThan(other Version) bool {
+	return v.Compare(other) > 0
+}
+
+// MarshalText encodes the version in its canonical form.
+func (v Version) MarshalText() ([]byte, error) {
+	text := v.String()
+	if _, err := Parse(text); err != nil {
+		return nil, fmt.Errorf("the version is not valid (%w)", err)
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText parses a semantic version.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
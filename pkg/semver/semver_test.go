@@ -0,0 +1,165 @@
+package semver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/semver"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when valid versions are parsed it should return their parts", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			version  string
+			expected semver.Version
+		}{
+			{"0.0.0", semver.Version{}},
+			{"1.2.3", semver.Version{Major: 1, Minor: 2, Patch: 3}},
+			{"v10.20.30", semver.Version{Major: 10, Minor: 20, Patch: 30}},
+			{"1.0.0-alpha", semver.Version{Major: 1, Prerelease: []string{"alpha"}}},
+			{"1.0.0-rc-1.0.x", semver.Version{Major: 1, Prerelease: []string{"rc-1", "0", "x"}}},
+			{"1.0.0+build.007", semver.Version{Major: 1, Build: []string{"build", "007"}}},
+			{"1.2.3-beta.2+exp.sha.5114f85", semver.Version{
+				Major:      1,
+				Minor:      2,
+				Patch:      3,
+				Prerelease: []string{"beta", "2"},
+				Build:      []string{"exp", "sha", "5114f85"},
+			}},
+			{"18446744073709551615.0.0", semver.Version{Major: 18446744073709551615}},
+		}
+		for _, testCase := range testCases {
+			parsed, err := semver.Parse(testCase.version)
+			assert.NoError(t, err)
+			assert.Equals(t, parsed, testCase.expected)
+		}
+	})
+
+	t.Run("when invalid versions are parsed it should return an error", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			version string
+			errPart string
+		}{
+			{"", "expected the format MAJOR.MINOR.PATCH"},
+			{"1.2", "expected the format MAJOR.MINOR.PATCH"},
+			{"1.2.3.4", "expected the format MAJOR.MINOR.PATCH"},
+			{"1.x.3", "the number 'x' must only contain digits"},
+			{"1..3", "the number '' must only contain digits"},
+			{"01.2.3", "the number '01' must not have leading zeros"},
+			{"18446744073709551616.0.0", "the number '18446744073709551616' is out of range"},
+			{"1.2.3-", "invalid prerelease in version '1.2.3-' (the identifiers '' must not be empty)"},
+			{"1.2.3-alpha..1", "the identifiers 'alpha..1' must not be empty"},
+			{"1.2.3-01", "the identifier '01' must not have leading zeros"},
+			{"1.2.3-al_pha", "the identifier 'al_pha' must only contain alphanumerics and hyphens"},
+			{"1.2.3+", "invalid build metadata in version '1.2.3+' (the identifiers '' must not be empty)"},
+			{"1.2.3+build+other", "the identifier 'build+other' must only contain alphanumerics and hyphens"},
+		}
+		for _, testCase := range testCases {
+			_, err := semver.Parse(testCase.version)
+			assert.ErrorPart(t, err, testCase.errPart)
+		}
+	})
+
+	t.Run("when MustParse is called with an invalid version it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicPart(t, func() {
+			semver.MustParse("invalid")
+		}, "Failed to parse the version (invalid version 'invalid'")
+		assert.Equals(t, semver.MustParse("1.2.3"), semver.Version{Major: 1, Minor: 2, Patch: 3})
+	})
+}
+
+func TestVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a version is formatted it should return its canonical form", func(t *testing.T) {
+		t.Parallel()
+		for _, version := range []string{"0.0.0", "1.2.3", "1.0.0-alpha.1", "1.0.0+build", "1.2.3-rc.1+build.5"} {
+			assert.Equals(t, semver.MustParse(version).String(), version)
+		}
+		assert.Equals(t, semver.MustParse("v1.2.3").String(), "1.2.3")
+	})
+
+	t.Run("when versions are compared it should follow the precedence rules", func(t *testing.T) {
+		t.Parallel()
+		ordered := []string{
+			"1.0.0-alpha",
+			"1.0.0-alpha.1",
+			"1.0.0-alpha.beta",
+			"1.0.0-beta",
+			"1.0.0-beta.2",
+			"1.0.0-beta.11",
+			"1.0.0-rc.1",
+			"1.0.0",
+			"1.0.1",
+			"1.1.0",
+			"2.0.0",
+		}
+		for i := range ordered {
+			for j := range ordered {
+				a := semver.MustParse(ordered[i])
+				b := semver.MustParse(ordered[j])
+				switch {
+				case i < j:
+					assert.Equals(t, a.Compare(b), -1)
+					assert.True(t, a.LessThan(b))
+					assert.False(t, a.GreaterThan(b))
+				case i > j:
+					assert.Equals(t, a.Compare(b), 1)
+					assert.True(t, a.GreaterThan(b))
+					assert.False(t, a.LessThan(b))
+				default:
+					assert.Equals(t, a.Compare(b), 0)
+					assert.True(t, a.Equal(b))
+				}
+			}
+		}
+	})
+
+	t.Run("when versions only differ by build metadata it should have the same precedence", func(t *testing.T) {
+		t.Parallel()
+		a := semver.MustParse("1.0.0+build.1")
+		b := semver.MustParse("1.0.0+build.2")
+		assert.True(t, a.Equal(b))
+		assert.Equals(t, a.Compare(b), 0)
+	})
+
+	t.Run("when a version has prerelease identifiers it should be a prerelease", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, semver.MustParse("1.0.0-rc.1").IsPrerelease())
+		assert.False(t, semver.MustParse("1.0.0+build").IsPrerelease())
+	})
+
+	t.Run("when a version is encoded as JSON it should be a string that decodes to the same version", func(t *testing.T) {
+		t.Parallel()
+		type payload struct {
+			Version  semver.Version  `json:"version"`
+			Optional *semver.Version `json:"optional,omitempty"`
+		}
+		encoded, err := json.Marshal(payload{Version: semver.MustParse("v1.2.3-rc.1+build")})
+		assert.NoError(t, err)
+		assert.Equals(t, string(encoded), `{"version":"1.2.3-rc.1+build"}`)
+		decoded := payload{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"version":"1.2.3-rc.1+build","optional":"2.0.0"}`), &decoded))
+		assert.Equals(t, decoded.Version, semver.MustParse("1.2.3-rc.1+build"))
+		assert.Equals(t, *decoded.Optional, semver.MustParse("2.0.0"))
+	})
+
+	t.Run("when an invalid version is decoded from JSON it should return an error", func(t *testing.T) {
+		t.Parallel()
+		decoded := semver.Version{}
+		err := json.Unmarshal([]byte(`"1.2"`), &decoded)
+		assert.ErrorPart(t, err, "invalid version '1.2'")
+	})
+
+	t.Run("when an invalid version is encoded it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := semver.Version{Major: 1, Prerelease: []string{"not valid"}}.MarshalText()
+		assert.ErrorPart(t, err, "the version is not valid")
+	})
+}
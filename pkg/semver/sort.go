@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"slices"
+)
+
+// Sort sorts the versions from the lowest to the highest precedence.
+// Versions with the same precedence keep their order.
+func Sort(versions []Version) {
+	slices.SortStableFunc(versions, Version.Compare)
+}
+
+// Max returns the version with the highest precedence. It returns false if there are no versions.
+func Max(versions []Version) (Version, bool) {
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+	return slices.MaxFunc(versions, Version.Compare), true
+}
+
+// MaxSatisfying returns the version with the highest precedence that satisfies the constraint.
+// It returns false if none of the versions satisfy it.
+func MaxSatisfying(versions []Version, constraint *Constraint) (Version, bool) {
+	found := false
+	var highest Version
+	for _, version := range versions {
+		if !constraint.Check(version) {
+			continue
+		}
+		if !found || version.GreaterThan(highest) {
+			highest = version
+			found = true
+		}
+	}
+	return highest, found
+}
@@ -0,0 +1,86 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/semver"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func parseAll(t *testing.T, versions ...string) []semver.Version {
+	t.Helper()
+	parsed := make([]semver.Version, 0, len(versions))
+	for _, version := range versions {
+		parsed = append(parsed, semver.MustParse(version))
+	}
+	return parsed
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when versions are sorted it should order them by precedence", func(t *testing.T) {
+		t.Parallel()
+		versions := parseAll(t, "2.0.0", "1.0.0", "1.0.0-rc.1", "1.10.0", "1.2.0", "1.0.0-alpha", "0.9.0")
+		semver.Sort(versions)
+		assert.Equals(t, versions, parseAll(t, "0.9.0", "1.0.0-alpha", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"))
+	})
+
+	t.Run("when versions have the same precedence it should keep their order", func(t *testing.T) {
+		t.Parallel()
+		versions := parseAll(t, "1.0.0+b", "0.1.0", "1.0.0+a")
+		semver.Sort(versions)
+		assert.Equals(t, versions, parseAll(t, "0.1.0", "1.0.0+b", "1.0.0+a"))
+	})
+}
+
+func TestMax(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when there are versions it should return the highest", func(t *testing.T) {
+		t.Parallel()
+		highest, found := semver.Max(parseAll(t, "1.2.0", "2.0.0-rc.1", "1.10.0"))
+		assert.True(t, found)
+		assert.Equals(t, highest, semver.MustParse("2.0.0-rc.1"))
+	})
+
+	t.Run("when there are no versions it should return false", func(t *testing.T) {
+		t.Parallel()
+		highest, found := semver.Max(nil)
+		assert.False(t, found)
+		assert.Equals(t, highest, semver.Version{})
+	})
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	t.Parallel()
+
+	versions := parseAll(t, "1.2.0", "1.4.2", "1.4.10", "2.0.0-rc.1", "2.0.0", "2.1.0", "3.0.0-beta")
+
+	t.Run("when versions satisfy the constraint it should return the highest of them", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			constraint string
+			expected   string
+		}{
+			{"^1.2.0", "1.4.10"},
+			{"~1.4", "1.4.10"},
+			{">=1.0.0 <2.0.0", "1.4.10"},
+			{"*", "2.1.0"},
+			{">=2.0.0-rc.1 <2.0.0", "2.0.0-rc.1"},
+			{">=3.0.0-alpha", "3.0.0-beta"},
+		}
+		for _, testCase := range testCases {
+			highest, found := semver.MaxSatisfying(versions, semver.MustParseConstraint(testCase.constraint))
+			assert.True(t, found)
+			assert.Equals(t, highest.String(), testCase.expected)
+		}
+	})
+
+	t.Run("when no version satisfies the constraint it should return false", func(t *testing.T) {
+		t.Parallel()
+		highest, found := semver.MaxSatisfying(versions, semver.MustParseConstraint("^4.0.0"))
+		assert.False(t, found)
+		assert.Equals(t, highest, semver.Version{})
+	})
+}
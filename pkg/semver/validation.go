@@ -0,0 +1,44 @@
+package semver
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/TriangleSide/GoTools/pkg/validation"
+)
+
+const (
+	SemverValidatorName validation.Validator = "semver"
+)
+
+// init registers the semver validator.
+func init() {
+	validation.MustRegisterValidator(SemverValidatorName, func(params *validation.CallbackParameters) *validation.CallbackResult {
+		result := validation.NewCallbackResult()
+
+		if params.Parameters != "" {
+			return result.WithError(fmt.Errorf("the %s validator does not take parameters", SemverValidatorName))
+		}
+
+		value, err := validation.DereferenceAndNilCheck(params.Value)
+		if err != nil {
+			return result.WithError(validation.NewViolation(params, err))
+		}
+
+		var version string
+		switch {
+		case value.Type() == reflect.TypeFor[Version]():
+			version = value.Interface().(Version).String()
+		case value.Kind() == reflect.String:
+			version = value.String()
+		default:
+			return result.WithError(validation.NewViolation(params, fmt.Errorf("the %s validation is not supported for type %s", SemverValidatorName, value.Type())))
+		}
+
+		if _, err := Parse(version); err != nil {
+			return result.WithError(validation.NewViolation(params, err))
+		}
+
+		return nil
+	})
+}
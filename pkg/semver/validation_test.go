@@ -0,0 +1,60 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/semver"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/validation"
+)
+
+func TestSemverValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a string is a valid version it should pass the validation", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Version string  `validate:"semver"`
+			Pointer *string `validate:"omitempty,semver"`
+		}
+		assert.NoError(t, validation.Struct(&testStruct{Version: "1.2.3-rc.1"}))
+		assert.NoError(t, validation.Struct(&testStruct{Version: "v1.0.0", Pointer: new(string)}))
+	})
+
+	t.Run("when a string is not a valid version it should fail the validation", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Version string `validate:"semver"`
+		}
+		err := validation.Struct(&testStruct{Version: "1.2"})
+		assert.ErrorPart(t, err, "invalid version '1.2'")
+	})
+
+	t.Run("when a Version is validated it should check its identifiers", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Version semver.Version `validate:"semver"`
+		}
+		assert.NoError(t, validation.Struct(&testStruct{Version: semver.MustParse("1.0.0+build")}))
+		err := validation.Struct(&testStruct{Version: semver.Version{Build: []string{""}}})
+		assert.ErrorPart(t, err, "invalid build metadata")
+	})
+
+	t.Run("when the value has an unsupported type it should fail the validation", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Version int `validate:"semver"`
+		}
+		err := validation.Struct(&testStruct{Version: 1})
+		assert.ErrorPart(t, err, "the semver validation is not supported for type int")
+	})
+
+	t.Run("when parameters are provided it should return an error", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Version string `validate:"semver=1"`
+		}
+		err := validation.Struct(&testStruct{Version: "1.0.0"})
+		assert.ErrorPart(t, err, "the semver validator does not take parameters")
+	})
+}
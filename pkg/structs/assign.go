@@ -14,13 +14,17 @@ import (
 // The conversion from string to the appropriate type is performed based on the field's underlying type.
 // JSON format is expected for complex types. This function supports setting both direct values and pointers to the values.
 func AssignToField[T any](obj *T, fieldName string, stringEncodedValue string) error {
-	structValue := reflect.ValueOf(obj)
+	return assignToField(reflect.ValueOf(obj), fieldName, stringEncodedValue)
+}
+
+// assignToField is AssignToField for a reflect.Value of a pointer to a struct.
+func assignToField(structValue reflect.Value, fieldName string, stringEncodedValue string) error {
 	if structValue.Kind() != reflect.Ptr || structValue.Elem().Kind() != reflect.Struct {
 		panic("obj must be a pointer to a struct")
 	}
 
 	// Get the field metadata for all the structs fields.
-	fieldsToMetadata := Metadata[T]()
+	fieldsToMetadata := MetadataFromType(structValue.Elem().Type())
 	fieldMetadata, foundFieldMetadata := fieldsToMetadata.Fetch(fieldName)
 	if !foundFieldMetadata {
 		panic(fmt.Sprintf("no field '%s' in struct '%s'", fieldName, structValue.Type().String()))
@@ -189,3 +189,17 @@ func TestAssign(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkAssignToField(b *testing.B) {
+	type testStruct struct {
+		Value int
+	}
+
+	b.ReportAllocs()
+	obj := &testStruct{}
+	for i := 0; i < b.N; i++ {
+		if err := structs.AssignToField(obj, "Value", "123"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
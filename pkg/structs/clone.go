@@ -0,0 +1,107 @@
+package structs
+
+import (
+	"reflect"
+)
+
+// cloneVisitKey identifies a pointer, map, or slice that was already cloned.
+type cloneVisitKey struct {
+	pointer   uintptr
+	length    int
+	valueType reflect.Type
+}
+
+// cloner deep copies values and remembers what it already copied so that cycles and shared references are preserved.
+type cloner struct {
+	visited map[cloneVisitKey]reflect.Value
+}
+
+// Clone returns a deep copy of the value. Pointers, slices, maps, arrays, interfaces, and exported struct
+// fields are copied recursively. If the same pointer, slice, or map is reachable more than once,
+// including through a cycle, the copy references a single clone of it.
+//
+// Unexported struct fields, channels, and functions cannot be copied through reflection,
+// so they are shallow copies that reference the same data as the original.
+func Clone[T any](value T) T {
+	c := &cloner{
+		visited: make(map[cloneVisitKey]reflect.Value),
+	}
+	var cloned T
+	c.clone(reflect.ValueOf(&value).Elem(), reflect.ValueOf(&cloned).Elem())
+	return cloned
+}
+
+// clone deep copies src into dst. The dst value must be settable and of the same type as src.
+func (c *cloner) clone(src reflect.Value, dst reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := cloneVisitKey{pointer: src.Pointer(), valueType: src.Type()}
+		if visited, ok := c.visited[key]; ok {
+			dst.Set(visited)
+			return
+		}
+		cloned := reflect.New(src.Type().Elem())
+		c.visited[key] = cloned
+		c.clone(src.Elem(), cloned.Elem())
+		dst.Set(cloned)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		inner := src.Elem()
+		cloned := reflect.New(inner.Type()).Elem()
+		c.clone(inner, cloned)
+		dst.Set(cloned)
+	case reflect.Struct:
+		dst.Set(src)
+		for fieldIndex := 0; fieldIndex < src.NumField(); fieldIndex++ {
+			if dstField := dst.Field(fieldIndex); dstField.CanSet() {
+				c.clone(src.Field(fieldIndex), dstField)
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := cloneVisitKey{pointer: src.Pointer(), length: src.Len(), valueType: src.Type()}
+		if visited, ok := c.visited[key]; ok {
+			dst.Set(visited)
+			return
+		}
+		cloned := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		c.visited[key] = cloned
+		for i := 0; i < src.Len(); i++ {
+			c.clone(src.Index(i), cloned.Index(i))
+		}
+		dst.Set(cloned)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.clone(src.Index(i), dst.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := cloneVisitKey{pointer: src.Pointer(), valueType: src.Type()}
+		if visited, ok := c.visited[key]; ok {
+			dst.Set(visited)
+			return
+		}
+		cloned := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.visited[key] = cloned
+		iter := src.MapRange()
+		for iter.Next() {
+			clonedKey := reflect.New(src.Type().Key()).Elem()
+			c.clone(iter.Key(), clonedKey)
+			clonedValue := reflect.New(src.Type().Elem()).Elem()
+			c.clone(iter.Value(), clonedValue)
+			cloned.SetMapIndex(clonedKey, clonedValue)
+		}
+		dst.Set(cloned)
+	default:
+		dst.Set(src)
+	}
+}
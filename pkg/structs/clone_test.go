@@ -0,0 +1,136 @@
+package structs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/structs"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Value int
+	}
+
+	type testStruct struct {
+		Name     string
+		Pointer  *inner
+		Slice    []*inner
+		Map      map[string][]int
+		Array    [2]*inner
+		Any      any
+		Time     time.Time
+		Nil      *inner
+		NilSlice []int
+		NilMap   map[string]int
+	}
+
+	t.Run("when a struct is cloned it should be equal to the original", func(t *testing.T) {
+		t.Parallel()
+		original := testStruct{
+			Name:    "name",
+			Pointer: &inner{Value: 1},
+			Slice:   []*inner{{Value: 2}},
+			Map:     map[string][]int{"key": {3}},
+			Array:   [2]*inner{{Value: 4}, nil},
+			Any:     &inner{Value: 5},
+			Time:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		cloned := structs.Clone(original)
+		assert.Equals(t, cloned, original)
+		assert.Nil(t, cloned.Nil)
+		assert.Nil(t, cloned.NilSlice)
+		assert.Nil(t, cloned.NilMap)
+	})
+
+	t.Run("when the clone is modified it should not change the original", func(t *testing.T) {
+		t.Parallel()
+		original := &testStruct{
+			Pointer: &inner{Value: 1},
+			Slice:   []*inner{{Value: 2}},
+			Map:     map[string][]int{"key": {3}},
+			Array:   [2]*inner{{Value: 4}, nil},
+			Any:     &inner{Value: 5},
+		}
+		cloned := structs.Clone(original)
+		assert.True(t, cloned != original)
+		cloned.Pointer.Value = 10
+		cloned.Slice[0].Value = 20
+		cloned.Map["key"][0] = 30
+		cloned.Map["other"] = nil
+		cloned.Array[0].Value = 40
+		cloned.Any.(*inner).Value = 50
+		assert.Equals(t, original.Pointer.Value, 1)
+		assert.Equals(t, original.Slice[0].Value, 2)
+		assert.Equals(t, original.Map, map[string][]int{"key": {3}})
+		assert.Equals(t, original.Array[0].Value, 4)
+		assert.Equals(t, original.Any.(*inner).Value, 5)
+	})
+
+	t.Run("when the value has a cycle it should clone it with the same shape", func(t *testing.T) {
+		t.Parallel()
+		type node struct {
+			Value int
+			Next  *node
+		}
+		first := &node{Value: 1}
+		second := &node{Value: 2, Next: first}
+		first.Next = second
+		cloned := structs.Clone(first)
+		assert.True(t, cloned != first)
+		assert.True(t, cloned.Next != second)
+		assert.Equals(t, cloned.Next.Value, 2)
+		assert.True(t, cloned.Next.Next == cloned)
+	})
+
+	t.Run("when a slice contains itself it should clone it without looping", func(t *testing.T) {
+		t.Parallel()
+		original := make([]any, 1)
+		original[0] = original
+		cloned := structs.Clone(original)
+		assert.True(t, &cloned[0] != &original[0])
+		assert.True(t, &cloned[0].([]any)[0] == &cloned[0])
+	})
+
+	t.Run("when a pointer is shared it should be shared in the clone", func(t *testing.T) {
+		t.Parallel()
+		shared := &inner{Value: 1}
+		original := testStruct{Pointer: shared, Slice: []*inner{shared}}
+		cloned := structs.Clone(original)
+		assert.True(t, cloned.Pointer != shared)
+		assert.True(t, cloned.Pointer == cloned.Slice[0])
+	})
+
+	t.Run("when a struct has unexported fields they should be shallow copies", func(t *testing.T) {
+		t.Parallel()
+		type withUnexported struct {
+			Exported   *inner
+			unexported *inner
+		}
+		original := withUnexported{Exported: &inner{Value: 1}, unexported: &inner{Value: 2}}
+		cloned := structs.Clone(original)
+		assert.True(t, cloned.Exported != original.Exported)
+		assert.True(t, cloned.unexported == original.unexported)
+	})
+
+	t.Run("when channels and functions are cloned they should be the same references", func(t *testing.T) {
+		t.Parallel()
+		type withReferences struct {
+			Channel chan int
+			Func    func() int
+		}
+		original := withReferences{Channel: make(chan int, 1), Func: func() int { return 1 }}
+		cloned := structs.Clone(original)
+		assert.True(t, cloned.Channel == original.Channel)
+		assert.Equals(t, cloned.Func(), 1)
+	})
+
+	t.Run("when a nil value is cloned it should return nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, structs.Clone[*testStruct](nil))
+		assert.Nil(t, structs.Clone[any](nil))
+	})
+}
@@ -0,0 +1,175 @@
+package structs
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FlattenSeparator separates the field names of nested structs in the keys of a flattened struct.
+	FlattenSeparator = "."
+)
+
+// Flatten converts the exported fields of a struct to a map of dot-notation keys to string encoded values.
+//
+//	type Server struct {
+//		Port int
+//	}
+//
+//	type Config struct {
+//		Server Server
+//	}
+//
+// Flattening a Config returns {"Server.Port": "8080"}. Fields of embedded anonymous structs are not prefixed,
+// nil pointers and the fields promoted through nil embedded pointers are omitted, and structs that implement encoding.TextMarshaler are encoded as a single value.
+// The values are encoded in the format that AssignToField expects, so Unflatten can restore them.
+func Flatten[T any](obj T) (map[string]string, error) {
+	value, err := structValueOf(reflect.ValueOf(obj))
+	if err != nil {
+		return nil, err
+	}
+	flattened := make(map[string]string)
+	if err := flattenStruct(value, "", flattened); err != nil {
+		return nil, err
+	}
+	return flattened, nil
+}
+
+// structValueOf dereferences a struct pointer and ensures the value is a struct.
+func structValueOf(value reflect.Value) (reflect.Value, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, errors.New("struct instance cannot be nil")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("type must be a struct or a pointer to a struct")
+	}
+	return value, nil
+}
+
+// flattenStruct adds the fields of the struct value to the flattened map with the prefix.
+func flattenStruct(structValue reflect.Value, prefix string, flattened map[string]string) error {
+	for fieldName := range MetadataFromType(structValue.Type()).All() {
+		if !token.IsExported(fieldName) {
+			continue
+		}
+		key := prefix + fieldName
+		fieldValue, reachable := promotedFieldByName(structValue, fieldName)
+		if !reachable {
+			continue
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if mergedByField(fieldValue.Type()) {
+			if err := flattenStruct(fieldValue, key+FlattenSeparator, flattened); err != nil {
+				return err
+			}
+			continue
+		}
+		encoded, err := encodeFieldValue(fieldValue)
+		if err != nil {
+			return fmt.Errorf("failed to encode the field %s (%w)", key, err)
+		}
+		flattened[key] = encoded
+	}
+	return nil
+}
+
+// encodeFieldValue encodes a value as a string that AssignToField can decode.
+func encodeFieldValue(value reflect.Value) (string, error) {
+	marshaler, ok := value.Interface().(encoding.TextMarshaler)
+	if !ok && value.CanAddr() {
+		marshaler, ok = value.Addr().Interface().(encoding.TextMarshaler)
+	}
+	if ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("text marshal error (%w)", err)
+		}
+		return string(text), nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Map, reflect.Slice, reflect.Struct:
+		marshaled, err := json.Marshal(value.Interface())
+		if err != nil {
+			return "", fmt.Errorf("json marshal error (%w)", err)
+		}
+		return string(marshaled), nil
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", value.Type())
+	}
+}
+
+// Unflatten sets the fields of a struct from a map of dot-notation keys to string encoded values,
+// which is the format returned by Flatten. The values are set with AssignToField,
+// and nil pointers to nested structs are allocated as needed. The keys are set in sorted order,
+// so the same map always fails on the same key.
+func Unflatten[T any](flattened map[string]string, obj *T) error {
+	if obj == nil {
+		panic("obj must not be nil")
+	}
+	for _, key := range slices.Sorted(maps.Keys(flattened)) {
+		if err := unflattenKey(reflect.ValueOf(obj), key, flattened[key]); err != nil {
+			return fmt.Errorf("failed to set the key %s (%w)", key, err)
+		}
+	}
+	return nil
+}
+
+// unflattenKey walks the struct pointer along the key and assigns the value to the last field.
+func unflattenKey(structPtr reflect.Value, key string, value string) error {
+	fieldNames := strings.Split(key, FlattenSeparator)
+	for _, fieldName := range fieldNames[:len(fieldNames)-1] {
+		fieldValue, err := exportedField(structPtr, fieldName)
+		if err != nil {
+			return err
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if !mergedByField(fieldValue.Type()) {
+			return fmt.Errorf("the field %s is not a nested struct", fieldName)
+		}
+		structPtr = fieldValue.Addr()
+	}
+	lastFieldName := fieldNames[len(fieldNames)-1]
+	if _, err := exportedField(structPtr, lastFieldName); err != nil {
+		return err
+	}
+	return assignToField(structPtr, lastFieldName, value)
+}
+
+// exportedField returns the exported field of the struct pointer with the name.
+func exportedField(structPtr reflect.Value, fieldName string) (reflect.Value, error) {
+	if !token.IsExported(fieldName) || !MetadataFromType(structPtr.Elem().Type()).Has(fieldName) {
+		return reflect.Value{}, fmt.Errorf("no exported field %s in struct %s", fieldName, structPtr.Elem().Type())
+	}
+	return structPtr.Elem().FieldByName(fieldName), nil
+}
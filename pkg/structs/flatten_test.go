@@ -0,0 +1,177 @@
+package structs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/structs"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	type tls struct {
+		Enabled bool
+		Ciphers []string
+	}
+
+	type server struct {
+		Host string
+		Port uint16
+		TLS  *tls
+	}
+
+	type embedded struct {
+		EmbeddedField string
+	}
+
+	type testStruct struct {
+		embedded
+		Name       string
+		Ratio      float64
+		Offset     int
+		Server     server
+		Backup     *server
+		Labels     map[string]string
+		Started    time.Time
+		unexported string
+	}
+
+	t.Run("when a struct is flattened it should use dot-notation keys", func(t *testing.T) {
+		t.Parallel()
+		flattened, err := structs.Flatten(testStruct{
+			embedded: embedded{EmbeddedField: "embedded"},
+			Name:     "name",
+			Ratio:    0.5,
+			Offset:   -1,
+			Server:   server{Host: "localhost", Port: 8080, TLS: &tls{Enabled: true, Ciphers: []string{"a"}}},
+			Labels:   map[string]string{"key": "value"},
+			Started:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+		assert.Equals(t, flattened, map[string]string{
+			"EmbeddedField":      "embedded",
+			"Name":               "name",
+			"Ratio":              "0.5",
+			"Offset":             "-1",
+			"Server.Host":        "localhost",
+			"Server.Port":        "8080",
+			"Server.TLS.Enabled": "true",
+			"Server.TLS.Ciphers": `["a"]`,
+			"Labels":             `{"key":"value"}`,
+			"Started":            "2024-01-01T00:00:00Z",
+		})
+	})
+
+	t.Run("when a struct is flattened and unflattened it should be the same", func(t *testing.T) {
+		t.Parallel()
+		original := &testStruct{
+			embedded: embedded{EmbeddedField: "embedded"},
+			Name:     "name",
+			Ratio:    1e-9,
+			Server:   server{Host: "localhost", Port: 8080},
+			Backup:   &server{Host: "backup", TLS: &tls{Ciphers: []string{"b"}}},
+			Labels:   map[string]string{"key": "value"},
+			Started:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		flattened, err := structs.Flatten(original)
+		assert.NoError(t, err)
+		var restored testStruct
+		assert.NoError(t, structs.Unflatten(flattened, &restored))
+		assert.Equals(t, &restored, original)
+	})
+
+	t.Run("when a nested pointer is nil it should be omitted", func(t *testing.T) {
+		t.Parallel()
+		flattened, err := structs.Flatten(testStruct{})
+		assert.NoError(t, err)
+		_, hasBackup := flattened["Backup.Host"]
+		assert.False(t, hasBackup)
+		_, hasTLS := flattened["Server.TLS.Enabled"]
+		assert.False(t, hasTLS)
+	})
+
+	t.Run("when an embedded pointer is nil it should omit its fields", func(t *testing.T) {
+		t.Parallel()
+		type inner struct {
+			A int
+		}
+		type outer struct {
+			*inner
+			B int
+		}
+		flattened, err := structs.Flatten(outer{B: 2})
+		assert.NoError(t, err)
+		assert.Equals(t, flattened, map[string]string{"B": "2"})
+		flattened, err = structs.Flatten(outer{inner: &inner{A: 1}, B: 2})
+		assert.NoError(t, err)
+		assert.Equals(t, flattened, map[string]string{"A": "1", "B": "2"})
+	})
+
+	t.Run("when a value is not a struct it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := structs.Flatten(1)
+		assert.ErrorExact(t, err, "type must be a struct or a pointer to a struct")
+		_, err = structs.Flatten[*testStruct](nil)
+		assert.ErrorExact(t, err, "struct instance cannot be nil")
+	})
+
+	t.Run("when a field cannot be encoded it should return an error", func(t *testing.T) {
+		t.Parallel()
+		type withChannel struct {
+			Channel chan int
+		}
+		_, err := structs.Flatten(withChannel{Channel: make(chan int)})
+		assert.ErrorPart(t, err, "failed to encode the field Channel (unsupported field type: chan int)")
+	})
+
+	t.Run("when a key is unflattened it should allocate nested pointers", func(t *testing.T) {
+		t.Parallel()
+		var restored testStruct
+		assert.NoError(t, structs.Unflatten(map[string]string{"Backup.TLS.Enabled": "true"}, &restored))
+		assert.True(t, restored.Backup.TLS.Enabled)
+		assert.Equals(t, restored.Backup.Host, "")
+	})
+
+	t.Run("when a key does not match a field it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var restored testStruct
+		err := structs.Unflatten(map[string]string{"Server.Missing": "1"}, &restored)
+		assert.ErrorPart(t, err, "failed to set the key Server.Missing (no exported field Missing in struct")
+		err = structs.Unflatten(map[string]string{"unexported": "1"}, &restored)
+		assert.ErrorPart(t, err, "no exported field unexported")
+		err = structs.Unflatten(map[string]string{"Name.Value": "1"}, &restored)
+		assert.ErrorPart(t, err, "the field Name is not a nested struct")
+		err = structs.Unflatten(map[string]string{"Started.Value": "1"}, &restored)
+		assert.ErrorPart(t, err, "the field Started is not a nested struct")
+	})
+
+	t.Run("when a value cannot be assigned it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var restored testStruct
+		err := structs.Unflatten(map[string]string{"Server.Port": "not_a_port"}, &restored)
+		assert.ErrorPart(t, err, "failed to set the key Server.Port (unsigned int parsing error")
+	})
+
+	t.Run("when several values cannot be assigned it should always fail on the first key in order", func(t *testing.T) {
+		t.Parallel()
+		flattened := map[string]string{
+			"Server.Port":        "not_a_port",
+			"Backup.TLS.Enabled": "not_a_bool",
+			"Name.Value":         "1",
+		}
+		for range 10 {
+			var restored testStruct
+			err := structs.Unflatten(flattened, &restored)
+			assert.ErrorPart(t, err, "failed to set the key Backup.TLS.Enabled")
+		}
+	})
+
+	t.Run("when unflattening into nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			_ = structs.Unflatten[testStruct](map[string]string{}, nil)
+		}, "obj must not be nil")
+	})
+}
@@ -0,0 +1,250 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UnknownKeyPolicy decides what happens to map keys that do not match a struct field.
+type UnknownKeyPolicy int
+
+const (
+	// UnknownKeysIgnore skips the keys that do not match a field.
+	UnknownKeysIgnore UnknownKeyPolicy = iota
+
+	// UnknownKeysError returns an error for each key that does not match a field.
+	UnknownKeysError
+)
+
+// mapConfig is the configuration for the Encode, Decode, and AssignFromMap functions.
+type mapConfig struct {
+	tag         string
+	unknownKeys UnknownKeyPolicy
+}
+
+// MapOption is used to set parameters for the Encode, Decode, and AssignFromMap functions.
+type MapOption func(*mapConfig)
+
+// WithTag sets the struct tag that names the map keys, for example "json".
+// The tag value up to the first comma is the key, a value of "-" skips the field,
+// and an empty value or a missing tag falls back to the field name.
+func WithTag(tag string) MapOption {
+	return func(c *mapConfig) {
+		c.tag = tag
+	}
+}
+
+// WithUnknownKeys sets the policy for map keys that do not match a field when decoding. The default is UnknownKeysIgnore.
+func WithUnknownKeys(policy UnknownKeyPolicy) MapOption {
+	return func(c *mapConfig) {
+		c.unknownKeys = policy
+	}
+}
+
+// configureMap applies the options to the default map configuration.
+func configureMap(opts ...MapOption) *mapConfig {
+	cfg := &mapConfig{
+		tag:         "",
+		unknownKeys: UnknownKeysIgnore,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// mapKey returns the map key for the field, and false if the field should be skipped.
+func (c *mapConfig) mapKey(fieldName string, fieldMetadata *FieldMetadata) (string, bool) {
+	if !token.IsExported(fieldName) {
+		return "", false
+	}
+	if c.tag == "" {
+		return fieldName, true
+	}
+	tagValue, hasTag := fieldMetadata.Tags().Fetch(c.tag)
+	if !hasTag {
+		return fieldName, true
+	}
+	key, _, _ := strings.Cut(tagValue, ",")
+	switch key {
+	case "-":
+		return "", false
+	case "":
+		return fieldName, true
+	default:
+		return key, true
+	}
+}
+
+// keysToFieldNames returns the map keys of the struct type mapped to their field names.
+func (c *mapConfig) keysToFieldNames(structType reflect.Type) map[string]string {
+	keysToFieldNames := make(map[string]string)
+	for fieldName, fieldMetadata := range MetadataFromType(structType).All() {
+		if key, ok := c.mapKey(fieldName, fieldMetadata); ok {
+			keysToFieldNames[key] = fieldName
+		}
+	}
+	return keysToFieldNames
+}
+
+// unknownKeysError returns an error listing the keys that do not match a field if the policy requires it.
+func (c *mapConfig) unknownKeysError(keys []string, keysToFieldNames map[string]string) error {
+	if c.unknownKeys != UnknownKeysError {
+		return nil
+	}
+	var errs []error
+	for _, key := range keys {
+		if _, known := keysToFieldNames[key]; !known {
+			errs = append(errs, fmt.Errorf("unknown key %s", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Encode returns a map of the exported struct fields to their values.
+// Nested structs, slices, and maps are stored as they are in the struct.
+// Fields promoted through a nil embedded struct pointer are omitted.
+func Encode[T any](obj T, opts ...MapOption) map[string]any {
+	structValue := reflect.ValueOf(obj)
+	if structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			panic("obj must not be nil")
+		}
+		structValue = structValue.Elem()
+	}
+	if structValue.Kind() != reflect.Struct {
+		panic("obj must be a struct or a pointer to a struct")
+	}
+
+	cfg := configureMap(opts...)
+	encoded := make(map[string]any)
+	for fieldName, fieldMetadata := range MetadataFromType(structValue.Type()).All() {
+		key, ok := cfg.mapKey(fieldName, fieldMetadata)
+		if !ok {
+			continue
+		}
+		fieldValue, reachable := promotedFieldByName(structValue, fieldName)
+		if !reachable {
+			continue
+		}
+		encoded[key] = fieldValue.Interface()
+	}
+	return encoded
+}
+
+// promotedFieldByName returns the field of the struct value with the name. It returns false if the field
+// is promoted through a nil embedded struct pointer, since the field does not exist in the value.
+func promotedFieldByName(structValue reflect.Value, fieldName string) (reflect.Value, bool) {
+	structField, _ := structValue.Type().FieldByName(fieldName)
+	fieldValue, err := structValue.FieldByIndexErr(structField.Index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return fieldValue, true
+}
+
+// Decode sets the exported struct fields from the values of the map.
+// Keys that do not match a field are ignored unless WithUnknownKeys is set to UnknownKeysError.
+// A value that can be assigned to the field, or to what the field points to, is set directly.
+// Other values are converted to a string and set with AssignToField, so a "42" or 42.0 can be set into an int,
+// and a map[string]any into a nested struct.
+func Decode[T any](values map[string]any, obj *T, opts ...MapOption) error {
+	structValue := reflect.ValueOf(obj)
+	if structValue.Kind() != reflect.Ptr || structValue.Elem().Kind() != reflect.Struct {
+		panic("obj must be a pointer to a struct")
+	}
+
+	cfg := configureMap(opts...)
+	keysToFieldNames := cfg.keysToFieldNames(structValue.Elem().Type())
+	keys := slices.Sorted(maps.Keys(values))
+	if err := cfg.unknownKeysError(keys, keysToFieldNames); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		fieldName, known := keysToFieldNames[key]
+		if !known {
+			continue
+		}
+		if err := decodeField(obj, structValue.Elem().FieldByName(fieldName), fieldName, values[key]); err != nil {
+			return fmt.Errorf("failed to decode the value of key %s into field %s (%w)", key, fieldName, err)
+		}
+	}
+	return nil
+}
+
+// AssignFromMap sets the exported struct fields from a map of keys to string encoded values with AssignToField.
+// Unlike Decode, it does not stop at the first failure. All the fields that can be assigned are set,
+// and the errors of the others are joined together with the unknown keys, if WithUnknownKeys is UnknownKeysError.
+func AssignFromMap[T any](obj *T, values map[string]string, opts ...MapOption) error {
+	structValue := reflect.ValueOf(obj)
+	if structValue.Kind() != reflect.Ptr || structValue.Elem().Kind() != reflect.Struct {
+		panic("obj must be a pointer to a struct")
+	}
+
+	cfg := configureMap(opts...)
+	keysToFieldNames := cfg.keysToFieldNames(structValue.Elem().Type())
+	keys := slices.Sorted(maps.Keys(values))
+	errs := []error{cfg.unknownKeysError(keys, keysToFieldNames)}
+	for _, key := range keys {
+		fieldName, known := keysToFieldNames[key]
+		if !known {
+			continue
+		}
+		if err := AssignToField(obj, fieldName, values[key]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to assign the value of key %s to field %s (%w)", key, fieldName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// decodeField sets a single field from a map value.
+func decodeField[T any](obj *T, fieldValue reflect.Value, fieldName string, value any) error {
+	if value == nil {
+		fieldValue.SetZero()
+		return nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	fieldType := fieldValue.Type()
+	if reflectValue.Type().AssignableTo(fieldType) {
+		fieldValue.Set(reflectValue)
+		return nil
+	}
+	if fieldType.Kind() == reflect.Ptr && reflectValue.Type().AssignableTo(fieldType.Elem()) {
+		fieldPtr := reflect.New(fieldType.Elem())
+		fieldPtr.Elem().Set(reflectValue)
+		fieldValue.Set(fieldPtr)
+		return nil
+	}
+	if reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			fieldValue.SetZero()
+			return nil
+		}
+		return decodeField(obj, fieldValue, fieldName, reflectValue.Elem().Interface())
+	}
+
+	var encoded string
+	switch reflectValue.Kind() {
+	case reflect.String:
+		encoded = reflectValue.String()
+	case reflect.Float32, reflect.Float64:
+		encoded = strconv.FormatFloat(reflectValue.Float(), 'f', -1, reflectValue.Type().Bits())
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		marshaled, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("json marshal error (%w)", err)
+		}
+		encoded = string(marshaled)
+	default:
+		encoded = fmt.Sprint(value)
+	}
+	return AssignToField(obj, fieldName, encoded)
+}
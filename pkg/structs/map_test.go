@@ -0,0 +1,209 @@
+package structs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/structs"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestMapConversion(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Value int `json:"value"`
+	}
+
+	type embedded struct {
+		EmbeddedField string `json:"embedded_field"`
+	}
+
+	type testStruct struct {
+		embedded
+		Name       string         `json:"name,omitempty"`
+		Count      int            `json:"count"`
+		Pointer    *int           `json:"pointer"`
+		Nested     nested         `json:"nested"`
+		List       []string       `json:"list"`
+		Timeout    time.Duration  `json:"timeout"`
+		Labels     map[string]int `json:""`
+		Ignored    string         `json:"-"`
+		unexported string
+	}
+
+	t.Run("when a struct is encoded it should map the field names to the values", func(t *testing.T) {
+		t.Parallel()
+		encoded := structs.Encode(testStruct{
+			embedded: embedded{EmbeddedField: "embedded"},
+			Name:     "name",
+			Count:    1,
+			Nested:   nested{Value: 2},
+		})
+		assert.Equals(t, len(encoded), 9)
+		assert.Equals(t, encoded["EmbeddedField"], any("embedded"))
+		assert.Equals(t, encoded["Name"], any("name"))
+		assert.Equals(t, encoded["Count"], any(1))
+		assert.Equals(t, encoded["Pointer"], any((*int)(nil)))
+		assert.Equals(t, encoded["Nested"], any(nested{Value: 2}))
+		assert.Equals(t, encoded["Ignored"], any(""))
+		_, hasUnexported := encoded["unexported"]
+		assert.False(t, hasUnexported)
+	})
+
+	t.Run("when a struct is encoded with a tag it should use the tag values as keys", func(t *testing.T) {
+		t.Parallel()
+		encoded := structs.Encode(&testStruct{Name: "name"}, structs.WithTag("json"))
+		assert.Equals(t, len(encoded), 8)
+		assert.Equals(t, encoded["name"], any("name"))
+		assert.Equals(t, encoded["embedded_field"], any(""))
+		_, hasLabels := encoded["Labels"]
+		assert.True(t, hasLabels)
+		_, hasIgnored := encoded["Ignored"]
+		assert.False(t, hasIgnored)
+	})
+
+	t.Run("when a struct with a nil embedded pointer is encoded it should omit its fields", func(t *testing.T) {
+		t.Parallel()
+		type inner struct {
+			A int
+		}
+		type outer struct {
+			*inner
+			B int
+		}
+		encoded := structs.Encode(outer{B: 2})
+		assert.Equals(t, encoded, map[string]any{"B": 2})
+		encoded = structs.Encode(outer{inner: &inner{A: 1}, B: 2})
+		assert.Equals(t, encoded, map[string]any{"A": 1, "B": 2})
+	})
+
+	t.Run("when encoding a value that is not a struct it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicPart(t, func() {
+			structs.Encode(1)
+		}, "obj must be a struct or a pointer to a struct")
+		assert.PanicPart(t, func() {
+			structs.Encode[*testStruct](nil)
+		}, "obj must not be nil")
+	})
+
+	t.Run("when a map is decoded it should set the fields", func(t *testing.T) {
+		t.Parallel()
+		var decoded testStruct
+		err := structs.Decode(map[string]any{
+			"embedded_field": "embedded",
+			"name":           "name",
+			"count":          "3",
+			"pointer":        4,
+			"nested":         map[string]any{"value": 5},
+			"list":           []any{"a", "b"},
+			"timeout":        time.Second,
+			"Labels":         map[string]int{"key": 6},
+			"Ignored":        "ignored",
+			"unknown":        true,
+		}, &decoded, structs.WithTag("json"))
+		assert.NoError(t, err)
+		assert.Equals(t, decoded, testStruct{
+			embedded: embedded{EmbeddedField: "embedded"},
+			Name:     "name",
+			Count:    3,
+			Pointer:  ptr.Of(4),
+			Nested:   nested{Value: 5},
+			List:     []string{"a", "b"},
+			Timeout:  time.Second,
+			Labels:   map[string]int{"key": 6},
+		})
+	})
+
+	t.Run("when a map has JSON numbers it should convert them to the field type", func(t *testing.T) {
+		t.Parallel()
+		var decoded testStruct
+		err := structs.Decode(map[string]any{"Count": float64(1000000), "Pointer": ptr.Of(float64(7))}, &decoded)
+		assert.NoError(t, err)
+		assert.Equals(t, decoded.Count, 1000000)
+		assert.Equals(t, *decoded.Pointer, 7)
+	})
+
+	t.Run("when a map has nil values it should reset the fields", func(t *testing.T) {
+		t.Parallel()
+		decoded := testStruct{Name: "name", Pointer: ptr.Of(1)}
+		err := structs.Decode(map[string]any{"Name": nil, "Pointer": (*int)(nil)}, &decoded)
+		assert.NoError(t, err)
+		assert.Equals(t, decoded.Name, "")
+		assert.Nil(t, decoded.Pointer)
+	})
+
+	t.Run("when a value cannot be converted it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var decoded testStruct
+		err := structs.Decode(map[string]any{"Count": "not_an_int"}, &decoded)
+		assert.ErrorPart(t, err, "failed to decode the value of key Count into field Count (int parsing error")
+		err = structs.Decode(map[string]any{"Nested": map[string]any{"value": "not_an_int"}}, &decoded)
+		assert.ErrorPart(t, err, "json unmarshal error")
+		err = structs.Decode(map[string]any{"List": []any{func() {}}}, &decoded)
+		assert.ErrorPart(t, err, "json marshal error")
+	})
+
+	t.Run("when a struct is encoded and decoded it should be the same", func(t *testing.T) {
+		t.Parallel()
+		original := testStruct{Name: "name", Count: 1, Pointer: ptr.Of(2), List: []string{"a"}}
+		var decoded testStruct
+		assert.NoError(t, structs.Decode(structs.Encode(original), &decoded))
+		original.Ignored = ""
+		assert.Equals(t, decoded, original)
+	})
+
+	t.Run("when unknown keys are decoded with the error policy it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var decoded testStruct
+		err := structs.Decode(map[string]any{"Name": "name", "b": 1, "a": 2}, &decoded, structs.WithUnknownKeys(structs.UnknownKeysError))
+		assert.ErrorExact(t, err, "unknown key a\nunknown key b")
+		assert.Equals(t, decoded.Name, "")
+	})
+
+	t.Run("when a string map is assigned it should set the fields", func(t *testing.T) {
+		t.Parallel()
+		var assigned testStruct
+		err := structs.AssignFromMap(&assigned, map[string]string{
+			"embedded_field": "embedded",
+			"count":          "1",
+			"pointer":        "2",
+			"nested":         `{"value":3}`,
+			"timeout":        "4",
+			"unknown":        "ignored",
+		}, structs.WithTag("json"))
+		assert.NoError(t, err)
+		assert.Equals(t, assigned, testStruct{
+			embedded: embedded{EmbeddedField: "embedded"},
+			Count:    1,
+			Pointer:  ptr.Of(2),
+			Nested:   nested{Value: 3},
+			Timeout:  4,
+		})
+	})
+
+	t.Run("when a string map has invalid values it should set the valid fields and join all the errors", func(t *testing.T) {
+		t.Parallel()
+		var assigned testStruct
+		err := structs.AssignFromMap(&assigned, map[string]string{
+			"Name":    "name",
+			"Count":   "not_an_int",
+			"Pointer": "not_an_int",
+			"unknown": "value",
+		}, structs.WithUnknownKeys(structs.UnknownKeysError))
+		assert.ErrorPart(t, err, "unknown key unknown")
+		assert.ErrorPart(t, err, "failed to assign the value of key Count to field Count (int parsing error")
+		assert.ErrorPart(t, err, "failed to assign the value of key Pointer to field Pointer (int parsing error")
+		assert.Equals(t, assigned.Name, "name")
+	})
+
+	t.Run("when a string map is assigned to a value that is not a struct pointer it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			var value int
+			_ = structs.AssignFromMap(&value, map[string]string{})
+		}, "obj must be a pointer to a struct")
+	})
+}
@@ -0,0 +1,79 @@
+package structs
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// mergeConfig is the configuration for the Merge function.
+type mergeConfig struct {
+	overwrite bool
+}
+
+// MergeOption is used to set parameters for the Merge function.
+type MergeOption func(*mergeConfig)
+
+// WithOverwrite makes the non-zero fields of the source replace the fields of the destination.
+// Without it, only the zero-valued fields of the destination are filled.
+func WithOverwrite() MergeOption {
+	return func(c *mergeConfig) {
+		c.overwrite = true
+	}
+}
+
+// Merge copies the exported fields of src into dst. By default, only the zero-valued fields of dst are set,
+// which layers the src as defaults under what is already in dst. With WithOverwrite, the non-zero fields of src
+// take precedence instead.
+//
+// Nested structs, and pointers to structs that are set in both dst and src, are merged field by field.
+// Structs that implement encoding.TextMarshaler, like time.Time, are treated as single values.
+// Values copied from src are deep copies, so dst never shares memory with src.
+func Merge[T any](dst *T, src T, opts ...MergeOption) {
+	if dst == nil {
+		panic("dst must not be nil")
+	}
+	cfg := &mergeConfig{
+		overwrite: false,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	m := &merger{
+		config: cfg,
+		cloner: &cloner{visited: make(map[cloneVisitKey]reflect.Value)},
+	}
+	m.merge(reflect.ValueOf(dst).Elem(), reflect.ValueOf(&src).Elem())
+}
+
+// merger merges values according to its configuration.
+type merger struct {
+	config *mergeConfig
+	cloner *cloner
+}
+
+// merge merges src into dst. The dst value must be settable or a struct with settable fields.
+func (m *merger) merge(dst reflect.Value, src reflect.Value) {
+	switch {
+	case mergedByField(dst.Type()):
+		for fieldIndex := 0; fieldIndex < dst.NumField(); fieldIndex++ {
+			dstField := dst.Field(fieldIndex)
+			if dstField.CanSet() || (dst.Type().Field(fieldIndex).Anonymous && mergedByField(dstField.Type())) {
+				m.merge(dstField, src.Field(fieldIndex))
+			}
+		}
+	case dst.Kind() == reflect.Ptr && mergedByField(dst.Type().Elem()) && !dst.IsNil() && !src.IsNil():
+		m.merge(dst.Elem(), src.Elem())
+	case src.IsZero():
+		return
+	case dst.IsZero() || m.config.overwrite:
+		m.cloner.clone(src, dst)
+	}
+}
+
+// mergedByField returns true if the type is a struct that is merged field by field instead of as a single value.
+func mergedByField(reflectType reflect.Type) bool {
+	textMarshalerType := reflect.TypeFor[encoding.TextMarshaler]()
+	return reflectType.Kind() == reflect.Struct &&
+		!reflectType.Implements(textMarshalerType) &&
+		!reflect.PointerTo(reflectType).Implements(textMarshalerType)
+}
@@ -0,0 +1,125 @@
+package structs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/ptr"
+	"github.com/TriangleSide/GoTools/pkg/structs"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host string
+		Port int
+	}
+
+	type embedded struct {
+		EmbeddedField string
+	}
+
+	type testStruct struct {
+		embedded
+		Name       string
+		Server     server
+		Database   *server
+		Tags       []string
+		Labels     map[string]string
+		Timeout    *int
+		Started    time.Time
+		unexported string
+	}
+
+	defaults := func() testStruct {
+		return testStruct{
+			embedded:   embedded{EmbeddedField: "default"},
+			Name:       "default",
+			Server:     server{Host: "localhost", Port: 8080},
+			Database:   &server{Host: "db", Port: 5432},
+			Tags:       []string{"default"},
+			Labels:     map[string]string{"env": "default"},
+			Timeout:    ptr.Of(30),
+			Started:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			unexported: "default",
+		}
+	}
+
+	t.Run("when dst is empty it should be filled with src", func(t *testing.T) {
+		t.Parallel()
+		var dst testStruct
+		structs.Merge(&dst, defaults())
+		expected := defaults()
+		expected.unexported = ""
+		assert.Equals(t, dst, expected)
+	})
+
+	t.Run("when dst has values it should only fill the zero-valued fields", func(t *testing.T) {
+		t.Parallel()
+		dst := testStruct{
+			Name:     "user",
+			Server:   server{Port: 9090},
+			Database: &server{Host: "user-db"},
+			Tags:     []string{},
+			Started:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		structs.Merge(&dst, defaults())
+		assert.Equals(t, dst.EmbeddedField, "default")
+		assert.Equals(t, dst.Name, "user")
+		assert.Equals(t, dst.Server, server{Host: "localhost", Port: 9090})
+		assert.Equals(t, *dst.Database, server{Host: "user-db", Port: 5432})
+		assert.Equals(t, dst.Tags, []string{})
+		assert.Equals(t, dst.Labels, map[string]string{"env": "default"})
+		assert.Equals(t, *dst.Timeout, 30)
+		assert.Equals(t, dst.Started, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("when overwriting it should replace the fields that are set in src", func(t *testing.T) {
+		t.Parallel()
+		dst := defaults()
+		structs.Merge(&dst, testStruct{
+			Name:     "user",
+			Server:   server{Port: 9090},
+			Database: &server{Host: "user-db"},
+			Timeout:  ptr.Of(10),
+		}, structs.WithOverwrite())
+		assert.Equals(t, dst.EmbeddedField, "default")
+		assert.Equals(t, dst.Name, "user")
+		assert.Equals(t, dst.Server, server{Host: "localhost", Port: 9090})
+		assert.Equals(t, *dst.Database, server{Host: "user-db", Port: 5432})
+		assert.Equals(t, dst.Tags, []string{"default"})
+		assert.Equals(t, *dst.Timeout, 10)
+		assert.Equals(t, dst.unexported, "default")
+	})
+
+	t.Run("when values are copied from src it should not share memory with it", func(t *testing.T) {
+		t.Parallel()
+		src := defaults()
+		var dst testStruct
+		structs.Merge(&dst, src)
+		dst.Database.Port = 1
+		dst.Tags[0] = "changed"
+		dst.Labels["env"] = "changed"
+		*dst.Timeout = 1
+		assert.Equals(t, src, defaults())
+	})
+
+	t.Run("when the struct implements a text marshaler it should be merged as a single value", func(t *testing.T) {
+		t.Parallel()
+		type withTime struct {
+			Time time.Time
+		}
+		dst := withTime{}
+		structs.Merge(&dst, withTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+		assert.Equals(t, dst.Time, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("when dst is nil it should panic", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicExact(t, func() {
+			structs.Merge(nil, testStruct{})
+		}, "dst must not be nil")
+	})
+}
@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
-	"time"
+	"sync"
 
-	"github.com/TriangleSide/GoTools/pkg/datastructures/cache"
 	"github.com/TriangleSide/GoTools/pkg/datastructures/readonly"
 )
 
@@ -15,7 +14,9 @@ var (
 	tagMatchRegex = regexp.MustCompile(`(\w+):"([^"]*)"`)
 
 	// typeToMetadataCache is used to cache the result of the Metadata function.
-	typeToMetadataCache = cache.New[reflect.Type, *readonly.Map[string, *FieldMetadata]]()
+	// It is a sync.Map because it is read on every call and only written once per type,
+	// which makes a cache hit free of locks and allocations.
+	typeToMetadataCache = sync.Map{}
 )
 
 // Metadata returns a map of a structs field names to their respective metadata.
@@ -25,13 +26,14 @@ func Metadata[T any]() *readonly.Map[string, *FieldMetadata] {
 
 // MetadataFromType returns a map of a structs field names to their respective metadata.
 func MetadataFromType(reflectType reflect.Type) *readonly.Map[string, *FieldMetadata] {
-	fieldsToMetadata, _ := typeToMetadataCache.GetOrSet(reflectType, func(reflectType reflect.Type) (*readonly.Map[string, *FieldMetadata], *time.Duration, error) {
-		fieldsToMetadata := make(map[string]*FieldMetadata)
-		processType(reflectType, fieldsToMetadata, make([]string, 0))
-		readOnlyMap := readonly.NewMapBuilder[string, *FieldMetadata]().SetMap(fieldsToMetadata).Build()
-		return readOnlyMap, nil, nil
-	})
-	return fieldsToMetadata
+	if cached, found := typeToMetadataCache.Load(reflectType); found {
+		return cached.(*readonly.Map[string, *FieldMetadata])
+	}
+	fieldsToMetadata := make(map[string]*FieldMetadata)
+	processType(reflectType, fieldsToMetadata, make([]string, 0))
+	readOnlyMap := readonly.NewMapBuilder[string, *FieldMetadata]().SetMap(fieldsToMetadata).Build()
+	cached, _ := typeToMetadataCache.LoadOrStore(reflectType, readOnlyMap)
+	return cached.(*readonly.Map[string, *FieldMetadata])
 }
 
 // processType takes a struct type, lists all of its fields, and builds the metadata for it.
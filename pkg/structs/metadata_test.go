@@ -187,3 +187,31 @@ func TestStructMetadata(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func BenchmarkMetadata(b *testing.B) {
+	type embeddedStruct struct {
+		EmbeddedField string `key:"Embedded"`
+	}
+
+	type testStruct struct {
+		embeddedStruct
+		Value1 string `key:"Value1"`
+		Value2 int    `key:"Value2"`
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = structs.Metadata[testStruct]()
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = structs.Metadata[testStruct]()
+			}
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package assert
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
 	"strings"
@@ -12,7 +13,7 @@ func Equals(t Testing, actual any, expected any, options ...Option) {
 	tCtx := newTestContext(t, options...)
 	tCtx.Helper()
 	if !reflect.DeepEqual(expected, actual) {
-		tCtx.fail(fmt.Sprintf("Expected %+v to equal %+v.", actual, expected))
+		tCtx.fail(fmt.Sprintf("Expected %+v to equal %+v.", actual, expected) + describeDifferences(actual, expected))
 	}
 }
 
@@ -96,6 +97,28 @@ func PanicPart(t Testing, panicFunc func(), part string, options ...Option) {
 	assertPanic(tCtx, panicFunc, &part, false)
 }
 
+// NotPanic checks that a function does not panic.
+func NotPanic(t Testing, panicFunc func(), options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+
+	var recovered any
+	panicOccurred := false
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				panicOccurred = true
+				recovered = r
+			}
+		}()
+		panicFunc()
+	}()
+
+	if panicOccurred {
+		tCtx.fail(fmt.Sprintf("Expected no panic but got '%v'.", recovered))
+	}
+}
+
 // Error checks if an error occurred.
 func Error(t Testing, err error, options ...Option) {
 	tCtx := newTestContext(t, options...)
@@ -224,3 +247,57 @@ func FloatEquals[T ~float64 | ~float32](t Testing, first T, second T, epsilon T,
 		tCtx.fail(fmt.Sprintf("Expecting %f to equal %f within a margin of %f.", first, second, epsilon))
 	}
 }
+
+// Zero checks if a value is the zero value of its type. A nil value is zero.
+func Zero(t Testing, value any, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if reflectValue := reflect.ValueOf(value); reflectValue.IsValid() && !reflectValue.IsZero() {
+		tCtx.fail(fmt.Sprintf("Expecting %+v to be the zero value.", value))
+	}
+}
+
+// NotZero checks if a value is not the zero value of its type.
+func NotZero(t Testing, value any, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if reflectValue := reflect.ValueOf(value); !reflectValue.IsValid() || reflectValue.IsZero() {
+		tCtx.fail("Expecting the value to not be the zero value.")
+	}
+}
+
+// Greater checks if the first value is greater than the second.
+func Greater[T cmp.Ordered](t Testing, first T, second T, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if !(first > second) {
+		tCtx.fail(fmt.Sprintf("Expecting %v to be greater than %v.", first, second))
+	}
+}
+
+// GreaterOrEqual checks if the first value is greater than or equal to the second.
+func GreaterOrEqual[T cmp.Ordered](t Testing, first T, second T, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if !(first >= second) {
+		tCtx.fail(fmt.Sprintf("Expecting %v to be greater than or equal to %v.", first, second))
+	}
+}
+
+// Less checks if the first value is less than the second.
+func Less[T cmp.Ordered](t Testing, first T, second T, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if !(first < second) {
+		tCtx.fail(fmt.Sprintf("Expecting %v to be less than %v.", first, second))
+	}
+}
+
+// LessOrEqual checks if the first value is less than or equal to the second.
+func LessOrEqual[T cmp.Ordered](t Testing, first T, second T, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	if !(first <= second) {
+		tCtx.fail(fmt.Sprintf("Expecting %v to be less than or equal to %v.", first, second))
+	}
+}
@@ -158,6 +158,40 @@ func TestAssertFunctions(t *testing.T) {
 				},
 				expectLogs: []string{"Expected map[] to equal map[]."},
 			},
+			{
+				name: "Equals negative case - Comparing structs should list the differences",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					type item struct {
+						ID   int
+						Name string
+					}
+					actual := []item{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+					expected := []item{{ID: 1, Name: "c"}}
+					assert.Equals(tr, actual, expected, opts...)
+				},
+				expectLogs: []string{
+					"Differences (actual != expected):",
+					"\n  [0].Name: \"a\" != \"c\"",
+					"\n  [1]: {ID:2 Name:b} != <missing>",
+				},
+			},
+			{
+				name: "Equals negative case - Comparing maps should list the missing keys",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Equals(tr, map[string]*int{"a": nil}, map[string]*int{"b": nil}, opts...)
+				},
+				expectLogs: []string{
+					"\n  [\"a\"]: <nil> != <missing>",
+					"\n  [\"b\"]: <missing> != <nil>",
+				},
+			},
+			{
+				name: "Equals negative case - Comparing many differences should summarize the rest",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Equals(tr, make([]int, 30), []int{}, opts...)
+				},
+				expectLogs: []string{"\n  [24]: 0 != <missing>\n  ... and 5 more."},
+			},
 			{
 				name: "NotEquals positive case - Comparing different integers",
 				callback: func(tr *testRecorder, opts ...assert.Option) {
@@ -513,6 +547,113 @@ func TestAssertFunctions(t *testing.T) {
 					"within a margin of 0.",
 				},
 			},
+			{
+				name: "NotPanic positive case - No panic",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotPanic(tr, func() {}, opts...)
+				},
+				expectLogs: []string{},
+			},
+			{
+				name: "NotPanic negative case - Panic with a message",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotPanic(tr, func() { panic("unexpected") }, opts...)
+				},
+				expectLogs: []string{"Expected no panic but got 'unexpected'."},
+			},
+			{
+				name: "NotPanic negative case - Panic with an error",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotPanic(tr, func() { panic(errors.New("error")) }, opts...)
+				},
+				expectLogs: []string{"Expected no panic but got 'error'."},
+			},
+			{
+				name: "Zero positive case - Zero values",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Zero(tr, nil, opts...)
+					assert.Zero(tr, 0, opts...)
+					assert.Zero(tr, "", opts...)
+					assert.Zero(tr, struct{ Value int }{}, opts...)
+					assert.Zero(tr, []int(nil), opts...)
+				},
+				expectLogs: []string{},
+			},
+			{
+				name: "Zero negative case - Non-zero struct",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Zero(tr, struct{ Value int }{Value: 1}, opts...)
+				},
+				expectLogs: []string{"Expecting {Value:1} to be the zero value."},
+			},
+			{
+				name: "Zero negative case - Empty slice",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Zero(tr, []int{}, opts...)
+				},
+				expectLogs: []string{"Expecting [] to be the zero value."},
+			},
+			{
+				name: "NotZero positive case - Non-zero values",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotZero(tr, 1, opts...)
+					assert.NotZero(tr, "a", opts...)
+					assert.NotZero(tr, []int{}, opts...)
+				},
+				expectLogs: []string{},
+			},
+			{
+				name: "NotZero negative case - Zero integer",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotZero(tr, 0, opts...)
+				},
+				expectLogs: []string{"Expecting the value to not be the zero value."},
+			},
+			{
+				name: "NotZero negative case - Nil",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.NotZero(tr, nil, opts...)
+				},
+				expectLogs: []string{"Expecting the value to not be the zero value."},
+			},
+			{
+				name: "Ordered comparisons positive case - Values in order",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Greater(tr, 2, 1, opts...)
+					assert.GreaterOrEqual(tr, 2.5, 2.5, opts...)
+					assert.Less(tr, "a", "b", opts...)
+					assert.LessOrEqual(tr, uint8(1), uint8(1), opts...)
+				},
+				expectLogs: []string{},
+			},
+			{
+				name: "Greater negative case - Equal values",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Greater(tr, 1, 1, opts...)
+				},
+				expectLogs: []string{"Expecting 1 to be greater than 1."},
+			},
+			{
+				name: "GreaterOrEqual negative case - Smaller value",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.GreaterOrEqual(tr, 1.5, 2.5, opts...)
+				},
+				expectLogs: []string{"Expecting 1.5 to be greater than or equal to 2.5."},
+			},
+			{
+				name: "Less negative case - Equal values",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.Less(tr, "b", "b", opts...)
+				},
+				expectLogs: []string{"Expecting b to be less than b."},
+			},
+			{
+				name: "LessOrEqual negative case - Greater value",
+				callback: func(tr *testRecorder, opts ...assert.Option) {
+					assert.LessOrEqual(tr, 3, 2, opts...)
+				},
+				expectLogs: []string{"Expecting 3 to be less than or equal to 2."},
+			},
 		}
 		for _, testCase := range testCases {
 			t.Run(testCase.name, func(t *testing.T) {
@@ -0,0 +1,57 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+)
+
+// checkPollingDurations panics if the durations of a polling assertion are not positive.
+func checkPollingDurations(duration time.Duration, interval time.Duration) {
+	if duration <= 0 {
+		panic("The duration must be greater than zero.")
+	}
+	if interval <= 0 {
+		panic("The interval must be greater than zero.")
+	}
+}
+
+// Eventually checks that the condition becomes true within the timeout.
+// The condition is checked right away, and then on every interval until it is true or the timeout passes.
+func Eventually(t Testing, condition func() bool, timeout time.Duration, interval time.Duration, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	checkPollingDurations(timeout, interval)
+
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for !condition() {
+		if !time.Now().Before(deadline) {
+			tCtx.fail(fmt.Sprintf("Expecting the condition to be true within %s.", timeout))
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// Consistently checks that the condition stays true for the whole duration.
+// The condition is checked right away, and then on every interval until it is false or the duration passes.
+func Consistently(t Testing, condition func() bool, duration time.Duration, interval time.Duration, options ...Option) {
+	tCtx := newTestContext(t, options...)
+	tCtx.Helper()
+	checkPollingDurations(duration, interval)
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if !condition() {
+			tCtx.fail(fmt.Sprintf("Expecting the condition to stay true for %s but it was false after %s.", duration, time.Since(start).Round(time.Millisecond)))
+			return
+		}
+		if time.Since(start) >= duration {
+			return
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,94 @@
+package assert_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+func TestAsyncAssertions(t *testing.T) {
+	t.Parallel()
+
+	failures := func(tr *testRecorder) string {
+		return strings.Join(tr.logs, "\n")
+	}
+
+	t.Run("when the condition becomes true before the timeout Eventually should pass", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		calls := atomic.Int32{}
+		assert.Eventually(tr, func() bool {
+			return calls.Add(1) == 3
+		}, time.Second, time.Millisecond)
+		assert.Equals(t, tr.fatalCount, 0)
+		assert.Equals(t, calls.Load(), int32(3))
+	})
+
+	t.Run("when the condition is already true Eventually should pass without waiting", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		start := time.Now()
+		assert.Eventually(tr, func() bool { return true }, time.Minute, time.Minute)
+		assert.Equals(t, tr.fatalCount, 0)
+		assert.True(t, time.Since(start) < time.Minute)
+	})
+
+	t.Run("when the condition stays false Eventually should fail after the timeout", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		start := time.Now()
+		assert.Eventually(tr, func() bool { return false }, 20*time.Millisecond, time.Millisecond)
+		assert.True(t, time.Since(start) >= 20*time.Millisecond)
+		assert.Equals(t, tr.fatalCount, 1)
+		assert.Equals(t, failures(tr), "Expecting the condition to be true within 20ms.")
+	})
+
+	t.Run("when the condition stays false and Continue is set Eventually should mark an error", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		assert.Eventually(tr, func() bool { return false }, time.Millisecond, time.Millisecond, assert.Continue())
+		assert.Equals(t, tr.errorCount, 1)
+		assert.Equals(t, tr.fatalCount, 0)
+	})
+
+	t.Run("when the condition stays true Consistently should pass after the duration", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		start := time.Now()
+		calls := atomic.Int32{}
+		assert.Consistently(tr, func() bool {
+			calls.Add(1)
+			return true
+		}, 20*time.Millisecond, time.Millisecond)
+		assert.True(t, time.Since(start) >= 20*time.Millisecond)
+		assert.True(t, calls.Load() > 1)
+		assert.Equals(t, tr.fatalCount, 0)
+	})
+
+	t.Run("when the condition becomes false Consistently should fail right away", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		calls := atomic.Int32{}
+		start := time.Now()
+		assert.Consistently(tr, func() bool {
+			return calls.Add(1) < 3
+		}, time.Minute, time.Millisecond)
+		assert.True(t, time.Since(start) < time.Minute)
+		assert.Equals(t, tr.fatalCount, 1)
+		assert.Contains(t, failures(tr), "Expecting the condition to stay true for 1m0s but it was false after")
+	})
+
+	t.Run("when the durations are not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		tr := newTestRecorder(t)
+		assert.PanicExact(t, func() {
+			assert.Eventually(tr, func() bool { return true }, 0, time.Millisecond)
+		}, "The duration must be greater than zero.")
+		assert.PanicExact(t, func() {
+			assert.Consistently(tr, func() bool { return true }, time.Second, 0)
+		}, "The interval must be greater than zero.")
+	})
+}
@@ -0,0 +1,52 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/TriangleSide/GoTools/pkg/reflection"
+)
+
+const (
+	// maxDiffLines is the number of differences listed before the rest are summarized.
+	maxDiffLines = 25
+)
+
+// describeDifferences lists the fields, elements, and entries that differ between the actual and expected values.
+// It returns an empty string if the values only differ as a whole, like two numbers, since the
+// failure message already shows them.
+func describeDifferences(actual any, expected any) string {
+	differences := reflection.Diff(actual, expected)
+	if len(differences) == 0 || (len(differences) == 1 && differences[0].Path == "") {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString("\nDifferences (actual != expected):")
+	for i, difference := range differences {
+		if i == maxDiffLines {
+			sb.WriteString(fmt.Sprintf("\n  ... and %d more.", len(differences)-maxDiffLines))
+			break
+		}
+		path := difference.Path
+		if path == "" {
+			path = "(root)"
+		}
+		sb.WriteString(fmt.Sprintf("\n  %s: %s != %s", path, formatDiffValue(difference.A), formatDiffValue(difference.B)))
+	}
+	return sb.String()
+}
+
+// formatDiffValue formats a value of a difference. Strings are quoted to make whitespace visible.
+func formatDiffValue(value reflect.Value) string {
+	switch {
+	case !value.IsValid():
+		return "<missing>"
+	case value.Kind() == reflect.String:
+		return fmt.Sprintf("%q", value.String())
+	case value.Kind() == reflect.Ptr && !value.IsNil():
+		return fmt.Sprintf("&%+v", value.Elem())
+	default:
+		return fmt.Sprintf("%+v", value)
+	}
+}
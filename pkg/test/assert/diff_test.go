@@ -0,0 +1,29 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestDescribeDifferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when the values contain themselves it should list the differences without looping", func(t *testing.T) {
+		t.Parallel()
+		actual := []any{nil, 1}
+		actual[0] = actual
+		expected := []any{nil, 2}
+		expected[0] = expected
+		description := describeDifferences(actual, expected)
+		Contains(t, description, "\n  [1]: 1 != 2")
+	})
+
+	t.Run("when maps contain themselves it should list the differences without looping", func(t *testing.T) {
+		t.Parallel()
+		actual := map[string]any{"value": "a"}
+		actual["self"] = actual
+		expected := map[string]any{"value": "b"}
+		expected["self"] = expected
+		description := describeDifferences(actual, expected)
+		Contains(t, description, "\n  [\"value\"]: \"a\" != \"b\"")
+	})
+}
@@ -0,0 +1,194 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/x509util"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+const (
+	// CACertFile is the name of the file of the CA certificate.
+	CACertFile = "ca_cert.pem"
+
+	// CAKeyFile is the name of the file of the CA private key.
+	CAKeyFile = "ca_key.pem"
+
+	// ServerCertFile is the name of the file of the server certificate.
+	ServerCertFile = "server_cert.pem"
+
+	// ServerKeyFile is the name of the file of the server private key.
+	ServerKeyFile = "server_key.pem"
+
+	// ClientCertFile is the name of the file of the client certificate.
+	ClientCertFile = "client_cert.pem"
+
+	// ClientKeyFile is the name of the file of the client private key.
+	ClientKeyFile = "client_key.pem"
+)
+
+// Testing is the subset of testing.T needed to generate the certificates.
+type Testing interface {
+	assert.Testing
+	TempDir() string
+}
+
+// config holds the settings of the generated certificates.
+type config struct {
+	validity    time.Duration
+	dnsNames    []string
+	ipAddresses []net.IP
+}
+
+// Option configures the generated certificates.
+type Option func(*config)
+
+// WithValidity sets how long the certificates are valid for.
+func WithValidity(validity time.Duration) Option {
+	return func(cfg *config) {
+		cfg.validity = validity
+	}
+}
+
+// WithDNSNames sets the DNS names of the server certificate.
+func WithDNSNames(dnsNames ...string) Option {
+	return func(cfg *config) {
+		cfg.dnsNames = dnsNames
+	}
+}
+
+// WithIPAddresses sets the IP addresses of the server certificate.
+func WithIPAddresses(ipAddresses ...net.IP) Option {
+	return func(cfg *config) {
+		cfg.ipAddresses = ipAddresses
+	}
+}
+
+// configure creates a config out of the provided options.
+func configure(opts ...Option) *config {
+	cfg := &config{
+		validity:    24 * time.Hour,
+		dnsNames:    []string{"localhost"},
+		ipAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Chain is a CA with a server and a client certificate signed by it.
+// The certificates and private keys are written as PEM files in Dir.
+type Chain struct {
+	Dir    string
+	CA     *x509util.Certificate
+	Server *x509util.Certificate
+	Client *x509util.Certificate
+}
+
+// Generate creates a CA, a server certificate, and a client certificate, and writes them to a temporary
+// directory of the test. By default, the server certificate is valid for localhost and the loopback addresses.
+func Generate(t Testing, opts ...Option) *Chain {
+	t.Helper()
+	cfg := configure(opts...)
+
+	ca, err := x509util.NewCA(
+		x509util.WithCommonName("Test CA"),
+		x509util.WithOrganization("Test CA"),
+		x509util.WithValidity(cfg.validity),
+	)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Failed to create the CA certificate (%s).", err))
+	}
+	server, err := x509util.NewLeaf(ca,
+		x509util.WithCommonName("Test Server"),
+		x509util.WithOrganization("Test Server"),
+		x509util.WithValidity(cfg.validity),
+		x509util.WithExtKeyUsages(x509.ExtKeyUsageServerAuth),
+		x509util.WithDNSNames(cfg.dnsNames...),
+		x509util.WithIPAddresses(cfg.ipAddresses...),
+	)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Failed to create the server certificate (%s).", err))
+	}
+	client, err := x509util.NewLeaf(ca,
+		x509util.WithCommonName("Test Client"),
+		x509util.WithOrganization("Test Client"),
+		x509util.WithValidity(cfg.validity),
+		x509util.WithExtKeyUsages(x509.ExtKeyUsageClientAuth),
+	)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Failed to create the client certificate (%s).", err))
+	}
+
+	chain := &Chain{
+		Dir:    t.TempDir(),
+		CA:     ca,
+		Server: server,
+		Client: client,
+	}
+	files := []struct {
+		certificate *x509util.Certificate
+		certFile    string
+		keyFile     string
+	}{
+		{certificate: ca, certFile: CACertFile, keyFile: CAKeyFile},
+		{certificate: server, certFile: ServerCertFile, keyFile: ServerKeyFile},
+		{certificate: client, certFile: ClientCertFile, keyFile: ClientKeyFile},
+	}
+	for _, file := range files {
+		if err := file.certificate.WriteFiles(chain.Path(file.certFile), chain.Path(file.keyFile)); err != nil {
+			t.Fatal(fmt.Sprintf("Failed to write the certificate files (%s).", err))
+		}
+	}
+	return chain
+}
+
+// Path returns the path of a file in the directory of the chain.
+func (c *Chain) Path(name string) string {
+	return filepath.Join(c.Dir, name)
+}
+
+// WriteFile writes data to a file in the directory of the chain and returns its path.
+// It is used to create invalid certificate files for tests.
+func (c *Chain) WriteFile(t assert.Testing, name string, data []byte) string {
+	t.Helper()
+	path := c.Path(name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(fmt.Sprintf("Failed to write the file %s (%s).", name, err))
+	}
+	return path
+}
+
+// CertPool returns a pool that trusts the CA of the chain.
+func (c *Chain) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.CA.Certificate)
+	return pool
+}
+
+// ClientTLSConfig returns a TLS configuration that trusts the CA and presents the client certificate.
+func (c *Chain) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		RootCAs:      c.CertPool(),
+		Certificates: []tls.Certificate{c.Client.TLSCertificate()},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// ServerTLSConfig returns a TLS configuration that presents the server certificate and requires
+// client certificates signed by the CA.
+func (c *Chain) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{c.Server.TLSCertificate()},
+		ClientCAs:    c.CertPool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,108 @@
+package certs_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/crypto/x509util"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/certs"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a chain is generated it should write the certificates and keys to the directory", func(t *testing.T) {
+		t.Parallel()
+		chain := certs.Generate(t)
+		pairs := []struct {
+			certFile string
+			keyFile  string
+			expected *x509util.Certificate
+		}{
+			{certs.CACertFile, certs.CAKeyFile, chain.CA},
+			{certs.ServerCertFile, certs.ServerKeyFile, chain.Server},
+			{certs.ClientCertFile, certs.ClientKeyFile, chain.Client},
+		}
+		for _, pair := range pairs {
+			loaded, err := x509util.Load(chain.Path(pair.certFile), chain.Path(pair.keyFile))
+			assert.NoError(t, err)
+			assert.True(t, loaded.Certificate.Equal(pair.expected.Certificate))
+		}
+		assert.True(t, chain.CA.Certificate.IsCA)
+	})
+
+	t.Run("when the chain is generated it should sign the server and client certificates with the CA", func(t *testing.T) {
+		t.Parallel()
+		chain := certs.Generate(t)
+		_, err := chain.Server.Certificate.Verify(x509.VerifyOptions{
+			DNSName:   "localhost",
+			Roots:     chain.CertPool(),
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		assert.NoError(t, err)
+		_, err = chain.Client.Certificate.Verify(x509.VerifyOptions{
+			Roots:     chain.CertPool(),
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, chain.Server.Certificate.VerifyHostname("127.0.0.1"))
+	})
+
+	t.Run("when options are provided it should use them for the server certificate", func(t *testing.T) {
+		t.Parallel()
+		chain := certs.Generate(t,
+			certs.WithValidity(time.Hour),
+			certs.WithDNSNames("example.com"),
+			certs.WithIPAddresses(net.ParseIP("10.0.0.1")),
+		)
+		assert.NoError(t, chain.Server.Certificate.VerifyHostname("example.com"))
+		assert.NoError(t, chain.Server.Certificate.VerifyHostname("10.0.0.1"))
+		assert.Error(t, chain.Server.Certificate.VerifyHostname("localhost"))
+		validity := chain.Server.Certificate.NotAfter.Sub(chain.Server.Certificate.NotBefore)
+		assert.Equals(t, validity, time.Hour)
+	})
+
+	t.Run("when a file is written to the chain directory it should return its path", func(t *testing.T) {
+		t.Parallel()
+		chain := certs.Generate(t)
+		path := chain.WriteFile(t, "invalid.pem", []byte("invalid data"))
+		assert.Equals(t, path, chain.Path("invalid.pem"))
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), "invalid data")
+	})
+
+	t.Run("when the TLS configurations are used it should perform a mutual TLS handshake", func(t *testing.T) {
+		t.Parallel()
+		chain := certs.Generate(t)
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		srv.TLS = chain.ServerTLSConfig()
+		srv.StartTLS()
+		defer srv.Close()
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: chain.ClientTLSConfig()}}
+		response, err := client.Get(srv.URL)
+		assert.NoError(t, err)
+		assert.Equals(t, response.StatusCode, http.StatusNoContent)
+		assert.NoError(t, response.Body.Close())
+
+		otherChain := certs.Generate(t)
+		untrustedClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      chain.CertPool(),
+			Certificates: []tls.Certificate{otherChain.Client.TLSCertificate()},
+			MinVersion:   tls.VersionTLS12,
+		}}}
+		response, err = untrustedClient.Get(srv.URL)
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
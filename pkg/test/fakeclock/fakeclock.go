@@ -0,0 +1,25 @@
+package fakeclock
+
+import (
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// Clock is a controllable timestamp.Clock for tests. Its time only moves with Advance or Set,
+// and BlockUntil waits for routines to be waiting on it before the time is moved.
+// It is the FakeClock of the timestamp package so it can be passed anywhere a timestamp.Clock is taken.
+type Clock = timestamp.FakeClock
+
+// start is the time at which the clocks created with New start.
+var start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// New returns a Clock that starts at midnight UTC on January 1, 2024.
+func New() *Clock {
+	return timestamp.NewFakeClock(start)
+}
+
+// NewAt returns a Clock that starts at the given time.
+func NewAt(t time.Time) *Clock {
+	return timestamp.NewFakeClock(t)
+}
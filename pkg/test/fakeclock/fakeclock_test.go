@@ -0,0 +1,42 @@
+package fakeclock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/fakeclock"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+// Ensure the Clock implements timestamp.Clock.
+var _ timestamp.Clock = fakeclock.New()
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when New is called it should start at the beginning of 2024", func(t *testing.T) {
+		t.Parallel()
+		clock := fakeclock.New()
+		assert.Equals(t, clock.Now(), time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("when NewAt is called it should start at the given time", func(t *testing.T) {
+		t.Parallel()
+		start := time.Date(2000, time.June, 15, 12, 0, 0, 0, time.UTC)
+		clock := fakeclock.NewAt(start)
+		assert.Equals(t, clock.Now(), start)
+	})
+
+	t.Run("when a routine waits on the clock it should wake up once the clock is advanced", func(t *testing.T) {
+		t.Parallel()
+		clock := fakeclock.New()
+		fired := make(chan time.Time)
+		go func() {
+			fired <- <-clock.After(time.Hour)
+		}()
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+		assert.Equals(t, <-fired, fakeclock.New().Now().Add(time.Hour))
+	})
+}
@@ -0,0 +1,232 @@
+package httpassert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	"github.com/TriangleSide/GoTools/pkg/http/api"
+	"github.com/TriangleSide/GoTools/pkg/http/headers"
+	"github.com/TriangleSide/GoTools/pkg/http/middleware"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// Client performs HTTP requests in tests and returns a Response to make assertions on.
+type Client struct {
+	t       assert.Testing
+	baseURL string
+	do      func(request *http.Request) (*http.Response, error)
+}
+
+// NewHandlerClient returns a Client that sends the requests directly to the handler, without a network connection.
+func NewHandlerClient(t assert.Testing, handler http.Handler) *Client {
+	return &Client{
+		t:       t,
+		baseURL: "",
+		do: func(request *http.Request) (*http.Response, error) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder.Result(), nil
+		},
+	}
+}
+
+// NewEndpointClient returns a Client that routes the requests to the endpoint handlers in the same way as the server.
+func NewEndpointClient(t assert.Testing, endpointHandlers []api.HTTPEndpointHandler, commonMiddleware ...middleware.Middleware) *Client {
+	builder := api.NewHTTPAPIBuilder()
+	for _, endpointHandler := range endpointHandlers {
+		endpointHandler.AcceptHTTPAPIBuilder(builder)
+	}
+	return NewHandlerClient(t, builder.ServeMux(commonMiddleware...))
+}
+
+// NewServerClient returns a Client that sends the requests to a running server at the base URL, like "http://127.0.0.1:8080".
+// If the HTTP client is nil, the http.DefaultClient is used.
+func NewServerClient(t assert.Testing, baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		t:       t,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		do:      httpClient.Do,
+	}
+}
+
+// RequestOption modifies a request before it is sent.
+type RequestOption func(request *http.Request)
+
+// WithHeader sets a header on the request.
+func WithHeader(key string, value string) RequestOption {
+	return func(request *http.Request) {
+		request.Header.Set(key, value)
+	}
+}
+
+// Do sends a request and reads the whole response. A body that is a string, []byte, or io.Reader is sent as is.
+// Any other non-nil body is encoded as JSON and the content type is set accordingly.
+func (c *Client) Do(method string, path string, body any, opts ...RequestOption) *Response {
+	c.t.Helper()
+
+	var bodyReader io.Reader
+	isJSON := false
+	switch typedBody := body.(type) {
+	case nil:
+	case string:
+		bodyReader = strings.NewReader(typedBody)
+	case []byte:
+		bodyReader = bytes.NewReader(typedBody)
+	case io.Reader:
+		bodyReader = typedBody
+	default:
+		encoded, err := json.Marshal(typedBody)
+		if err != nil {
+			c.t.Fatal(fmt.Sprintf("Failed to encode the request body as JSON (%s).", err))
+			return nil
+		}
+		bodyReader = bytes.NewReader(encoded)
+		isJSON = true
+	}
+
+	request, err := http.NewRequest(method, c.baseURL+path, bodyReader)
+	if err != nil {
+		c.t.Fatal(fmt.Sprintf("Failed to create the request (%s).", err))
+		return nil
+	}
+	if isJSON {
+		request.Header.Set(headers.ContentType, headers.ContentTypeApplicationJson)
+	}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	response, err := c.do(request)
+	if err != nil {
+		c.t.Fatal(fmt.Sprintf("Failed to perform the request (%s).", err))
+		return nil
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		c.t.Fatal(fmt.Sprintf("Failed to read the response body (%s).", err))
+		return nil
+	}
+	return &Response{
+		t:        c.t,
+		response: response,
+		body:     responseBody,
+	}
+}
+
+// Get sends a GET request.
+func (c *Client) Get(path string, opts ...RequestOption) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodGet, path, nil, opts...)
+}
+
+// Post sends a POST request with the body.
+func (c *Client) Post(path string, body any, opts ...RequestOption) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodPost, path, body, opts...)
+}
+
+// Put sends a PUT request with the body.
+func (c *Client) Put(path string, body any, opts ...RequestOption) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodPut, path, body, opts...)
+}
+
+// Patch sends a PATCH request with the body.
+func (c *Client) Patch(path string, body any, opts ...RequestOption) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodPatch, path, body, opts...)
+}
+
+// Delete sends a DELETE request.
+func (c *Client) Delete(path string, opts ...RequestOption) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodDelete, path, nil, opts...)
+}
+
+// Response is a completed response whose body was already read. Its assertions can be chained.
+type Response struct {
+	t        assert.Testing
+	response *http.Response
+	body     []byte
+}
+
+// Raw returns the http.Response. Its body is already read and closed.
+func (r *Response) Raw() *http.Response {
+	return r.response
+}
+
+// Body returns the contents of the response body.
+func (r *Response) Body() []byte {
+	return r.body
+}
+
+// Status checks the status code of the response. The body is included in the failure to help debugging.
+func (r *Response) Status(expected int) *Response {
+	r.t.Helper()
+	if r.response.StatusCode != expected {
+		r.t.Fatal(fmt.Sprintf("Expected the status code %d to equal %d. The body is '%s'.", r.response.StatusCode, expected, r.body))
+	}
+	return r
+}
+
+// Header checks the value of a response header.
+func (r *Response) Header(key string, expected string) *Response {
+	r.t.Helper()
+	if actual := r.response.Header.Get(key); actual != expected {
+		r.t.Fatal(fmt.Sprintf("Expected the header %s with value '%s' to equal '%s'.", key, actual, expected))
+	}
+	return r
+}
+
+// BodyEquals checks that the response body is exactly the expected string.
+func (r *Response) BodyEquals(expected string) *Response {
+	r.t.Helper()
+	if string(r.body) != expected {
+		r.t.Fatal(fmt.Sprintf("Expected the body '%s' to equal '%s'.", r.body, expected))
+	}
+	return r
+}
+
+// BodyContains checks that the response body contains the expected string.
+func (r *Response) BodyContains(expected string) *Response {
+	r.t.Helper()
+	if !strings.Contains(string(r.body), expected) {
+		r.t.Fatal(fmt.Sprintf("Expected the body '%s' to contain '%s'.", r.body, expected))
+	}
+	return r
+}
+
+// DecodeJSON decodes the response body into the target.
+func (r *Response) DecodeJSON(target any) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.body, target); err != nil {
+		r.t.Fatal(fmt.Sprintf("Failed to decode the body '%s' as JSON (%s).", r.body, err))
+	}
+	return r
+}
+
+// JSON checks that the response is JSON and that it decodes to a value equal to the expected value.
+// The body is decoded into a new value of the type of the expected value.
+func (r *Response) JSON(expected any) *Response {
+	r.t.Helper()
+	if contentType := r.response.Header.Get(headers.ContentType); !strings.HasPrefix(contentType, headers.ContentTypeApplicationJson) {
+		r.t.Fatal(fmt.Sprintf("Expected the content type '%s' to be JSON.", contentType))
+		return r
+	}
+	decoded := reflect.New(reflect.TypeOf(expected))
+	r.DecodeJSON(decoded.Interface())
+	assert.Equals(r.t, decoded.Elem().Interface(), expected)
+	return r
+}
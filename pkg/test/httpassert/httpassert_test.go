@@ -0,0 +1,167 @@
+package httpassert_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/http/api"
+	"github.com/TriangleSide/GoTools/pkg/http/headers"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/httpassert"
+)
+
+type failureRecorder struct {
+	name     string
+	failures []string
+}
+
+func (r *failureRecorder) Name() string {
+	return r.name
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Error(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func (r *failureRecorder) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+type echoHandler struct{}
+
+type echoBody struct {
+	Message string `json:"message"`
+}
+
+func (e *echoHandler) AcceptHTTPAPIBuilder(builder *api.HTTPAPIBuilder) {
+	builder.MustRegister("/echo", http.MethodPost, &api.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			body, _ := io.ReadAll(request.Body)
+			writer.Header().Set(headers.ContentType, request.Header.Get(headers.ContentType))
+			writer.Header().Set("X-Request-Header", request.Header.Get("X-Request-Header"))
+			writer.WriteHeader(http.StatusCreated)
+			_, _ = writer.Write(body)
+		},
+	})
+	builder.MustRegister("/text", http.MethodGet, &api.Handler{
+		Handler: func(writer http.ResponseWriter, request *http.Request) {
+			_, _ = io.WriteString(writer, "hello world")
+		},
+	})
+}
+
+func TestHTTPAssert(t *testing.T) {
+	t.Parallel()
+
+	newRecorder := func(t *testing.T) *failureRecorder {
+		return &failureRecorder{name: t.Name()}
+	}
+
+	t.Run("when a JSON body is posted to an endpoint it should pass the assertions on the response", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewEndpointClient(recorder, []api.HTTPEndpointHandler{&echoHandler{}})
+		var decoded echoBody
+		client.Post("/echo", echoBody{Message: "hi"}, httpassert.WithHeader("X-Request-Header", "value")).
+			Status(http.StatusCreated).
+			Header("X-Request-Header", "value").
+			JSON(echoBody{Message: "hi"}).
+			DecodeJSON(&decoded).
+			BodyContains(`"message"`)
+		assert.Equals(t, decoded, echoBody{Message: "hi"})
+		assert.Equals(t, len(recorder.failures), 0)
+	})
+
+	t.Run("when raw bodies are sent they should not be encoded", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewEndpointClient(recorder, []api.HTTPEndpointHandler{&echoHandler{}})
+		client.Post("/echo", "text").BodyEquals("text").Header(headers.ContentType, "")
+		client.Post("/echo", []byte("bytes")).BodyEquals("bytes")
+		response := client.Post("/echo", strings.NewReader("reader")).BodyEquals("reader")
+		assert.Equals(t, response.Body(), []byte("reader"))
+		assert.Equals(t, response.Raw().StatusCode, http.StatusCreated)
+		assert.Equals(t, len(recorder.failures), 0)
+	})
+
+	t.Run("when the common middleware is given it should run before the endpoint", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewEndpointClient(recorder, []api.HTTPEndpointHandler{&echoHandler{}}, func(next http.HandlerFunc) http.HandlerFunc {
+			return func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusTeapot)
+			}
+		})
+		client.Get("/text").Status(http.StatusTeapot)
+		assert.Equals(t, len(recorder.failures), 0)
+	})
+
+	t.Run("when the methods are used they should send the matching request method", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewHandlerClient(recorder, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			_, _ = io.WriteString(writer, request.Method)
+		}))
+		client.Get("/").BodyEquals(http.MethodGet)
+		client.Post("/", nil).BodyEquals(http.MethodPost)
+		client.Put("/", nil).BodyEquals(http.MethodPut)
+		client.Patch("/", nil).BodyEquals(http.MethodPatch)
+		client.Delete("/").BodyEquals(http.MethodDelete)
+		client.Do(http.MethodOptions, "/", nil).BodyEquals(http.MethodOptions)
+		assert.Equals(t, len(recorder.failures), 0)
+	})
+
+	t.Run("when a server is running it should send the requests over the network", func(t *testing.T) {
+		t.Parallel()
+		builder := api.NewHTTPAPIBuilder()
+		(&echoHandler{}).AcceptHTTPAPIBuilder(builder)
+		srv := httptest.NewServer(builder.ServeMux())
+		defer srv.Close()
+		recorder := newRecorder(t)
+		client := httpassert.NewServerClient(recorder, srv.URL+"/", nil)
+		client.Get("/text").Status(http.StatusOK).BodyEquals("hello world")
+		client.Get("/missing").Status(http.StatusNotFound)
+		assert.Equals(t, len(recorder.failures), 0)
+	})
+
+	t.Run("when the assertions fail they should describe the response", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewEndpointClient(recorder, []api.HTTPEndpointHandler{&echoHandler{}})
+		client.Get("/text").
+			Status(http.StatusNotFound).
+			Header("X-Missing", "value").
+			BodyEquals("goodbye").
+			BodyContains("goodbye").
+			JSON(echoBody{})
+		client.Post("/echo", echoBody{Message: "hi"}).JSON(echoBody{Message: "bye"})
+		client.Post("/echo", "not json").DecodeJSON(&echoBody{})
+		assert.Equals(t, recorder.failures[:5], []string{
+			"Expected the status code 200 to equal 404. The body is 'hello world'.",
+			"Expected the header X-Missing with value '' to equal 'value'.",
+			"Expected the body 'hello world' to equal 'goodbye'.",
+			"Expected the body 'hello world' to contain 'goodbye'.",
+			"Expected the content type 'text/plain; charset=utf-8' to be JSON.",
+		})
+		assert.Contains(t, recorder.failures[5], "Expected {Message:hi} to equal {Message:bye}.")
+		assert.Contains(t, recorder.failures[6], "Failed to decode the body 'not json' as JSON")
+	})
+
+	t.Run("when the request cannot be made it should fail", func(t *testing.T) {
+		t.Parallel()
+		recorder := newRecorder(t)
+		client := httpassert.NewServerClient(recorder, "http://127.0.0.1:0", nil)
+		assert.Nil(t, client.Post("/", make(chan int)))
+		assert.Nil(t, client.Get("/\x00"))
+		assert.Nil(t, client.Get("/"))
+		assert.Contains(t, recorder.failures[0], "Failed to encode the request body as JSON")
+		assert.Contains(t, recorder.failures[1], "Failed to create the request")
+		assert.Contains(t, recorder.failures[2], "Failed to perform the request")
+	})
+}
@@ -0,0 +1,162 @@
+package logcapture
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/TriangleSide/GoTools/pkg/logger"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// Testing is the subset of testing.T needed to capture the logs for the duration of a test.
+type Testing interface {
+	assert.Testing
+	Cleanup(func())
+}
+
+// Capture holds the log records and output written by the application logger during a test.
+type Capture struct {
+	t       Testing
+	lock    sync.Mutex
+	records []logger.Record
+	output  bytes.Buffer
+}
+
+// Start redirects the output of the application logger to a buffer and records each formatted entry.
+// The log level is set to trace so every entry is captured. The previous output, formatter, and log
+// level are restored when the test is cleaned up. Since the logger is global, the test must not run in parallel.
+func Start(t Testing) *Capture {
+	t.Helper()
+
+	previousOutput := logger.GetOutput()
+	previousFormatter := logger.GetFormatter()
+	previousLevel := logger.GetLevel()
+
+	capture := &Capture{
+		t: t,
+	}
+	logger.SetFormatter(func(level logger.LogLevel, fields map[string]any, msg string) string {
+		capture.record(level, fields, msg)
+		return previousFormatter(level, fields, msg)
+	})
+	logger.SetLevel(logger.LevelTrace)
+	logger.SetOutput(capture)
+
+	t.Cleanup(func() {
+		logger.SetOutput(previousOutput)
+		logger.SetLevel(previousLevel)
+		logger.SetFormatter(previousFormatter)
+	})
+
+	return capture
+}
+
+// record stores a copy of the entry that is being formatted.
+func (c *Capture) record(level logger.LogLevel, fields map[string]any, msg string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	fieldsCopy := make(map[string]any, len(fields))
+	maps.Copy(fieldsCopy, fields)
+	c.records = append(c.records, logger.Record{
+		Level:   level,
+		Fields:  fieldsCopy,
+		Message: msg,
+	})
+}
+
+// Write appends the formatted output of the logger to the buffer.
+func (c *Capture) Write(data []byte) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.output.Write(data)
+}
+
+// Records returns the captured records in the order they were logged.
+func (c *Capture) Records() []logger.Record {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	records := make([]logger.Record, len(c.records))
+	copy(records, c.records)
+	return records
+}
+
+// Output returns the formatted output written by the logger.
+func (c *Capture) Output() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.output.String()
+}
+
+// Reset removes the captured records and output.
+func (c *Capture) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.records = nil
+	c.output.Reset()
+}
+
+// Find returns the records at the level whose message contains the part and whose fields contain the
+// expected fields. The field values are compared with reflect.DeepEqual.
+func (c *Capture) Find(level logger.LogLevel, part string, fields map[string]any) []logger.Record {
+	found := make([]logger.Record, 0)
+	for _, record := range c.Records() {
+		if record.Level != level || !strings.Contains(record.Message, part) {
+			continue
+		}
+		if !containsFields(record.Fields, fields) {
+			continue
+		}
+		found = append(found, record)
+	}
+	return found
+}
+
+// containsFields checks if the expected fields are present with equal values.
+func containsFields(actual map[string]any, expected map[string]any) bool {
+	for key, expectedValue := range expected {
+		actualValue, ok := actual[key]
+		if !ok || !reflect.DeepEqual(actualValue, expectedValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertLogged checks that a record at the level was logged with a message containing the part
+// and with the fields. The fields can be nil if they should not be checked.
+func (c *Capture) AssertLogged(level logger.LogLevel, part string, fields map[string]any) {
+	c.t.Helper()
+	if len(c.Find(level, part, fields)) == 0 {
+		c.t.Fatal(fmt.Sprintf("Expected a log at level %s containing '%s' with the fields %v. The logs are %s.", level, part, fields, c.describe()))
+	}
+}
+
+// AssertNotLogged checks that no record at the level was logged with a message containing the part.
+func (c *Capture) AssertNotLogged(level logger.LogLevel, part string) {
+	c.t.Helper()
+	if len(c.Find(level, part, nil)) != 0 {
+		c.t.Fatal(fmt.Sprintf("Expected no log at level %s containing '%s'. The logs are %s.", level, part, c.describe()))
+	}
+}
+
+// AssertCount checks the number of records logged at the level.
+func (c *Capture) AssertCount(level logger.LogLevel, expected int) {
+	c.t.Helper()
+	if actual := len(c.Find(level, "", nil)); actual != expected {
+		c.t.Fatal(fmt.Sprintf("Expected %d log(s) at level %s but got %d. The logs are %s.", expected, level, actual, c.describe()))
+	}
+}
+
+// describe formats the captured records for failure messages.
+func (c *Capture) describe() string {
+	records := c.Records()
+	descriptions := make([]string, 0, len(records))
+	for _, record := range records {
+		descriptions = append(descriptions, fmt.Sprintf("%s '%s' %v", record.Level, record.Message, record.Fields))
+	}
+	return "[" + strings.Join(descriptions, ", ") + "]"
+}
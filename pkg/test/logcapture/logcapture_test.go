@@ -0,0 +1,101 @@
+package logcapture_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/logger"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/logcapture"
+)
+
+type failureRecorder struct {
+	*testing.T
+	failures []string
+}
+
+func (r *failureRecorder) Error(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func (r *failureRecorder) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func TestCapture(t *testing.T) {
+	t.Run("when logs are written it should capture the records and the output", func(t *testing.T) {
+		capture := logcapture.Start(t)
+		ctx := context.Background()
+		logger.AddField(&ctx, "user", "alice").Info("user logged in")
+		logger.Named("worker").Debugf("processed %d jobs", 3)
+		logger.Warn("disk almost full")
+
+		records := capture.Records()
+		assert.Equals(t, len(records), 3)
+		assert.Equals(t, records[0], logger.Record{
+			Level:   logger.LevelInfo,
+			Fields:  map[string]any{"user": "alice"},
+			Message: "user logged in",
+		})
+		assert.Equals(t, records[1].Level, logger.LevelDebug)
+		assert.Equals(t, records[1].Fields, map[string]any{logger.NameField: "worker"})
+		assert.Contains(t, capture.Output(), "user=alice user logged in")
+		assert.Contains(t, capture.Output(), "disk almost full")
+
+		capture.AssertLogged(logger.LevelInfo, "logged in", map[string]any{"user": "alice"})
+		capture.AssertLogged(logger.LevelDebug, "processed 3 jobs", nil)
+		capture.AssertNotLogged(logger.LevelError, "disk")
+		capture.AssertCount(logger.LevelWarn, 1)
+	})
+
+	t.Run("when the capture is reset it should remove the records and the output", func(t *testing.T) {
+		capture := logcapture.Start(t)
+		logger.Info("message")
+		capture.Reset()
+		assert.Equals(t, len(capture.Records()), 0)
+		assert.Equals(t, capture.Output(), "")
+	})
+
+	t.Run("when the test is cleaned up it should restore the logger", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		previousOutput := logger.GetOutput()
+		logger.SetOutput(buffer)
+		logger.SetLevel(logger.LevelError)
+		t.Cleanup(func() {
+			logger.SetOutput(previousOutput)
+			logger.SetLevel(logger.LevelInfo)
+		})
+
+		t.Run("capture", func(t *testing.T) {
+			logcapture.Start(t)
+			assert.Equals(t, logger.GetLevel(), logger.LevelTrace)
+			logger.Error("captured message")
+		})
+
+		assert.Equals(t, logger.GetOutput(), io.Writer(buffer))
+		assert.Equals(t, logger.GetLevel(), logger.LevelError)
+		logger.Error("restored message")
+		assert.Contains(t, buffer.String(), "restored message")
+		assert.False(t, bytes.Contains(buffer.Bytes(), []byte("captured message")))
+	})
+
+	t.Run("when the assertions fail it should describe the captured logs", func(t *testing.T) {
+		recorder := &failureRecorder{T: t}
+		capture := logcapture.Start(recorder)
+		logger.Named("db").Error("connection lost")
+		capture.AssertLogged(logger.LevelError, "connection lost", map[string]any{logger.NameField: "http"})
+		capture.AssertLogged(logger.LevelWarn, "connection lost", nil)
+		capture.AssertNotLogged(logger.LevelError, "lost")
+		capture.AssertCount(logger.LevelError, 2)
+		logs := "[ERROR 'connection lost' map[logger:db]]"
+		assert.Equals(t, recorder.failures, []string{
+			"Expected a log at level ERROR containing 'connection lost' with the fields map[logger:http]. The logs are " + logs + ".",
+			"Expected a log at level WARN containing 'connection lost' with the fields map[]. The logs are " + logs + ".",
+			"Expected no log at level ERROR containing 'lost'. The logs are " + logs + ".",
+			"Expected 2 log(s) at level ERROR but got 1. The logs are " + logs + ".",
+		})
+	})
+}
@@ -0,0 +1,331 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+)
+
+// Call is an invocation of a mocked method.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// String returns the call formatted like Method(arg1, arg2).
+func (c Call) String() string {
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, fmt.Sprintf("%+v", arg))
+	}
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(args, ", "))
+}
+
+// Arg returns the argument at the index of the call converted to T.
+// The zero value of T is returned if the index is out of range or the argument is not a T.
+func Arg[T any](call Call, index int) T {
+	var zero T
+	if index < 0 || index >= len(call.Args) {
+		return zero
+	}
+	value, ok := call.Args[index].(T)
+	if !ok {
+		return zero
+	}
+	return value
+}
+
+// Returns are the values a stub returns for a call.
+type Returns []any
+
+// Get returns the value at the index, or nil if the index is out of range.
+func (r Returns) Get(index int) any {
+	if index < 0 || index >= len(r) {
+		return nil
+	}
+	return r[index]
+}
+
+// Error returns the value at the index as an error, or nil if it is not an error.
+func (r Returns) Error(index int) error {
+	err, _ := r.Get(index).(error)
+	return err
+}
+
+// Value returns the value at the index of the returns converted to T.
+// The zero value of T is returned if the index is out of range or the value is not a T.
+func Value[T any](returns Returns, index int) T {
+	value, _ := returns.Get(index).(T)
+	return value
+}
+
+// anything is the type of the Anything matcher.
+type anything struct{}
+
+// Anything matches any argument when passed to On.
+var Anything = anything{}
+
+// String returns the name of the matcher.
+func (anything) String() string {
+	return "mock.Anything"
+}
+
+// argumentMatcher matches an argument using a custom function.
+type argumentMatcher struct {
+	matches func(arg any) bool
+}
+
+// String returns the name of the matcher.
+func (argumentMatcher) String() string {
+	return "mock.MatchedBy"
+}
+
+// MatchedBy matches arguments of type T for which the function returns true.
+func MatchedBy[T any](fn func(arg T) bool) any {
+	return argumentMatcher{
+		matches: func(arg any) bool {
+			typedArg, ok := arg.(T)
+			if !ok {
+				if arg != nil {
+					return false
+				}
+				var zero T
+				if reflect.TypeOf(&zero).Elem().Kind() != reflect.Interface {
+					return false
+				}
+			}
+			return fn(typedArg)
+		},
+	}
+}
+
+// argumentsMatch checks if the arguments of a call satisfy the expected arguments.
+func argumentsMatch(expected []any, actual []any) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		switch matcher := expected[i].(type) {
+		case anything:
+			continue
+		case argumentMatcher:
+			if !matcher.matches(actual[i]) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(expected[i], actual[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Stub configures how a mocked method responds to matching calls.
+// Its setters can be called while the Mock is in use since they take the lock of the Mock.
+type Stub struct {
+	lock     *sync.Mutex
+	method   string
+	args     []any
+	returns  Returns
+	run      func(args []any)
+	times    int
+	optional bool
+	calls    int
+}
+
+// Return sets the values returned to matching calls.
+func (s *Stub) Return(values ...any) *Stub {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.returns = values
+	return s
+}
+
+// Run sets a function that is invoked with the arguments of each matching call.
+func (s *Stub) Run(fn func(args []any)) *Stub {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.run = fn
+	return s
+}
+
+// Times limits the stub to n matching calls and expects it to be called exactly n times.
+func (s *Stub) Times(n int) *Stub {
+	if n <= 0 {
+		panic("The number of times must be greater than zero.")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.times = n
+	return s
+}
+
+// Once is shorthand for Times(1).
+func (s *Stub) Once() *Stub {
+	return s.Times(1)
+}
+
+// Maybe marks the stub as not required to be called when verifying expectations.
+func (s *Stub) Maybe() *Stub {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.optional = true
+	return s
+}
+
+// String returns the stub formatted like Method(arg1, arg2).
+func (s *Stub) String() string {
+	return Call{Method: s.method, Args: s.args}.String()
+}
+
+// Mock records calls and returns stubbed values. It is meant to be embedded in hand-written fakes
+// of interfaces, whose methods forward their arguments to Called. It is safe for concurrent use.
+type Mock struct {
+	lock       sync.Mutex
+	calls      []Call
+	stubs      []*Stub
+	unexpected []Call
+}
+
+// On adds a stub for calls to the method with matching arguments.
+// Arguments are compared with reflect.DeepEqual unless Anything or MatchedBy is used.
+func (m *Mock) On(method string, args ...any) *Stub {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	stub := &Stub{
+		lock:   &m.lock,
+		method: method,
+		args:   args,
+	}
+	m.stubs = append(m.stubs, stub)
+	return stub
+}
+
+// Called records a call to the method and returns the values of the first matching stub that is not exhausted.
+// If no stub matches, the call is still recorded and no values are returned, and AssertExpectations fails.
+func (m *Mock) Called(method string, args ...any) Returns {
+	m.lock.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	var matched *Stub
+	for _, stub := range m.stubs {
+		if stub.method != method || !argumentsMatch(stub.args, args) {
+			continue
+		}
+		if stub.times > 0 && stub.calls >= stub.times {
+			continue
+		}
+		matched = stub
+		break
+	}
+	if matched == nil {
+		m.unexpected = append(m.unexpected, Call{Method: method, Args: args})
+		m.lock.Unlock()
+		return nil
+	}
+	matched.calls++
+	returns := matched.returns
+	run := matched.run
+	m.lock.Unlock()
+
+	if run != nil {
+		run(args)
+	}
+	return returns
+}
+
+// Calls returns all the recorded calls in the order they were made.
+func (m *Mock) Calls() []Call {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	calls := make([]Call, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// CallsTo returns the recorded calls to the method in the order they were made.
+func (m *Mock) CallsTo(method string) []Call {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	calls := make([]Call, 0)
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all the recorded calls and stubs.
+func (m *Mock) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.calls = nil
+	m.stubs = nil
+	m.unexpected = nil
+}
+
+// AssertExpectations checks that every stub that is not optional was called, that stubs
+// limited with Times were called exactly that many times, and that every call matched a stub.
+func (m *Mock) AssertExpectations(t assert.Testing) {
+	t.Helper()
+	m.lock.Lock()
+	failures := make([]string, 0, 2)
+	unmet := make([]string, 0)
+	for _, stub := range m.stubs {
+		switch {
+		case stub.times > 0 && stub.calls != stub.times:
+			unmet = append(unmet, fmt.Sprintf("%s was called %d time(s) but expected %d", stub, stub.calls, stub.times))
+		case stub.times == 0 && !stub.optional && stub.calls == 0:
+			unmet = append(unmet, fmt.Sprintf("%s was not called", stub))
+		}
+	}
+	if len(unmet) > 0 {
+		failures = append(failures, fmt.Sprintf("Expected the stubs to be called: %s.", strings.Join(unmet, "; ")))
+	}
+	if len(m.unexpected) > 0 {
+		failures = append(failures, fmt.Sprintf("Expected the calls to match a stub: %v.", m.unexpected))
+	}
+	m.lock.Unlock()
+	if len(failures) > 0 {
+		t.Fatal(strings.Join(failures, " "))
+	}
+}
+
+// AssertCalled checks that the method was called at least once with matching arguments.
+func (m *Mock) AssertCalled(t assert.Testing, method string, args ...any) {
+	t.Helper()
+	if m.countMatching(method, args) == 0 {
+		t.Fatal(fmt.Sprintf("Expected %s to be called. The calls are %v.", Call{Method: method, Args: args}, m.Calls()))
+	}
+}
+
+// AssertNotCalled checks that the method was never called with matching arguments.
+func (m *Mock) AssertNotCalled(t assert.Testing, method string, args ...any) {
+	t.Helper()
+	if m.countMatching(method, args) != 0 {
+		t.Fatal(fmt.Sprintf("Expected %s to not be called.", Call{Method: method, Args: args}))
+	}
+}
+
+// AssertNumberOfCalls checks that the method was called exactly n times with any arguments.
+func (m *Mock) AssertNumberOfCalls(t assert.Testing, method string, n int) {
+	t.Helper()
+	if actual := len(m.CallsTo(method)); actual != n {
+		t.Fatal(fmt.Sprintf("Expected %s to be called %d time(s) but it was called %d time(s).", method, n, actual))
+	}
+}
+
+// countMatching returns the number of recorded calls to the method with matching arguments.
+func (m *Mock) countMatching(method string, args []any) int {
+	count := 0
+	for _, call := range m.CallsTo(method) {
+		if argumentsMatch(args, call.Args) {
+			count++
+		}
+	}
+	return count
+}
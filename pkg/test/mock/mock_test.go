@@ -0,0 +1,287 @@
+package mock_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/test/mock"
+)
+
+type failureRecorder struct {
+	name     string
+	failures []string
+}
+
+func (r *failureRecorder) Name() string {
+	return r.name
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Error(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func (r *failureRecorder) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+type store interface {
+	Get(key string) (string, error)
+	Put(key string, value string) error
+}
+
+type storeMock struct {
+	mock.Mock
+}
+
+func (s *storeMock) Get(key string) (string, error) {
+	returns := s.Called("Get", key)
+	return mock.Value[string](returns, 0), returns.Error(1)
+}
+
+func (s *storeMock) Put(key string, value string) error {
+	return s.Called("Put", key, value).Error(0)
+}
+
+var _ store = (*storeMock)(nil)
+
+func TestMock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when a method is stubbed it should return the stubbed values for matching arguments", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		m.On("Get", "key").Return("value", nil)
+		m.On("Get", "missing").Return("", errors.New("not found"))
+		value, err := m.Get("key")
+		assert.NoError(t, err)
+		assert.Equals(t, value, "value")
+		value, err = m.Get("missing")
+		assert.ErrorExact(t, err, "not found")
+		assert.Equals(t, value, "")
+	})
+
+	t.Run("when no stub matches it should record the call and return zero values", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		value, err := m.Get("key")
+		assert.NoError(t, err)
+		assert.Equals(t, value, "")
+		assert.Equals(t, m.Calls(), []mock.Call{{Method: "Get", Args: []any{"key"}}})
+	})
+
+	t.Run("when the Anything and MatchedBy matchers are used it should match the arguments", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		m.On("Put", mock.Anything, mock.MatchedBy(func(value string) bool {
+			return len(value) > 3
+		})).Return(errors.New("too long"))
+		assert.NoError(t, m.Put("key", "abc"))
+		assert.ErrorExact(t, m.Put("key", "abcd"), "too long")
+		assert.ErrorExact(t, m.Put("other", "abcde"), "too long")
+	})
+
+	t.Run("when MatchedBy receives an argument of another type it should not match", func(t *testing.T) {
+		t.Parallel()
+		m := &mock.Mock{}
+		m.On("Method", mock.MatchedBy(func(value int) bool { return true })).Return(true)
+		assert.Nil(t, m.Called("Method", "string").Get(0))
+		assert.Nil(t, m.Called("Method", nil).Get(0))
+		assert.Equals(t, m.Called("Method", 1).Get(0), true)
+	})
+
+	t.Run("when MatchedBy is used with an interface type it should match nil arguments", func(t *testing.T) {
+		t.Parallel()
+		m := &mock.Mock{}
+		m.On("Method", mock.MatchedBy(func(err error) bool { return err == nil })).Return(true)
+		assert.Equals(t, m.Called("Method", nil).Get(0), true)
+	})
+
+	t.Run("when a stub is limited with Times it should fall through to the next stub once exhausted", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		m.On("Get", "key").Return("first", nil).Once()
+		m.On("Get", "key").Return("second", nil)
+		value, _ := m.Get("key")
+		assert.Equals(t, value, "first")
+		value, _ = m.Get("key")
+		assert.Equals(t, value, "second")
+		value, _ = m.Get("key")
+		assert.Equals(t, value, "second")
+	})
+
+	t.Run("when Times is not positive it should panic", func(t *testing.T) {
+		t.Parallel()
+		m := &mock.Mock{}
+		assert.PanicExact(t, func() {
+			m.On("Method").Times(0)
+		}, "The number of times must be greater than zero.")
+	})
+
+	t.Run("when a stub has a run function it should be called with the arguments", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		captured := make([]string, 0)
+		m.On("Put", mock.Anything, mock.Anything).Run(func(args []any) {
+			captured = append(captured, args[0].(string)+"="+args[1].(string))
+		})
+		assert.NoError(t, m.Put("a", "1"))
+		assert.NoError(t, m.Put("b", "2"))
+		assert.Equals(t, captured, []string{"a=1", "b=2"})
+	})
+
+	t.Run("when arguments are captured from the calls it should convert them to the requested type", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		assert.NoError(t, m.Put("key", "value"))
+		calls := m.CallsTo("Put")
+		assert.Equals(t, len(calls), 1)
+		assert.Equals(t, mock.Arg[string](calls[0], 1), "value")
+		assert.Equals(t, mock.Arg[int](calls[0], 1), 0)
+		assert.Equals(t, mock.Arg[string](calls[0], 2), "")
+		assert.Equals(t, calls[0].String(), "Put(key, value)")
+	})
+
+	t.Run("when the returns are accessed out of range it should return zero values", func(t *testing.T) {
+		t.Parallel()
+		returns := mock.Returns{1, "text"}
+		assert.Nil(t, returns.Get(-1))
+		assert.Nil(t, returns.Get(2))
+		assert.Nil(t, returns.Error(1))
+		assert.Equals(t, mock.Value[int](returns, 0), 1)
+		assert.Equals(t, mock.Value[int](returns, 1), 0)
+	})
+
+	t.Run("when the expectations are met it should not fail", func(t *testing.T) {
+		t.Parallel()
+		recorder := &failureRecorder{name: t.Name()}
+		m := &storeMock{}
+		m.On("Get", "key").Return("value", nil).Times(2)
+		m.On("Put", mock.Anything, mock.Anything)
+		m.On("Get", "optional").Maybe()
+		_, _ = m.Get("key")
+		_, _ = m.Get("key")
+		_ = m.Put("key", "value")
+		m.AssertExpectations(recorder)
+		m.AssertCalled(recorder, "Get", "key")
+		m.AssertCalled(recorder, "Put", "key", mock.Anything)
+		m.AssertNotCalled(recorder, "Get", "optional")
+		m.AssertNumberOfCalls(recorder, "Get", 2)
+		assert.Equals(t, recorder.failures, []string(nil))
+	})
+
+	t.Run("when the expectations are not met it should list the unmet stubs", func(t *testing.T) {
+		t.Parallel()
+		recorder := &failureRecorder{name: t.Name()}
+		m := &storeMock{}
+		m.On("Get", "key").Return("value", nil).Times(2)
+		m.On("Put", "key", "value")
+		_, _ = m.Get("key")
+		m.AssertExpectations(recorder)
+		assert.Equals(t, recorder.failures, []string{
+			"Expected the stubs to be called: Get(key) was called 1 time(s) but expected 2; Put(key, value) was not called.",
+		})
+	})
+
+	t.Run("when a call does not match a stub it should fail the expectations", func(t *testing.T) {
+		t.Parallel()
+		recorder := &failureRecorder{name: t.Name()}
+		m := &storeMock{}
+		m.On("Get", "key").Return("value", nil).Once()
+		_, _ = m.Get("key")
+		_, _ = m.Get("key")
+		_ = m.Put("key", "value")
+		m.AssertExpectations(recorder)
+		assert.Equals(t, recorder.failures, []string{
+			"Expected the calls to match a stub: [Get(key) Put(key, value)].",
+		})
+	})
+
+	t.Run("when the expectations are not met and a call does not match it should list both", func(t *testing.T) {
+		t.Parallel()
+		recorder := &failureRecorder{name: t.Name()}
+		m := &storeMock{}
+		m.On("Put", "key", "value")
+		_, _ = m.Get("key")
+		m.AssertExpectations(recorder)
+		assert.Equals(t, recorder.failures, []string{
+			"Expected the stubs to be called: Put(key, value) was not called. Expected the calls to match a stub: [Get(key)].",
+		})
+	})
+
+	t.Run("when the call assertions fail it should describe the calls", func(t *testing.T) {
+		t.Parallel()
+		recorder := &failureRecorder{name: t.Name()}
+		m := &storeMock{}
+		_, _ = m.Get("key")
+		m.AssertCalled(recorder, "Get", "other")
+		m.AssertNotCalled(recorder, "Get", mock.Anything)
+		m.AssertNumberOfCalls(recorder, "Put", 1)
+		assert.Equals(t, recorder.failures, []string{
+			"Expected Get(other) to be called. The calls are [Get(key)].",
+			"Expected Get(mock.Anything) to not be called.",
+			"Expected Put to be called 1 time(s) but it was called 0 time(s).",
+		})
+	})
+
+	t.Run("when the mock is reset it should remove the calls and stubs", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		m.On("Get", "key").Return("value", nil)
+		_, _ = m.Get("key")
+		_, _ = m.Get("other")
+		m.Reset()
+		assert.Equals(t, len(m.Calls()), 0)
+		recorder := &failureRecorder{name: t.Name()}
+		m.AssertExpectations(recorder)
+		assert.Equals(t, recorder.failures, []string(nil))
+		value, _ := m.Get("key")
+		assert.Equals(t, value, "")
+	})
+
+	t.Run("when the mock is called concurrently it should record every call", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		m.On("Put", mock.Anything, mock.Anything).Return(nil)
+		const routineCount = 8
+		const callsPerRoutine = 100
+		wg := sync.WaitGroup{}
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < callsPerRoutine; k++ {
+					_ = m.Put("key", "value")
+				}
+			}()
+		}
+		wg.Wait()
+		m.AssertNumberOfCalls(t, "Put", routineCount*callsPerRoutine)
+	})
+	t.Run("when a stub is configured while the mock is called it should not race", func(t *testing.T) {
+		t.Parallel()
+		m := &storeMock{}
+		stub := m.On("Get", mock.Anything).Return("value", nil)
+		const routineCount = 4
+		const callsPerRoutine = 100
+		wg := sync.WaitGroup{}
+		for i := 0; i < routineCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := 0; k < callsPerRoutine; k++ {
+					_, _ = m.Get("key")
+				}
+			}()
+		}
+		for k := 0; k < callsPerRoutine; k++ {
+			stub.Return("value", nil).Run(func([]any) {}).Maybe()
+		}
+		wg.Wait()
+		m.AssertNumberOfCalls(t, "Get", routineCount*callsPerRoutine)
+	})
+}
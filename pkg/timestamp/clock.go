@@ -0,0 +1,121 @@
+package timestamp
+
+import (
+	"time"
+)
+
+// Clock tells the time and creates timers. Code that depends on a Clock instead of the time package
+// can be tested deterministically with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a Timer that sends the current time on its channel after the duration.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker creates a Ticker that sends the current time on its channel every period.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single event created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns false if the Timer already fired or was stopped.
+	Stop() bool
+
+	// Reset changes the Timer to expire after the duration. It returns true if the Timer was active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers ticks at intervals and is created by a Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the Ticker.
+	Stop()
+
+	// Reset stops the Ticker and resets its period to the duration.
+	Reset(d time.Duration)
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+// SystemClock returns a Clock that uses the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// Now returns the current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns the time elapsed since t.
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer creates a Timer that sends the current time on its channel after the duration.
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{timer: time.NewTimer(d)}
+}
+
+// NewTicker creates a Ticker that sends the current time on its channel every period.
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{ticker: time.NewTicker(d)}
+}
+
+// systemTimer is a Timer of the time package.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+// C returns the channel on which the time is delivered.
+func (t *systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+// Stop prevents the Timer from firing.
+func (t *systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Reset changes the Timer to expire after the duration.
+func (t *systemTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// systemTicker is a Ticker of the time package.
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+// C returns the channel on which the ticks are delivered.
+func (t *systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+// Stop turns off the Ticker.
+func (t *systemTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// Reset stops the Ticker and resets its period to the duration.
+func (t *systemTicker) Reset(d time.Duration) {
+	t.ticker.Reset(d)
+}
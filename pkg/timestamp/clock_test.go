@@ -0,0 +1,55 @@
+package timestamp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestSystemClock(t *testing.T) {
+	t.Parallel()
+
+	clock := timestamp.SystemClock()
+
+	t.Run("when the time is requested it should be the current time", func(t *testing.T) {
+		t.Parallel()
+		before := time.Now()
+		now := clock.Now()
+		assert.False(t, now.Before(before))
+		assert.True(t, clock.Since(before) >= 0)
+	})
+
+	t.Run("when waiting with After it should deliver the time", func(t *testing.T) {
+		t.Parallel()
+		start := time.Now()
+		fired := <-clock.After(time.Millisecond)
+		assert.False(t, fired.Before(start))
+	})
+
+	t.Run("when a timer expires it should deliver the time", func(t *testing.T) {
+		t.Parallel()
+		timer := clock.NewTimer(time.Millisecond)
+		<-timer.C()
+		assert.False(t, timer.Stop())
+		assert.False(t, timer.Reset(time.Millisecond))
+		<-timer.C()
+	})
+
+	t.Run("when a timer is stopped before it expires it should report that it was active", func(t *testing.T) {
+		t.Parallel()
+		timer := clock.NewTimer(time.Hour)
+		assert.True(t, timer.Reset(time.Hour))
+		assert.True(t, timer.Stop())
+	})
+
+	t.Run("when a ticker runs it should deliver ticks until it is stopped", func(t *testing.T) {
+		t.Parallel()
+		ticker := clock.NewTicker(time.Millisecond)
+		<-ticker.C()
+		ticker.Reset(time.Millisecond)
+		<-ticker.C()
+		ticker.Stop()
+	})
+}
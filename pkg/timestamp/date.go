@@ -0,0 +1,126 @@
+package timestamp
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar day without a time or a time zone.
+// It is encoded as an RFC 3339 full-date (YYYY-MM-DD) in JSON and text.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the Date of the year, month, and day. Values out of range are normalized,
+// so October 32 becomes November 1.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the Date of the time in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses an RFC 3339 full-date (YYYY-MM-DD).
+func ParseDate(s string) (Date, error) {
+	parsed, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("failed to parse the date (%w)", err)
+	}
+	return DateOf(parsed), nil
+}
+
+// IsZero returns true if the Date is the zero value.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid returns true if the Date is an existing calendar day.
+func (d Date) IsValid() bool {
+	return NewDate(d.Year, d.Month, d.Day) == d
+}
+
+// String returns the Date as an RFC 3339 full-date.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Time returns the start of the Date in the location.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the Date a number of days later. A negative number goes back in time.
+func (d Date) AddDays(days int) Date {
+	return NewDate(d.Year, d.Month, d.Day+days)
+}
+
+// AddDate returns the Date with the years, months, and days added, normalized like time.Time.AddDate.
+func (d Date) AddDate(years int, months int, days int) Date {
+	return NewDate(d.Year+years, d.Month+time.Month(months), d.Day+days)
+}
+
+// DaysSince returns the number of days from the other Date to this Date.
+func (d Date) DaysSince(other Date) int {
+	return int(d.unixDays() - other.unixDays())
+}
+
+// unixDays returns the number of days from January 1, 1970 to the Date.
+// It does not go through time.Duration, which saturates after about 292 years.
+func (d Date) unixDays() int64 {
+	const secondsPerDay = 24 * 60 * 60
+	return d.Time(time.UTC).Unix() / secondsPerDay
+}
+
+// Compare returns -1 if the Date is before the other, 0 if they are the same day, and 1 if it is after.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return cmp.Compare(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmp.Compare(int(d.Month), int(other.Month))
+	default:
+		return cmp.Compare(d.Day, other.Day)
+	}
+}
+
+// Before returns true if the Date is before the other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After returns true if the Date is after the other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// MarshalText encodes the Date as an RFC 3339 full-date. The zero Date is encoded as an empty string.
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+	if !d.IsValid() {
+		return nil, errors.New("the date is not a valid calendar day")
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses an RFC 3339 full-date. An empty string is decoded as the zero Date.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,144 @@
+package timestamp_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestDate(t *testing.T) {
+	t.Run("when a date is created with out of range values it should be normalized", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, timestamp.NewDate(2024, time.October, 32), timestamp.Date{Year: 2024, Month: time.November, Day: 1})
+		assert.Equals(t, timestamp.NewDate(2024, time.March, 0), timestamp.Date{Year: 2024, Month: time.February, Day: 29})
+	})
+
+	t.Run("when a date is taken from a time it should use the day in the time's location", func(t *testing.T) {
+		t.Parallel()
+		tokyo := time.FixedZone("JST", 9*60*60)
+		instant := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+		assert.Equals(t, timestamp.DateOf(instant), timestamp.NewDate(2024, time.January, 1))
+		assert.Equals(t, timestamp.DateOf(instant.In(tokyo)), timestamp.NewDate(2024, time.January, 2))
+	})
+
+	t.Run("when a date is checked for validity it should reject days that do not exist", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, timestamp.Date{Year: 2024, Month: time.February, Day: 29}.IsValid())
+		assert.False(t, timestamp.Date{Year: 2023, Month: time.February, Day: 29}.IsValid())
+		assert.False(t, timestamp.Date{}.IsValid())
+		assert.True(t, timestamp.Date{}.IsZero())
+		assert.False(t, timestamp.NewDate(2024, time.January, 1).IsZero())
+	})
+
+	t.Run("when a date is converted to a time it should be the start of the day in the location", func(t *testing.T) {
+		t.Parallel()
+		est := time.FixedZone("EST", -5*60*60)
+		converted := timestamp.NewDate(2024, time.May, 6).Time(est)
+		assert.Equals(t, converted, time.Date(2024, time.May, 6, 0, 0, 0, 0, est))
+	})
+
+	t.Run("when days are added it should cross month and year boundaries", func(t *testing.T) {
+		t.Parallel()
+		date := timestamp.NewDate(2024, time.December, 31)
+		assert.Equals(t, date.AddDays(1), timestamp.NewDate(2025, time.January, 1))
+		assert.Equals(t, date.AddDays(-366), timestamp.NewDate(2023, time.December, 31))
+		assert.Equals(t, date.AddDate(0, 2, 0), timestamp.NewDate(2025, time.March, 3))
+		assert.Equals(t, date.AddDate(1, 0, 1), timestamp.NewDate(2026, time.January, 1))
+	})
+
+	t.Run("when the days between dates are counted it should ignore daylight saving time", func(t *testing.T) {
+		t.Parallel()
+		start := timestamp.NewDate(2024, time.March, 1)
+		end := timestamp.NewDate(2024, time.April, 1)
+		assert.Equals(t, end.DaysSince(start), 31)
+		assert.Equals(t, start.DaysSince(end), -31)
+		assert.Equals(t, start.DaysSince(start), 0)
+	})
+
+	t.Run("when the days between distant dates are counted it should not saturate", func(t *testing.T) {
+		t.Parallel()
+		start := timestamp.NewDate(1500, time.January, 1)
+		end := timestamp.NewDate(2000, time.January, 1)
+		assert.Equals(t, end.DaysSince(start), 182621)
+		assert.Equals(t, start.DaysSince(end), -182621)
+		assert.Equals(t, timestamp.NewDate(1969, time.December, 31).DaysSince(timestamp.NewDate(1970, time.January, 1)), -1)
+	})
+
+	t.Run("when dates are compared it should order them by year, month, then day", func(t *testing.T) {
+		t.Parallel()
+		date := timestamp.NewDate(2024, time.June, 15)
+		assert.Equals(t, date.Compare(date), 0)
+		assert.Equals(t, date.Compare(timestamp.NewDate(2025, time.January, 1)), -1)
+		assert.Equals(t, date.Compare(timestamp.NewDate(2024, time.May, 30)), 1)
+		assert.Equals(t, date.Compare(timestamp.NewDate(2024, time.June, 16)), -1)
+		assert.True(t, date.Before(date.AddDays(1)))
+		assert.False(t, date.Before(date))
+		assert.True(t, date.After(date.AddDays(-1)))
+		assert.False(t, date.After(date))
+	})
+
+	t.Run("when a date is marshaled to JSON it should be a full-date", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Birthday timestamp.Date `json:"birthday"`
+		}
+		data, err := json.Marshal(testStruct{Birthday: timestamp.NewDate(1999, time.March, 7)})
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `{"birthday":"1999-03-07"}`)
+		var decoded testStruct
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equals(t, decoded.Birthday, timestamp.NewDate(1999, time.March, 7))
+	})
+
+	t.Run("when a zero date is marshaled to JSON it should be an empty string", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Birthday timestamp.Date `json:"birthday"`
+		}
+		data, err := json.Marshal(testStruct{})
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `{"birthday":""}`)
+		decoded := testStruct{Birthday: timestamp.NewDate(1999, time.March, 7)}
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.True(t, decoded.Birthday.IsZero())
+	})
+
+	t.Run("when an invalid date is marshaled it should return an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := json.Marshal(timestamp.Date{Year: 2023, Month: time.February, Day: 29})
+		assert.ErrorPart(t, err, "the date is not a valid calendar day")
+	})
+
+	t.Run("when the text is not a full-date it should fail to unmarshal", func(t *testing.T) {
+		t.Parallel()
+		for _, text := range []string{"2024-02-30", "2024-1-1", "2024-01-01T00:00:00Z"} {
+			var date timestamp.Date
+			assert.ErrorPart(t, date.UnmarshalText([]byte(text)), "failed to parse the date")
+		}
+		_, err := timestamp.ParseDate("nope")
+		assert.ErrorPart(t, err, "failed to parse the date")
+	})
+
+	t.Run("when a date is loaded from the configuration", func(t *testing.T) {
+		type testStruct struct {
+			BillingStart timestamp.Date `config_format:"snake" config_default:"2024-01-01"`
+		}
+
+		t.Run("it should parse the default value", func(t *testing.T) {
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.NoError(t, err)
+			assert.Equals(t, conf.BillingStart, timestamp.NewDate(2024, time.January, 1))
+		})
+
+		t.Run("it should parse the environment variable", func(t *testing.T) {
+			t.Setenv("BILLING_START", "2024-02-15")
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.NoError(t, err)
+			assert.Equals(t, conf.BillingStart, timestamp.NewDate(2024, time.February, 15))
+		})
+	})
+}
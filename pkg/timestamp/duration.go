@@ -0,0 +1,36 @@
+package timestamp
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration is a time.Duration that is encoded as a string like "1h30m" in JSON and text.
+// It can be used in config structs with defaults such as config_default:"30s".
+// Validation rules like gt=0 compare the number of nanoseconds.
+type Duration time.Duration
+
+// Std returns the Duration as a time.Duration.
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// String returns the Duration in the format of time.Duration, for example "1h30m0s".
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText encodes the Duration in the format of time.Duration.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a duration string like "300ms", "1.5h", or "2h45m".
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse the duration (%w)", err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
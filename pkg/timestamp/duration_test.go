@@ -0,0 +1,90 @@
+package timestamp_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/config"
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+	"github.com/TriangleSide/GoTools/pkg/validation"
+)
+
+func TestDuration(t *testing.T) {
+	t.Run("when a duration is marshaled to JSON it should be a duration string", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Timeout timestamp.Duration `json:"timeout"`
+		}
+		data, err := json.Marshal(testStruct{Timeout: timestamp.Duration(90 * time.Minute)})
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `{"timeout":"1h30m0s"}`)
+	})
+
+	t.Run("when a duration string is unmarshaled from JSON it should be parsed", func(t *testing.T) {
+		t.Parallel()
+		var value struct {
+			Timeout timestamp.Duration `json:"timeout"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(`{"timeout":"1h30m"}`), &value))
+		assert.Equals(t, value.Timeout.Std(), 90*time.Minute)
+	})
+
+	t.Run("when the JSON value is not a valid duration it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var value timestamp.Duration
+		assert.ErrorPart(t, json.Unmarshal([]byte(`"forever"`), &value), "failed to parse the duration")
+		assert.NotNil(t, json.Unmarshal([]byte(`1000`), &value))
+	})
+
+	t.Run("when a duration is converted to a string it should use the time.Duration format", func(t *testing.T) {
+		t.Parallel()
+		assert.Equals(t, timestamp.Duration(1500*time.Millisecond).String(), "1.5s")
+		text, err := timestamp.Duration(0).MarshalText()
+		assert.NoError(t, err)
+		assert.Equals(t, string(text), "0s")
+	})
+
+	t.Run("when a duration is used in a config struct", func(t *testing.T) {
+		type testStruct struct {
+			Timeout timestamp.Duration `config_format:"snake" config_default:"30s" validate:"gt=0"`
+		}
+
+		t.Run("when no environment variable is set it should use the default", func(t *testing.T) {
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.NoError(t, err)
+			assert.Equals(t, conf.Timeout.Std(), 30*time.Second)
+		})
+
+		t.Run("when the environment variable is set it should be parsed", func(t *testing.T) {
+			t.Setenv("TIMEOUT", "2m")
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.NoError(t, err)
+			assert.Equals(t, conf.Timeout.Std(), 2*time.Minute)
+		})
+
+		t.Run("when the environment variable is invalid it should return an error", func(t *testing.T) {
+			t.Setenv("TIMEOUT", "soon")
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.ErrorPart(t, err, "failed to parse the duration")
+			assert.Nil(t, conf)
+		})
+
+		t.Run("when the duration fails validation it should return an error", func(t *testing.T) {
+			t.Setenv("TIMEOUT", "-1s")
+			conf, err := config.ProcessAndValidate[testStruct]()
+			assert.ErrorPart(t, err, "validation failed")
+			assert.Nil(t, conf)
+		})
+	})
+
+	t.Run("when a duration is validated it should compare nanoseconds", func(t *testing.T) {
+		t.Parallel()
+		type testStruct struct {
+			Timeout timestamp.Duration `validate:"gte=1000000000"`
+		}
+		assert.NoError(t, validation.Struct(&testStruct{Timeout: timestamp.Duration(time.Second)}))
+		assert.ErrorPart(t, validation.Struct(&testStruct{Timeout: timestamp.Duration(time.Millisecond)}), "validation failed")
+	})
+}
@@ -0,0 +1,215 @@
+package timestamp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock for tests. Its time only moves when Advance or Set is called,
+// and the timers and tickers fire synchronously as the time passes their deadlines.
+type FakeClock struct {
+	lock           sync.Mutex
+	now            time.Time
+	waiters        []*fakeWaiter
+	waitersChanged *sync.Cond
+}
+
+// Ensure FakeClock implements Clock.
+var _ Clock = (*FakeClock)(nil)
+
+// fakeWaiter is a timer or ticker of a FakeClock.
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	period   time.Duration
+	channel  chan time.Time
+}
+
+// NewFakeClock returns a FakeClock that starts at the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	clock := &FakeClock{
+		now: start,
+	}
+	clock.waitersChanged = sync.NewCond(&clock.lock)
+	return clock
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Since returns the fake time elapsed since t.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After sends the fake time on the returned channel once the clock has advanced by the duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a Timer that fires once the clock has advanced by the duration.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	waiter := &fakeWaiter{
+		clock:   c,
+		channel: make(chan time.Time, 1),
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.schedule(waiter, d)
+	return &fakeTimer{waiter: waiter}
+}
+
+// NewTicker creates a Ticker that fires every time the clock advances by the period.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("The ticker period must be greater than zero.")
+	}
+	waiter := &fakeWaiter{
+		clock:   c,
+		period:  d,
+		channel: make(chan time.Time, 1),
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.schedule(waiter, d)
+	return &fakeTicker{waiter: waiter}
+}
+
+// Advance moves the time of the clock forward and fires the timers and tickers whose deadlines have passed.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setTime(c.now.Add(d))
+}
+
+// Set moves the time of the clock to t and fires the timers and tickers whose deadlines have passed.
+// Setting a time in the past does not fire anything.
+func (c *FakeClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setTime(t)
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting on the clock.
+// It is used to make sure a routine is waiting on the clock before advancing it.
+func (c *FakeClock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for len(c.waiters) < n {
+		c.waitersChanged.Wait()
+	}
+}
+
+// Waiters returns the number of timers and tickers that are waiting on the clock.
+func (c *FakeClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.waiters)
+}
+
+// setTime fires the waiters in the order of their deadlines, with the clock at each deadline when it fires.
+// The lock must be held.
+func (c *FakeClock) setTime(t time.Time) {
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].deadline.After(t) {
+			break
+		}
+		waiter := c.waiters[0]
+		if waiter.deadline.After(c.now) {
+			c.now = waiter.deadline
+		}
+		select {
+		case waiter.channel <- c.now:
+		default:
+		}
+		if waiter.period > 0 {
+			waiter.deadline = waiter.deadline.Add(waiter.period)
+		} else {
+			c.unschedule(waiter)
+		}
+	}
+	c.now = t
+}
+
+// schedule adds the waiter with a deadline after the duration. A non-positive duration fires immediately.
+// The lock must be held.
+func (c *FakeClock) schedule(waiter *fakeWaiter, d time.Duration) {
+	waiter.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, waiter)
+	c.setTime(c.now)
+	c.waitersChanged.Broadcast()
+}
+
+// unschedule removes the waiter and returns true if it was scheduled. The lock must be held.
+func (c *FakeClock) unschedule(waiter *fakeWaiter) bool {
+	for i, scheduled := range c.waiters {
+		if scheduled == waiter {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer is a Timer of a FakeClock.
+type fakeTimer struct {
+	waiter *fakeWaiter
+}
+
+// C returns the channel on which the time is delivered.
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.waiter.channel
+}
+
+// Stop prevents the Timer from firing. It returns false if the Timer already fired or was stopped.
+func (t *fakeTimer) Stop() bool {
+	t.waiter.clock.lock.Lock()
+	defer t.waiter.clock.lock.Unlock()
+	return t.waiter.clock.unschedule(t.waiter)
+}
+
+// Reset changes the Timer to expire after the duration. It returns true if the Timer was active.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.waiter.clock.lock.Lock()
+	defer t.waiter.clock.lock.Unlock()
+	active := t.waiter.clock.unschedule(t.waiter)
+	t.waiter.clock.schedule(t.waiter, d)
+	return active
+}
+
+// fakeTicker is a Ticker of a FakeClock.
+type fakeTicker struct {
+	waiter *fakeWaiter
+}
+
+// C returns the channel on which the ticks are delivered.
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.channel
+}
+
+// Stop turns off the Ticker.
+func (t *fakeTicker) Stop() {
+	t.waiter.clock.lock.Lock()
+	defer t.waiter.clock.lock.Unlock()
+	t.waiter.clock.unschedule(t.waiter)
+}
+
+// Reset stops the Ticker and resets its period to the duration.
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("The ticker period must be greater than zero.")
+	}
+	t.waiter.clock.lock.Lock()
+	defer t.waiter.clock.lock.Unlock()
+	t.waiter.clock.unschedule(t.waiter)
+	t.waiter.period = d
+	t.waiter.clock.schedule(t.waiter, d)
+}
@@ -0,0 +1,190 @@
+package timestamp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fired := func(channel <-chan time.Time) (time.Time, bool) {
+		select {
+		case value := <-channel:
+			return value, true
+		default:
+			return time.Time{}, false
+		}
+	}
+
+	t.Run("when the clock is created it should be at the start time until it is moved", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		assert.Equals(t, clock.Now(), start)
+		clock.Advance(time.Minute)
+		assert.Equals(t, clock.Now(), start.Add(time.Minute))
+		assert.Equals(t, clock.Since(start), time.Minute)
+		clock.Set(start.Add(time.Hour))
+		assert.Equals(t, clock.Now(), start.Add(time.Hour))
+	})
+
+	t.Run("when the clock advances past a timer it should fire with the deadline", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+		clock.Advance(time.Second - time.Nanosecond)
+		_, ok := fired(timer.C())
+		assert.False(t, ok)
+		clock.Advance(time.Minute)
+		value, ok := fired(timer.C())
+		assert.True(t, ok)
+		assert.Equals(t, value, start.Add(time.Second))
+		assert.Equals(t, clock.Now(), start.Add(time.Minute+time.Second-time.Nanosecond))
+		assert.False(t, timer.Stop())
+	})
+
+	t.Run("when After is used it should fire once the duration has passed", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		channel := clock.After(time.Second)
+		clock.Advance(time.Second)
+		value, ok := fired(channel)
+		assert.True(t, ok)
+		assert.Equals(t, value, start.Add(time.Second))
+	})
+
+	t.Run("when a timer has a non-positive duration it should fire immediately", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		value, ok := fired(clock.After(0))
+		assert.True(t, ok)
+		assert.Equals(t, value, start)
+	})
+
+	t.Run("when a timer is stopped it should not fire", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+		assert.True(t, timer.Stop())
+		assert.False(t, timer.Stop())
+		clock.Advance(time.Hour)
+		_, ok := fired(timer.C())
+		assert.False(t, ok)
+	})
+
+	t.Run("when a timer is reset it should fire after the new duration", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+		assert.True(t, timer.Reset(time.Minute))
+		clock.Advance(time.Second)
+		_, ok := fired(timer.C())
+		assert.False(t, ok)
+		clock.Advance(time.Minute)
+		value, ok := fired(timer.C())
+		assert.True(t, ok)
+		assert.Equals(t, value, start.Add(time.Minute))
+		assert.False(t, timer.Reset(time.Second))
+		clock.Advance(time.Second)
+		_, ok = fired(timer.C())
+		assert.True(t, ok)
+	})
+
+	t.Run("when timers expire in the same advance it should fire them in the order of their deadlines", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		late := clock.NewTimer(2 * time.Second)
+		early := clock.NewTimer(time.Second)
+		clock.Advance(time.Hour)
+		earlyValue, ok := fired(early.C())
+		assert.True(t, ok)
+		lateValue, ok := fired(late.C())
+		assert.True(t, ok)
+		assert.True(t, earlyValue.Before(lateValue))
+	})
+
+	t.Run("when the clock is set to the past it should not fire anything", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		timer := clock.NewTimer(time.Second)
+		clock.Set(start.Add(-time.Hour))
+		assert.Equals(t, clock.Now(), start.Add(-time.Hour))
+		_, ok := fired(timer.C())
+		assert.False(t, ok)
+	})
+
+	t.Run("when a ticker's period passes it should tick and drop ticks that are not received", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+		clock.Advance(time.Second)
+		value, ok := fired(ticker.C())
+		assert.True(t, ok)
+		assert.Equals(t, value, start.Add(time.Second))
+		clock.Advance(3 * time.Second)
+		value, ok = fired(ticker.C())
+		assert.True(t, ok)
+		assert.Equals(t, value, start.Add(2*time.Second))
+		_, ok = fired(ticker.C())
+		assert.False(t, ok)
+	})
+
+	t.Run("when a ticker is reset it should tick with the new period", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+		ticker.Reset(time.Minute)
+		clock.Advance(time.Second)
+		_, ok := fired(ticker.C())
+		assert.False(t, ok)
+		clock.Advance(time.Minute)
+		_, ok = fired(ticker.C())
+		assert.True(t, ok)
+	})
+
+	t.Run("when a ticker is stopped it should not tick", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+		ticker.Stop()
+		clock.Advance(time.Hour)
+		_, ok := fired(ticker.C())
+		assert.False(t, ok)
+	})
+
+	t.Run("when a ticker has a non-positive period it should panic", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		assert.PanicExact(t, func() {
+			clock.NewTicker(0)
+		}, "The ticker period must be greater than zero.")
+		ticker := clock.NewTicker(time.Second)
+		assert.PanicExact(t, func() {
+			ticker.Reset(-time.Second)
+		}, "The ticker period must be greater than zero.")
+	})
+
+	t.Run("when BlockUntil is called it should wait until enough routines wait on the clock", func(t *testing.T) {
+		t.Parallel()
+		clock := timestamp.NewFakeClock(start)
+		assert.Equals(t, clock.Waiters(), 0)
+		done := make(chan time.Time)
+		for i := 0; i < 2; i++ {
+			go func() {
+				done <- <-clock.After(time.Second)
+			}()
+		}
+		clock.BlockUntil(2)
+		assert.Equals(t, clock.Waiters(), 2)
+		clock.Advance(time.Second)
+		assert.Equals(t, <-done, start.Add(time.Second))
+		assert.Equals(t, <-done, start.Add(time.Second))
+		assert.Equals(t, clock.Waiters(), 0)
+		clock.BlockUntil(0)
+	})
+}
@@ -0,0 +1,77 @@
+package timestamp
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Timestamp is a point in time that is always in UTC.
+// It is encoded in the RFC 3339 format in JSON and text, and can be stored in and loaded from databases.
+type Timestamp struct {
+	time time.Time
+}
+
+// New returns a Timestamp of the time converted to UTC.
+func New(t time.Time) Timestamp {
+	return Timestamp{time: t.UTC()}
+}
+
+// Now returns a Timestamp of the current time.
+func Now() Timestamp {
+	return New(time.Now())
+}
+
+// Time returns the Timestamp as a time.Time in UTC.
+func (ts Timestamp) Time() time.Time {
+	return ts.time
+}
+
+// IsZero returns true if the Timestamp is the zero time.
+func (ts Timestamp) IsZero() bool {
+	return ts.time.IsZero()
+}
+
+// String returns the Timestamp in the RFC 3339 format.
+func (ts Timestamp) String() string {
+	return ts.time.Format(time.RFC3339Nano)
+}
+
+// MarshalText encodes the Timestamp in the RFC 3339 format.
+func (ts Timestamp) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+// UnmarshalText parses an RFC 3339 time and converts it to UTC.
+func (ts *Timestamp) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse(time.RFC3339Nano, string(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse the timestamp (%w)", err)
+	}
+	*ts = New(parsed)
+	return nil
+}
+
+// Value returns the Timestamp as a time.Time in UTC for database drivers.
+func (ts Timestamp) Value() (driver.Value, error) {
+	return ts.time, nil
+}
+
+// Scan loads the Timestamp from a database value.
+// The value can be a time.Time, or an RFC 3339 string or byte slice, and is converted to UTC.
+// A NULL value results in the zero Timestamp.
+func (ts *Timestamp) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*ts = Timestamp{}
+	case time.Time:
+		*ts = New(value)
+	case string:
+		return ts.UnmarshalText([]byte(value))
+	case []byte:
+		return ts.UnmarshalText(value)
+	default:
+		return fmt.Errorf("cannot scan a %T into a Timestamp", src)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package timestamp_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestTimestamp(t *testing.T) {
+	t.Parallel()
+
+	est := time.FixedZone("EST", -5*60*60)
+
+	t.Run("when a timestamp is created it should be in UTC", func(t *testing.T) {
+		t.Parallel()
+		local := time.Date(2024, 1, 2, 3, 4, 5, 0, est)
+		ts := timestamp.New(local)
+		assert.Equals(t, ts.Time().Location(), time.UTC)
+		assert.True(t, ts.Time().Equal(local))
+		assert.Equals(t, timestamp.Now().Time().Location(), time.UTC)
+		assert.False(t, timestamp.Now().IsZero())
+		assert.True(t, timestamp.Timestamp{}.IsZero())
+	})
+
+	t.Run("when a timestamp is marshaled to JSON it should be in the RFC 3339 format", func(t *testing.T) {
+		t.Parallel()
+		ts := timestamp.New(time.Date(2024, 1, 2, 3, 4, 5, 600, est))
+		data, err := json.Marshal(ts)
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `"2024-01-02T08:04:05.0000006Z"`)
+		assert.Equals(t, ts.String(), "2024-01-02T08:04:05.0000006Z")
+	})
+
+	t.Run("when a timestamp is unmarshaled from JSON it should be converted to UTC", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.Timestamp
+		assert.NoError(t, json.Unmarshal([]byte(`"2024-01-02T03:04:05-05:00"`), &ts))
+		assert.Equals(t, ts, timestamp.New(time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC)))
+		assert.ErrorPart(t, json.Unmarshal([]byte(`"yesterday"`), &ts), "failed to parse the timestamp")
+	})
+
+	t.Run("when a timestamp is given to a database driver it should be a UTC time", func(t *testing.T) {
+		t.Parallel()
+		var valuer driver.Valuer = timestamp.New(time.Date(2024, 1, 2, 3, 4, 5, 0, est))
+		value, err := valuer.Value()
+		assert.NoError(t, err)
+		assert.Equals(t, value, driver.Value(time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC)))
+	})
+
+	t.Run("when a timestamp is scanned it should accept times and RFC 3339 text", func(t *testing.T) {
+		t.Parallel()
+		expected := timestamp.New(time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC))
+		for _, src := range []any{
+			time.Date(2024, 1, 2, 3, 4, 5, 0, est),
+			"2024-01-02T03:04:05-05:00",
+			[]byte("2024-01-02T08:04:05Z"),
+		} {
+			var ts timestamp.Timestamp
+			var scanner sql.Scanner = &ts
+			assert.NoError(t, scanner.Scan(src))
+			assert.Equals(t, ts, expected)
+		}
+	})
+
+	t.Run("when NULL is scanned it should be the zero timestamp", func(t *testing.T) {
+		t.Parallel()
+		ts := timestamp.Now()
+		assert.NoError(t, ts.Scan(nil))
+		assert.True(t, ts.IsZero())
+	})
+
+	t.Run("when an unsupported value is scanned it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.Timestamp
+		assert.ErrorExact(t, ts.Scan(123), "cannot scan a int into a Timestamp")
+		assert.ErrorPart(t, ts.Scan("not a time"), "failed to parse the timestamp")
+	})
+}
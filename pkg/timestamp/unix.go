@@ -0,0 +1,99 @@
+package timestamp
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// UnixSeconds is a Timestamp that is encoded as the number of seconds since the Unix epoch.
+// The fractional seconds are dropped when it is encoded.
+type UnixSeconds struct {
+	Timestamp
+}
+
+// NewUnixSeconds returns a UnixSeconds of the time converted to UTC.
+func NewUnixSeconds(t time.Time) UnixSeconds {
+	return UnixSeconds{Timestamp: New(t)}
+}
+
+// MarshalText encodes the number of seconds since the Unix epoch.
+func (ts UnixSeconds) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, ts.time.Unix(), 10), nil
+}
+
+// UnmarshalText parses the number of seconds since the Unix epoch.
+func (ts *UnixSeconds) UnmarshalText(text []byte) error {
+	seconds, err := parseEpoch(text)
+	if err != nil {
+		return err
+	}
+	*ts = NewUnixSeconds(time.Unix(seconds, 0))
+	return nil
+}
+
+// MarshalJSON encodes the number of seconds since the Unix epoch as a JSON number.
+func (ts UnixSeconds) MarshalJSON() ([]byte, error) {
+	return ts.MarshalText()
+}
+
+// UnmarshalJSON parses a JSON number of seconds since the Unix epoch. A JSON null leaves it unchanged.
+func (ts *UnixSeconds) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	return ts.UnmarshalText(data)
+}
+
+// UnixMilliseconds is a Timestamp that is encoded as the number of milliseconds since the Unix epoch.
+// The fractional milliseconds are dropped when it is encoded.
+type UnixMilliseconds struct {
+	Timestamp
+}
+
+// NewUnixMilliseconds returns a UnixMilliseconds of the time converted to UTC.
+func NewUnixMilliseconds(t time.Time) UnixMilliseconds {
+	return UnixMilliseconds{Timestamp: New(t)}
+}
+
+// MarshalText encodes the number of milliseconds since the Unix epoch.
+func (ts UnixMilliseconds) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, ts.time.UnixMilli(), 10), nil
+}
+
+// UnmarshalText parses the number of milliseconds since the Unix epoch.
+func (ts *UnixMilliseconds) UnmarshalText(text []byte) error {
+	milliseconds, err := parseEpoch(text)
+	if err != nil {
+		return err
+	}
+	*ts = NewUnixMilliseconds(time.UnixMilli(milliseconds))
+	return nil
+}
+
+// MarshalJSON encodes the number of milliseconds since the Unix epoch as a JSON number.
+func (ts UnixMilliseconds) MarshalJSON() ([]byte, error) {
+	return ts.MarshalText()
+}
+
+// UnmarshalJSON parses a JSON number of milliseconds since the Unix epoch. A JSON null leaves it unchanged.
+func (ts *UnixMilliseconds) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	return ts.UnmarshalText(data)
+}
+
+// isJSONNull returns true if the data is the JSON null literal. By convention, unmarshaling null is a no-op.
+func isJSONNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+// parseEpoch parses an integer number of units since the Unix epoch.
+func parseEpoch(text []byte) (int64, error) {
+	value, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the epoch time %q (%w)", text, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,98 @@
+package timestamp_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+func TestUnixSeconds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when it is marshaled to JSON it should be a number of seconds", func(t *testing.T) {
+		t.Parallel()
+		ts := timestamp.NewUnixSeconds(time.Date(2024, 1, 2, 3, 4, 5, 999, time.FixedZone("EST", -5*60*60)))
+		assert.Equals(t, ts.Time().Location(), time.UTC)
+		data, err := json.Marshal(struct {
+			CreatedAt timestamp.UnixSeconds `json:"created_at"`
+		}{CreatedAt: ts})
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `{"created_at":1704182645}`)
+	})
+
+	t.Run("when it is unmarshaled from JSON it should be in UTC", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.UnixSeconds
+		assert.NoError(t, json.Unmarshal([]byte(`1704182645`), &ts))
+		assert.Equals(t, ts.Time(), time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC))
+	})
+
+	t.Run("when it is unmarshaled from a JSON null it should be unchanged", func(t *testing.T) {
+		t.Parallel()
+		decoded := struct {
+			CreatedAt timestamp.UnixSeconds  `json:"created_at"`
+			DeletedAt *timestamp.UnixSeconds `json:"deleted_at"`
+		}{CreatedAt: timestamp.NewUnixSeconds(time.Unix(1704182645, 0))}
+		assert.NoError(t, json.Unmarshal([]byte(`{"created_at":null,"deleted_at":null}`), &decoded))
+		assert.Equals(t, decoded.CreatedAt, timestamp.NewUnixSeconds(time.Unix(1704182645, 0)))
+		assert.Nil(t, decoded.DeletedAt)
+	})
+
+	t.Run("when the text is not an integer it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.UnixSeconds
+		assert.ErrorPart(t, json.Unmarshal([]byte(`"1704182645"`), &ts), "failed to parse the epoch time")
+		assert.ErrorPart(t, json.Unmarshal([]byte(`1.5`), &ts), "failed to parse the epoch time")
+	})
+
+	t.Run("when it is used with a database it should use the embedded timestamp", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.UnixSeconds
+		assert.NoError(t, ts.Scan("2024-01-02T08:04:05Z"))
+		value, err := ts.Value()
+		assert.NoError(t, err)
+		assert.Equals(t, value.(time.Time).Unix(), int64(1704182645))
+	})
+}
+
+func TestUnixMilliseconds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when it is marshaled to JSON it should be a number of milliseconds", func(t *testing.T) {
+		t.Parallel()
+		ts := timestamp.NewUnixMilliseconds(time.Date(2024, 1, 2, 8, 4, 5, 123456789, time.UTC))
+		data, err := json.Marshal(ts)
+		assert.NoError(t, err)
+		assert.Equals(t, string(data), `1704182645123`)
+		text, err := ts.MarshalText()
+		assert.NoError(t, err)
+		assert.Equals(t, string(text), "1704182645123")
+	})
+
+	t.Run("when it is unmarshaled from JSON it should be in UTC", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.UnixMilliseconds
+		assert.NoError(t, json.Unmarshal([]byte(`1704182645123`), &ts))
+		assert.Equals(t, ts.Time(), time.Date(2024, 1, 2, 8, 4, 5, 123000000, time.UTC))
+	})
+
+	t.Run("when it is unmarshaled from a JSON null it should be unchanged", func(t *testing.T) {
+		t.Parallel()
+		decoded := struct {
+			CreatedAt timestamp.UnixMilliseconds  `json:"created_at"`
+			DeletedAt *timestamp.UnixMilliseconds `json:"deleted_at"`
+		}{CreatedAt: timestamp.NewUnixMilliseconds(time.UnixMilli(1704182645123))}
+		assert.NoError(t, json.Unmarshal([]byte(`{"created_at":null,"deleted_at":null}`), &decoded))
+		assert.Equals(t, decoded.CreatedAt, timestamp.NewUnixMilliseconds(time.UnixMilli(1704182645123)))
+		assert.Nil(t, decoded.DeletedAt)
+	})
+
+	t.Run("when the text is not an integer it should return an error", func(t *testing.T) {
+		t.Parallel()
+		var ts timestamp.UnixMilliseconds
+		assert.ErrorPart(t, ts.UnmarshalText([]byte("soon")), `failed to parse the epoch time "soon"`)
+	})
+}
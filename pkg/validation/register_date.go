@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+)
+
+const (
+	DateAfterValidatorName  Validator = "date_after"
+	DateBeforeValidatorName Validator = "date_before"
+)
+
+// init registers the date validators.
+func init() {
+	registerDateComparisonValidation(DateAfterValidatorName, timestamp.Date.After, "after")
+	registerDateComparisonValidation(DateBeforeValidatorName, timestamp.Date.Before, "before")
+}
+
+// registerDateComparisonValidation registers a validator that compares a timestamp.Date against the full-date in the parameters.
+func registerDateComparisonValidation(name Validator, compareFunc func(timestamp.Date, timestamp.Date) bool, operator string) {
+	MustRegisterValidator(name, func(params *CallbackParameters) *CallbackResult {
+		result := NewCallbackResult()
+
+		threshold, err := timestamp.ParseDate(params.Parameters)
+		if err != nil {
+			return result.WithError(fmt.Errorf("invalid parameters '%s' for %s: %w", params.Parameters, name, err))
+		}
+
+		value, err := DereferenceAndNilCheck(params.Value)
+		if err != nil {
+			return result.WithError(NewViolation(params, err))
+		}
+		if value.Type() != reflect.TypeFor[timestamp.Date]() {
+			return result.WithError(NewViolation(params, fmt.Errorf("the %s validation is not supported for type %s", name, value.Type())))
+		}
+
+		date := value.Interface().(timestamp.Date)
+		if !compareFunc(date, threshold) {
+			return result.WithError(NewViolation(params, fmt.Errorf("the date %s must be %s %s", date, operator, threshold)))
+		}
+
+		return nil
+	})
+}
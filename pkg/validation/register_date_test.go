@@ -0,0 +1,56 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TriangleSide/GoTools/pkg/test/assert"
+	"github.com/TriangleSide/GoTools/pkg/timestamp"
+	"github.com/TriangleSide/GoTools/pkg/validation"
+)
+
+func TestDateValidators(t *testing.T) {
+	t.Parallel()
+
+	type testStruct struct {
+		Day timestamp.Date `validate:"required,date_after=1900-01-01,date_before=2100-01-01"`
+	}
+
+	t.Run("when the date is inside the bounds it should pass", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, validation.Struct(&testStruct{Day: timestamp.NewDate(2000, time.January, 1)}))
+	})
+
+	t.Run("when the date is outside the bounds it should fail", func(t *testing.T) {
+		t.Parallel()
+		err := validation.Struct(&testStruct{Day: timestamp.NewDate(1900, time.January, 1)})
+		assert.ErrorPart(t, err, "the date 1900-01-01 must be after 1900-01-01")
+		err = validation.Struct(&testStruct{Day: timestamp.NewDate(2100, time.January, 1)})
+		assert.ErrorPart(t, err, "the date 2100-01-01 must be before 2100-01-01")
+	})
+
+	t.Run("when the date is not set it should fail", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorPart(t, validation.Struct(&testStruct{}), "the value is the zero-value")
+	})
+
+	t.Run("when the date is a pointer it should be dereferenced", func(t *testing.T) {
+		t.Parallel()
+		date := timestamp.NewDate(2000, time.January, 1)
+		assert.NoError(t, validation.Var(&date, "date_after=1999-12-31"))
+		var missing *timestamp.Date
+		assert.ErrorPart(t, validation.Var(missing, "date_after=1999-12-31"), "validation failed")
+	})
+
+	t.Run("when the parameters are not a full-date it should fail", func(t *testing.T) {
+		t.Parallel()
+		err := validation.Var(timestamp.NewDate(2000, time.January, 1), "date_after=yesterday")
+		assert.ErrorPart(t, err, "invalid parameters 'yesterday' for date_after")
+	})
+
+	t.Run("when the value is not a date it should fail", func(t *testing.T) {
+		t.Parallel()
+		err := validation.Var("2000-01-01", "date_before=2100-01-01")
+		assert.ErrorPart(t, err, "the date_before validation is not supported for type string")
+	})
+}
@@ -257,3 +257,18 @@ func TestValidation(t *testing.T) {
 		assert.ErrorPart(t, Var(value, "required"), "cycle found in the validation")
 	})
 }
+
+func BenchmarkStruct(b *testing.B) {
+	type testStruct struct {
+		Name  string `validate:"required"`
+		Count int    `validate:"gte=0,lte=10"`
+	}
+
+	b.ReportAllocs()
+	value := &testStruct{Name: "name", Count: 5}
+	for i := 0; i < b.N; i++ {
+		if err := Struct(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}